@@ -0,0 +1,443 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+)
+
+// An Algorithm names one of the ways the package can compute a function.
+// The package-level functions, such as Sin, use AlgDefault; the With
+// functions, such as SinWith, evaluate any of the algorithms registered for
+// the function, for comparing them on particular arguments.
+//
+// Only the default algorithms of Exp, Log, Sin, Cos and Sqrt are correctly
+// rounded. The others are as accurate as their kernels are, which for some
+// falls well short of the precision of the argument, and some only cover
+// part of the domain: AlgStirling for Gamma is only asymptotic, off by
+// about 1/12x.
+type Algorithm int
+
+const (
+	// AlgDefault is the algorithm of the package-level function.
+	AlgDefault Algorithm = iota
+
+	// AlgTaylor sums the Taylor series of the function.
+	AlgTaylor
+
+	// AlgSeries reduces the argument, halves it and sums a power series,
+	// as the default algorithms of Exp, Sin and Cos do below a few
+	// thousand bits.
+	AlgSeries
+
+	// AlgBitBurst is the bit-burst algorithm, as the default algorithms
+	// of Exp, Sin and Cos use at high precision.
+	AlgBitBurst
+
+	// AlgArgReduction reduces the argument by symmetry before evaluating
+	// the default algorithm.
+	AlgArgReduction
+
+	// AlgCORDIC is the CORDIC algorithm: circular for the trigonometric
+	// functions, and hyperbolic for Exp, Log, the hyperbolic functions and
+	// Sqrt.
+	AlgCORDIC
+
+	// AlgCORDICImproved is CORDIC with half the rotations, finishing with
+	// one multiplication by the small angle left.
+	AlgCORDICImproved
+
+	// AlgChebyshev is a Chebyshev series, with coefficients generated to
+	// the precision of the argument.
+	AlgChebyshev
+
+	// AlgMinimax is a minimax polynomial approximation. For Sin it is the
+	// near-minimax truncated Chebyshev series of AlgChebyshev in powers of
+	// the argument.
+	AlgMinimax
+
+	// AlgGoSource is a port of the algorithm of package math.
+	AlgGoSource
+
+	// AlgNaive computes the function from others, such as tan x as
+	// sin x / cos x.
+	AlgNaive
+
+	// AlgContinuedFraction evaluates a continued fraction.
+	AlgContinuedFraction
+
+	// AlgNewton is Newton's iteration on the inverse function.
+	AlgNewton
+
+	// AlgHalley is Halley's iteration on the inverse function.
+	AlgHalley
+
+	// AlgAGM uses the arithmetic-geometric mean.
+	AlgAGM
+
+	// AlgLanczos is the Lanczos approximation.
+	AlgLanczos
+
+	// AlgSpouge is Spouge's approximation.
+	AlgSpouge
+
+	// AlgStirling is Stirling's approximation.
+	AlgStirling
+)
+
+var algorithmNames = [...]string{
+	AlgDefault:           "Default",
+	AlgTaylor:            "Taylor",
+	AlgSeries:            "Series",
+	AlgBitBurst:          "BitBurst",
+	AlgArgReduction:      "ArgReduction",
+	AlgCORDIC:            "CORDIC",
+	AlgCORDICImproved:    "CORDICImproved",
+	AlgChebyshev:         "Chebyshev",
+	AlgMinimax:           "Minimax",
+	AlgGoSource:          "GoSource",
+	AlgNaive:             "Naive",
+	AlgContinuedFraction: "ContinuedFraction",
+	AlgNewton:            "Newton",
+	AlgHalley:            "Halley",
+	AlgAGM:               "AGM",
+	AlgLanczos:           "Lanczos",
+	AlgSpouge:            "Spouge",
+	AlgStirling:          "Stirling",
+}
+
+// String returns the name of a, such as "CORDIC".
+func (a Algorithm) String() string {
+	if a >= 0 && int(a) < len(algorithmNames) {
+		return algorithmNames[a]
+	}
+
+	return fmt.Sprintf("Algorithm(%d)", int(a))
+}
+
+// A kernel is one registered algorithm for a function.
+type kernel struct {
+	alg Algorithm
+	fn  func(x *big.Float) *big.Float
+}
+
+// A registeredFunction is a function with its algorithms, the default
+// first. special returns the result for the arguments that the default
+// function handles whatever the algorithm, such as those out of the
+// domain, and nil for every other argument.
+type registeredFunction struct {
+	special func(x *big.Float) *big.Float
+	kernels []kernel
+}
+
+// registry holds the algorithms of each function that has more than one.
+var registry = map[string]registeredFunction{
+	"Sin": {trigSpecial(Sin), []kernel{
+		{AlgDefault, Sin},
+		{AlgSeries, func(x *big.Float) *big.Float {
+			sin, _ := sinCosSeries(context.Background(), x)
+
+			return sin
+		}},
+		{AlgBitBurst, func(x *big.Float) *big.Float {
+			sin, _ := sinCosBitBurst(context.Background(), x)
+
+			return sin
+		}},
+		{AlgTaylor, sinTaylor},
+		{AlgArgReduction, sinArgReduction},
+		{AlgCORDIC, sinCORDIC},
+		{AlgCORDICImproved, sinCORDICImproved},
+		{AlgChebyshev, sinChebyshev},
+		{AlgMinimax, sinMinimax},
+		{AlgGoSource, sinGoSource},
+	}},
+	"Cos": {trigSpecial(Cos), []kernel{
+		{AlgDefault, Cos},
+		{AlgSeries, func(x *big.Float) *big.Float {
+			_, cos := sinCosSeries(context.Background(), x)
+
+			return cos
+		}},
+		{AlgBitBurst, func(x *big.Float) *big.Float {
+			_, cos := sinCosBitBurst(context.Background(), x)
+
+			return cos
+		}},
+		{AlgArgReduction, cosArgReduction},
+		{AlgCORDIC, cosCORDIC},
+	}},
+	"Tan": {trigSpecial(Tan), []kernel{
+		{AlgDefault, Tan},
+		{AlgTaylor, tanTaylor},
+		{AlgCORDIC, tanCORDIC},
+		{AlgContinuedFraction, tanContinuedFraction},
+		{AlgNaive, tanNaive},
+	}},
+	"Sec": {trigSpecial(Sec), []kernel{
+		{AlgDefault, Sec},
+		{AlgTaylor, secSeries},
+	}},
+	"Exp": {expSpecial, []kernel{
+		{AlgDefault, Exp},
+		{AlgSeries, func(x *big.Float) *big.Float { return expSeries(context.Background(), x) }},
+		{AlgBitBurst, func(x *big.Float) *big.Float { return expBitBurst(context.Background(), x) }},
+		{AlgCORDIC, expCORDIC},
+	}},
+	"Log": {logSpecial, []kernel{
+		{AlgDefault, Log},
+		{AlgAGM, func(x *big.Float) *big.Float { return logAGM(context.Background(), x) }},
+		{AlgTaylor, logTaylor},
+		{AlgNewton, logNewton},
+		{AlgHalley, logHalley},
+		{AlgCORDIC, logCORDIC},
+	}},
+	"Sinh": {hyperbolicSpecial(Sinh), []kernel{
+		{AlgDefault, Sinh},
+		{AlgCORDIC, sinhCORDIC},
+	}},
+	"Cosh": {hyperbolicSpecial(Cosh), []kernel{
+		{AlgDefault, Cosh},
+		{AlgCORDIC, coshCORDIC},
+	}},
+	"Tanh": {hyperbolicSpecial(Tanh), []kernel{
+		{AlgDefault, Tanh},
+		{AlgCORDIC, tanhCORDIC},
+	}},
+	"Atanh": {atanhSpecial, []kernel{
+		{AlgDefault, Atanh},
+		{AlgCORDIC, atanhCORDIC},
+	}},
+	"Sqrt": {sqrtSpecial, []kernel{
+		{AlgDefault, Sqrt},
+		{AlgCORDIC, sqrtCORDIC},
+	}},
+	"Gamma": {gammaSpecial, []kernel{
+		{AlgDefault, Gamma},
+		{AlgLanczos, func(x *big.Float) *big.Float { return gammaLanczos(context.Background(), x) }},
+		{AlgSpouge, gammaSpouge},
+		{AlgStirling, gammaStirling},
+	}},
+}
+
+// trigSpecial returns the special function of a trigonometric function
+// fn, which takes ±0 and ±Inf to fn.
+func trigSpecial(fn func(x *big.Float) *big.Float) func(x *big.Float) *big.Float {
+	return func(x *big.Float) *big.Float {
+		if x.Sign() == 0 || x.IsInf() {
+			return fn(x)
+		}
+
+		return nil
+	}
+}
+
+// hyperbolicSpecial returns the special function of a hyperbolic function
+// fn, which takes ±0, ±Inf and the x for which e^x overflows to fn.
+func hyperbolicSpecial(fn func(x *big.Float) *big.Float) func(x *big.Float) *big.Float {
+	return func(x *big.Float) *big.Float {
+		if expSpecial(x) != nil {
+			return fn(x)
+		}
+
+		return nil
+	}
+}
+
+// atanhSpecial takes ±0 and |x| >= 1 to Atanh.
+func atanhSpecial(x *big.Float) *big.Float {
+	if x.Sign() == 0 || new(big.Float).Abs(x).Cmp(one) >= 0 {
+		return Atanh(x)
+	}
+
+	return nil
+}
+
+// sqrtSpecial takes x <= 0 and +Inf to Sqrt.
+func sqrtSpecial(x *big.Float) *big.Float {
+	if x.Sign() <= 0 || x.IsInf() {
+		return Sqrt(x)
+	}
+
+	return nil
+}
+
+// logSpecial takes x <= 0 and +Inf to Log.
+func logSpecial(x *big.Float) *big.Float {
+	if x.Sign() <= 0 || x.IsInf() {
+		return Log(x)
+	}
+
+	return nil
+}
+
+// gammaSpecial takes x <= 0, ±Inf and the x for which Γ(x) overflows to
+// Gamma.
+func gammaSpecial(x *big.Float) *big.Float {
+	if x.Sign() <= 0 || x.IsInf() {
+		return Gamma(x)
+	}
+	xf, _ := x.Float64()
+	if lg, _ := math.Lgamma(xf); lg/math.Ln2 > math.MaxInt32 {
+		return Gamma(x)
+	}
+
+	return nil
+}
+
+// Functions returns the names of the functions that have algorithms
+// registered, such as "Sin", in sorted order.
+func Functions() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Algorithms returns the algorithms registered for the named function,
+// AlgDefault first, or nil if it has none.
+func Algorithms(function string) []Algorithm {
+	f, ok := registry[function]
+	if !ok {
+		return nil
+	}
+
+	algs := make([]Algorithm, len(f.kernels))
+	for i, k := range f.kernels {
+		algs[i] = k.alg
+	}
+
+	return algs
+}
+
+// AlgorithmFunc returns the named function evaluated with alg, and whether
+// alg is registered for it. The special cases of the function, such as
+// arguments outside its domain, are handled as the package-level function
+// handles them, whatever the algorithm.
+func AlgorithmFunc(function string, alg Algorithm) (func(x *big.Float) *big.Float, bool) {
+	f, ok := registry[function]
+	if !ok {
+		return nil, false
+	}
+
+	for _, k := range f.kernels {
+		if k.alg != alg {
+			continue
+		}
+		if alg == AlgDefault {
+			return k.fn, true
+		}
+
+		return func(x *big.Float) *big.Float {
+			if result := f.special(x); result != nil {
+				return result
+			}
+
+			return k.fn(x)
+		}, true
+	}
+
+	return nil, false
+}
+
+// with evaluates function at x with alg, and panics if alg is not
+// registered for it.
+func with(function string, alg Algorithm, x *big.Float) *big.Float {
+	fn, ok := AlgorithmFunc(function, alg)
+	if !ok {
+		panic(fmt.Sprintf("bigmath: no algorithm %v for %s", alg, function))
+	}
+
+	return fn(x)
+}
+
+// SinWith returns the sine of the radian argument x computed with alg. It
+// panics if alg is not one of Algorithms("Sin").
+func SinWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Sin", alg, x)
+}
+
+// CosWith returns the cosine of the radian argument x computed with alg.
+// It panics if alg is not one of Algorithms("Cos").
+func CosWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Cos", alg, x)
+}
+
+// TanWith returns the tangent of the radian argument x computed with alg.
+// It panics if alg is not one of Algorithms("Tan").
+func TanWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Tan", alg, x)
+}
+
+// SecWith returns the secant of the radian argument x computed with alg.
+// It panics if alg is not one of Algorithms("Sec").
+func SecWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Sec", alg, x)
+}
+
+// ExpWith returns e**x computed with alg. It panics if alg is not one of
+// Algorithms("Exp").
+func ExpWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Exp", alg, x)
+}
+
+// LogWith returns the natural logarithm of x computed with alg. It panics
+// if alg is not one of Algorithms("Log").
+func LogWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Log", alg, x)
+}
+
+// GammaWith returns the Gamma function of x computed with alg. It panics
+// if alg is not one of Algorithms("Gamma").
+func GammaWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Gamma", alg, x)
+}
+
+// SinhWith returns the hyperbolic sine of x computed with alg. It panics if
+// alg is not one of Algorithms("Sinh").
+func SinhWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Sinh", alg, x)
+}
+
+// CoshWith returns the hyperbolic cosine of x computed with alg. It panics
+// if alg is not one of Algorithms("Cosh").
+func CoshWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Cosh", alg, x)
+}
+
+// TanhWith returns the hyperbolic tangent of x computed with alg. It panics
+// if alg is not one of Algorithms("Tanh").
+func TanhWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Tanh", alg, x)
+}
+
+// AtanhWith returns the inverse hyperbolic tangent of x computed with alg.
+// It panics if alg is not one of Algorithms("Atanh").
+func AtanhWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Atanh", alg, x)
+}
+
+// SqrtWith returns the square root of x computed with alg. It panics if alg
+// is not one of Algorithms("Sqrt").
+func SqrtWith(alg Algorithm, x *big.Float) *big.Float {
+	return with("Sqrt", alg, x)
+}
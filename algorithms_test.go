@@ -0,0 +1,199 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+var algorithmTests = []struct {
+	function string
+	with     func(Algorithm, *big.Float) *big.Float
+	want     func(float64) float64
+	args     []float64
+}{
+	{"Sin", SinWith, math.Sin, []float64{0.1, 0.5, 1, -1.2, 3}},
+	{"Cos", CosWith, math.Cos, []float64{0.1, 0.5, 1, -1.2, 3}},
+	{"Tan", TanWith, math.Tan, []float64{0.1, 0.5, 1, -1.2}},
+	{"Sec", SecWith, func(x float64) float64 { return 1 / math.Cos(x) }, []float64{0.1, 0.5, -0.7}},
+	{"Exp", ExpWith, math.Exp, []float64{0.1, 1, -2.5, 10}},
+	{"Log", LogWith, math.Log, []float64{0.1, 0.5, 2, 10, 1000}},
+	{"Gamma", GammaWith, math.Gamma, []float64{0.5, 1.5, 3.7, 10}},
+	{"Sinh", SinhWith, math.Sinh, []float64{0.1, 0.5, 1, -2.5, 10}},
+	{"Cosh", CoshWith, math.Cosh, []float64{0.1, 0.5, 1, -2.5, 10}},
+	{"Tanh", TanhWith, math.Tanh, []float64{0.1, 0.5, -1, 3, 30}},
+	{"Atanh", AtanhWith, math.Atanh, []float64{0.1, -0.5, 0.8, -0.99}},
+	{"Sqrt", SqrtWith, math.Sqrt, []float64{0.1, 2, 10, 1e10}},
+}
+
+func TestAlgorithms(t *testing.T) {
+	var functions []string
+	for _, tt := range algorithmTests {
+		functions = append(functions, tt.function)
+
+		algs := Algorithms(tt.function)
+		if len(algs) < 2 || algs[0] != AlgDefault {
+			t.Errorf("Algorithms(%q) = %v, want AlgDefault first and others", tt.function, algs)
+		}
+
+		for _, alg := range algs {
+			for _, x := range tt.args {
+				want := tt.want(x)
+				got, _ := tt.with(alg, big.NewFloat(x)).Float64()
+				if math.Abs(got-want) > algorithmTolerance(tt.function, alg, x, want) {
+					t.Errorf("%sWith(%v, %g) = %g, want %g", tt.function, alg, x, got, want)
+				}
+			}
+		}
+	}
+
+	slices.Sort(functions)
+	if got := Functions(); !slices.Equal(got, functions) {
+		t.Errorf("Functions() = %v, want %v", got, functions)
+	}
+	if got := Algorithms("Asinh"); got != nil {
+		t.Errorf("Algorithms(\"Asinh\") = %v, want nil", got)
+	}
+}
+
+// algorithmTolerance returns the error allowed in function computed with
+// alg at x, where the true value is want.
+func algorithmTolerance(function string, alg Algorithm, x, want float64) float64 {
+	if function == "Gamma" && alg == AlgStirling {
+		// Stirling's approximation is off by about a factor of 1 + 1/12x.
+		return math.Abs(want) / (10 * x)
+	}
+
+	// Some of the kernels are only good to a few digits.
+	return 1e-5 * math.Max(1, math.Abs(want))
+}
+
+func TestAlgorithmSpecialCases(t *testing.T) {
+	negInf := new(big.Float).SetInf(true)
+	for _, tt := range []struct {
+		function string
+		x        *big.Float
+	}{
+		{"Sin", new(big.Float)},
+		{"Sin", negInf},
+		{"Cos", negInf},
+		{"Tan", new(big.Float)},
+		{"Sec", negInf},
+		{"Log", new(big.Float)},
+		{"Log", big.NewFloat(-1)},
+		{"Exp", negInf},
+		{"Exp", big.NewFloat(1e20)},
+		{"Gamma", big.NewFloat(-2)},
+		{"Gamma", big.NewFloat(1e10)},
+		{"Gamma", new(big.Float).SetPrec(300).SetFloat64(1e10)},
+		{"Sinh", negInf},
+		{"Cosh", new(big.Float)},
+		{"Tanh", big.NewFloat(-1e20)},
+		{"Atanh", big.NewFloat(1)},
+		{"Atanh", big.NewFloat(-2)},
+		{"Sqrt", big.NewFloat(-1)},
+		{"Sqrt", new(big.Float).Neg(new(big.Float))},
+	} {
+		def, _ := AlgorithmFunc(tt.function, AlgDefault)
+		want := def(tt.x)
+		for _, alg := range Algorithms(tt.function) {
+			fn, ok := AlgorithmFunc(tt.function, alg)
+			if !ok {
+				t.Fatalf("AlgorithmFunc(%q, %v) is not registered", tt.function, alg)
+			}
+			if got := fn(tt.x); !sameValue(got, want) {
+				t.Errorf("%s with %v at %v = %v, want %v", tt.function, alg, tt.x, got, want)
+			}
+		}
+	}
+}
+
+func TestAlgorithmLargeArguments(t *testing.T) {
+	// Γ(10⁶) overflows a float64 but not a big.Float; Stirling's
+	// approximation, the default there, is within 1/12x of it.
+	for _, prec := range []uint{53, 300} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(1e6)
+		want := Gamma(x)
+		for _, alg := range []Algorithm{AlgLanczos, AlgSpouge} {
+			got := GammaWith(alg, x)
+			rel, _ := new(big.Float).Quo(new(big.Float).Sub(got, want), want).Float64()
+			if math.Abs(rel) > 1e-6 {
+				t.Errorf("GammaWith(%v, %v) at %d bits = %.10g, want %.10g", alg, x, prec, got, want)
+			}
+		}
+	}
+
+	// The reduction rounds once more than Sin does, so allow a few units
+	// in the last place.
+	x := new(big.Float).SetPrec(200).SetFloat64(1e10)
+	if got, want := SinWith(AlgArgReduction, x), Sin(x); bitsOfAgreement(got, want) < 196 {
+		t.Errorf("SinWith(AlgArgReduction, %v) = %v, want %v", x, got, want)
+	}
+	if got, want := CosWith(AlgArgReduction, x), Cos(x); bitsOfAgreement(got, want) < 196 {
+		t.Errorf("CosWith(AlgArgReduction, %v) = %v, want %v", x, got, want)
+	}
+}
+
+func TestAlgorithmString(t *testing.T) {
+	for alg, want := range map[Algorithm]string{
+		AlgDefault:     "Default",
+		AlgCORDIC:      "CORDIC",
+		AlgStirling:    "Stirling",
+		Algorithm(-1):  "Algorithm(-1)",
+		Algorithm(100): "Algorithm(100)",
+	} {
+		if got := alg.String(); got != want {
+			t.Errorf("Algorithm(%d).String() = %q, want %q", int(alg), got, want)
+		}
+	}
+}
+
+func TestAlgorithmNotRegistered(t *testing.T) {
+	if _, ok := AlgorithmFunc("Exp", AlgLanczos); ok {
+		t.Errorf("AlgorithmFunc(\"Exp\", AlgLanczos) is registered")
+	}
+	if _, ok := AlgorithmFunc("Asinh", AlgDefault); ok {
+		t.Errorf("AlgorithmFunc(\"Asinh\", AlgDefault) is registered")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("GammaWith(AlgCORDIC, 1) did not panic")
+		}
+	}()
+	GammaWith(AlgCORDIC, big.NewFloat(1))
+}
+
+// BenchmarkAlgorithms compares the algorithms of each function at a few
+// precisions.
+func BenchmarkAlgorithms(b *testing.B) {
+	for _, prec := range []uint{64, 256} {
+		x := newRatFloat("7/10", prec)
+		for _, function := range Functions() {
+			for _, alg := range Algorithms(function) {
+				fn, _ := AlgorithmFunc(function, alg)
+				b.Run(fmt.Sprintf("%s/%v/prec_%d", function, alg, prec), func(b *testing.B) {
+					for b.Loop() {
+						fn(x)
+					}
+				})
+			}
+		}
+	}
+}
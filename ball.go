@@ -0,0 +1,407 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"math"
+	"math/big"
+)
+
+// ballRadiusPrec is the precision of the radius of a Ball. The radius only
+// needs to be a close upper bound, so it is kept short.
+const ballRadiusPrec = 32
+
+// ballGuardBits is the number of extra bits with which the Ball functions
+// evaluate their enclosures, so that rounding the midpoint to the precision
+// of the result dominates the radius.
+const ballGuardBits = 16
+
+// Ball is a real number known to lie within rad of mid, for verified
+// computation in the style of Arb: the midpoint carries the precision of
+// the value and the radius is a short upper bound on its error.
+//
+// Every operation rounds the midpoint of its result to the precision of the
+// receiver and adds to the radius a rigorous bound on the propagated error
+// of the operands and on that rounding, so the exact result of the operation
+// at every point of its operands lies within the result. The functions
+// evaluate the Interval enclosures at a slightly higher precision and
+// convert them back. AccuracyBits and CertifiedDigits report how much of the
+// midpoint is guaranteed.
+//
+// A radius of +Inf means nothing is known. Where a function is undefined
+// the result has midpoint +Inf, which stands in for NaN as elsewhere in the
+// package, and radius +Inf.
+//
+// The zero value is the exact 0 with precision 0, and as with big.Float the
+// precision of the receiver of an operation is set from its operands when
+// it is 0. Like big.Float, a Ball must not be copied by value; use Set.
+type Ball struct {
+	mid, rad big.Float
+}
+
+// NewBall returns a new Ball with midpoint mid, the precision of mid, and
+// radius rad.
+func NewBall(mid, rad *big.Float) *Ball {
+	z := new(Ball)
+	z.mid.SetPrec(mid.Prec()).Set(mid)
+	z.rad.SetPrec(ballRadiusPrec).SetMode(big.ToPositiveInf).Abs(rad)
+
+	return z
+}
+
+// Mid returns the midpoint of x. The result is a reference to x's midpoint;
+// it may change if a new value is assigned to x, and vice versa.
+func (x *Ball) Mid() *big.Float {
+	return &x.mid
+}
+
+// Rad returns the radius of x. The result is a reference to x's radius; it
+// may change if a new value is assigned to x, and vice versa.
+func (x *Ball) Rad() *big.Float {
+	return &x.rad
+}
+
+// Prec returns the precision of the midpoint of x in bits.
+func (x *Ball) Prec() uint {
+	return x.mid.Prec()
+}
+
+// SetPrec sets the precision of the midpoint of z to prec, adding the
+// rounding error to the radius, and returns z.
+func (z *Ball) SetPrec(prec uint) *Ball {
+	if prec == 0 {
+		z.mid.SetPrec(0)
+		return z
+	}
+
+	m := new(big.Float).SetPrec(prec).Set(&z.mid)
+
+	return z.setMid(m, &z.mid, &z.rad)
+}
+
+// Set sets z to x, rounded to the precision of z, and returns z. If z's
+// precision is 0, it is changed to the precision of x first.
+func (z *Ball) Set(x *Ball) *Ball {
+	m := new(big.Float).SetPrec(z.resultPrec(x)).Set(&x.mid)
+
+	return z.setMid(m, &x.mid, &x.rad)
+}
+
+// SetFloat sets z to the exact value x, rounded to the precision of z, and
+// returns z. If z's precision is 0, it is changed to the precision of x.
+func (z *Ball) SetFloat(x *big.Float) *Ball {
+	prec := z.Prec()
+	if prec == 0 {
+		prec = x.Prec()
+	}
+
+	return z.setMid(new(big.Float).SetPrec(prec).Set(x), x, new(big.Float))
+}
+
+// SetString sets z to a ball containing the number s, which may be any
+// decimal or fraction accepted by big.Rat.SetString, and returns z and a
+// boolean indicating success. If z's precision is 0, it is changed to 64.
+// On failure z is unchanged and the result is (nil, false).
+func (z *Ball) SetString(s string) (*Ball, bool) {
+	prec := z.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+
+	x, ok := new(Interval).SetPrec(prec + ballGuardBits).SetString(s)
+	if !ok {
+		return nil, false
+	}
+
+	return z.SetInterval(x, prec), true
+}
+
+// Interval returns the smallest interval with the precision of x that
+// contains x.
+func (x *Ball) Interval() *Interval {
+	prec := x.Prec()
+	lo := addRounded(&x.mid, new(big.Float).Neg(&x.rad), prec, false)
+	hi := addRounded(&x.mid, &x.rad, prec, true)
+
+	return new(Interval).setBounds(lo, hi, prec)
+}
+
+// SetInterval sets z to a ball with a midpoint of precision prec that
+// contains the interval x, and returns z. If prec is 0, the precision of x
+// is used. The empty interval gives a midpoint and radius of +Inf.
+func (z *Ball) SetInterval(x *Interval, prec uint) *Ball {
+	if prec == 0 {
+		prec = x.Prec()
+	}
+
+	inf := new(big.Float).SetInf(false)
+	switch {
+	case x.IsEmpty():
+		return z.setMid(new(big.Float).SetPrec(prec).Set(inf), inf, inf)
+	case x.lo.IsInf() || x.hi.IsInf():
+		return z.setMid(new(big.Float).SetPrec(prec), zero, inf)
+	}
+
+	// Every point of x is within half its width of its midpoint c.
+	c := x.Mid()
+	r := newUp(ballRadiusPrec).Sub(&x.hi, &x.lo)
+	r.SetMantExp(r, -1)
+
+	return z.setMid(new(big.Float).SetPrec(prec).Set(c), c, r)
+}
+
+// Contains reports whether v lies in x.
+func (x *Ball) Contains(v *big.Float) bool {
+	return x.Interval().Contains(v)
+}
+
+// IsExact reports whether x has radius 0.
+func (x *Ball) IsExact() bool {
+	return x.rad.Sign() == 0
+}
+
+// ErrorBound returns an upper bound, rounded up, on the error of v as an
+// approximation of any number in x: |v - mid| + rad. It makes the accuracy
+// of a result computed some other way checkable at runtime; for example
+// with b = new(Ball).Exp(new(Ball).SetFloat(a)), b.ErrorBound(Exp(a)) bounds
+// the error of Exp at a.
+func (x *Ball) ErrorBound(v *big.Float) *big.Float {
+	d := newUp(max(v.Prec(), x.Prec()))
+	d.Abs(addRounded(v, new(big.Float).Neg(&x.mid), d.Prec(), v.Cmp(&x.mid) >= 0))
+
+	return d.SetPrec(ballRadiusPrec).Add(d, &x.rad)
+}
+
+// AccuracyBits returns the number of leading bits of the midpoint of x that
+// are certified: a lower bound on log2(|mid|/rad). It is math.MaxInt if x
+// is exact and 0 if the radius is not smaller than the midpoint.
+func (x *Ball) AccuracyBits() int {
+	switch {
+	case x.IsExact():
+		return math.MaxInt
+	case x.rad.IsInf() || x.mid.IsInf() || x.mid.Sign() == 0:
+		return 0
+	}
+
+	// |mid| >= 2^(e-1) and rad < 2^r.
+	return max(x.mid.MantExp(nil)-1-x.rad.MantExp(nil), 0)
+}
+
+// CertifiedDigits returns the number of significant decimal digits of the
+// midpoint of x that are certified, a lower bound on the largest d with
+// rad <= |mid|·10^-d. It is math.MaxInt if x is exact.
+func (x *Ball) CertifiedDigits() int {
+	b := x.AccuracyBits()
+	if b == math.MaxInt {
+		return b
+	}
+
+	return int(float64(b) * math.Log10(2))
+}
+
+// Text converts x to a string of the form [mid ± rad], formatting the
+// midpoint with big.Float.Text using the given format and number of digits
+// and the radius with three significant digits.
+func (x *Ball) Text(format byte, digits int) string {
+	return "[" + x.mid.Text(format, digits) + " ± " + x.rad.Text('g', 3) + "]"
+}
+
+// String formats x like x.Text('g', 10).
+func (x *Ball) String() string {
+	return x.Text('g', 10)
+}
+
+// Neg sets z to -x and returns z.
+func (z *Ball) Neg(x *Ball) *Ball {
+	m := new(big.Float).Neg(&x.mid)
+
+	return z.setMid(new(big.Float).SetPrec(z.resultPrec(x)).Set(m), m, &x.rad)
+}
+
+// Add sets z to x+y and returns z.
+func (z *Ball) Add(x, y *Ball) *Ball {
+	if z.indeterminate(x, y) {
+		return z
+	}
+
+	m := new(big.Float).SetPrec(z.resultPrec(x, y)).Add(&x.mid, &y.mid)
+	r := newUp(ballRadiusPrec).Add(&x.rad, &y.rad)
+
+	return z.setRounded(m, r)
+}
+
+// Sub sets z to x-y and returns z.
+func (z *Ball) Sub(x, y *Ball) *Ball {
+	if z.indeterminate(x, y) {
+		return z
+	}
+
+	m := new(big.Float).SetPrec(z.resultPrec(x, y)).Sub(&x.mid, &y.mid)
+	r := newUp(ballRadiusPrec).Add(&x.rad, &y.rad)
+
+	return z.setRounded(m, r)
+}
+
+// Mul sets z to x·y and returns z. The radius is
+// |x.mid|·y.rad + |y.mid|·x.rad + x.rad·y.rad.
+func (z *Ball) Mul(x, y *Ball) *Ball {
+	if z.indeterminate(x, y) {
+		return z
+	}
+
+	m := new(big.Float).SetPrec(z.resultPrec(x, y)).Mul(&x.mid, &y.mid)
+	r := mulRounded(new(big.Float).Abs(&x.mid), &y.rad, ballRadiusPrec, true)
+	r.Add(r, mulRounded(new(big.Float).Abs(&y.mid), &x.rad, ballRadiusPrec, true))
+	r.Add(r, mulRounded(&x.rad, &y.rad, ballRadiusPrec, true))
+
+	return z.setRounded(m, r)
+}
+
+// Quo sets z to x/y and returns z. If y contains 0 the result has radius
+// +Inf, and otherwise the radius is
+// (|x.mid|·y.rad + |y.mid|·x.rad) / (|y.mid|·(|y.mid| - y.rad)).
+func (z *Ball) Quo(x, y *Ball) *Ball {
+	if z.indeterminate(x, y) {
+		return z
+	}
+
+	prec := z.resultPrec(x, y)
+	ym := new(big.Float).Abs(&y.mid)
+	if ym.Cmp(&y.rad) <= 0 {
+		return z.setMid(new(big.Float).SetPrec(prec), zero, new(big.Float).SetInf(false))
+	}
+
+	m := new(big.Float).SetPrec(prec).Quo(&x.mid, &y.mid)
+	r := mulRounded(new(big.Float).Abs(&x.mid), &y.rad, ballRadiusPrec, true)
+	r.Add(r, mulRounded(ym, &x.rad, ballRadiusPrec, true))
+	d := newDown(ballRadiusPrec).Sub(ym, &y.rad)
+	d.Mul(d, ym)
+	r.Quo(r, d)
+
+	return z.setRounded(m, r)
+}
+
+// Sqrt sets z to the square root of x and returns z.
+func (z *Ball) Sqrt(x *Ball) *Ball {
+	return z.apply((*Interval).Sqrt, x)
+}
+
+// Exp sets z to e^x and returns z.
+func (z *Ball) Exp(x *Ball) *Ball {
+	return z.apply((*Interval).Exp, x)
+}
+
+// Log sets z to the natural logarithm of x and returns z.
+func (z *Ball) Log(x *Ball) *Ball {
+	return z.apply((*Interval).Log, x)
+}
+
+// Sin sets z to the sine of the radian argument x and returns z.
+func (z *Ball) Sin(x *Ball) *Ball {
+	return z.apply((*Interval).Sin, x)
+}
+
+// Cos sets z to the cosine of the radian argument x and returns z.
+func (z *Ball) Cos(x *Ball) *Ball {
+	return z.apply((*Interval).Cos, x)
+}
+
+// Atan sets z to the arctangent, in radians, of x and returns z.
+func (z *Ball) Atan(x *Ball) *Ball {
+	return z.apply((*Interval).Atan, x)
+}
+
+// Gamma sets z to the Gamma function of x and returns z.
+func (z *Ball) Gamma(x *Ball) *Ball {
+	return z.apply((*Interval).Gamma, x)
+}
+
+// Pow sets z to x**y and returns z, following Interval.Pow.
+func (z *Ball) Pow(x, y *Ball) *Ball {
+	prec := z.resultPrec(x, y)
+	work := prec + ballGuardBits
+	result := new(Interval).SetPrec(work).Pow(x.Interval(), y.Interval())
+
+	return z.SetInterval(result, prec)
+}
+
+// apply sets z to a ball containing f(x) for an Interval function f and
+// returns z.
+func (z *Ball) apply(f func(z, x *Interval) *Interval, x *Ball) *Ball {
+	prec := z.resultPrec(x)
+	result := f(new(Interval).SetPrec(prec+ballGuardBits), x.Interval())
+
+	return z.SetInterval(result, prec)
+}
+
+// indeterminate sets z to the undefined ball and reports true if either
+// operand has an infinite midpoint, for which the arithmetic has no
+// meaningful radius.
+func (z *Ball) indeterminate(x, y *Ball) bool {
+	if !x.mid.IsInf() && !y.mid.IsInf() {
+		return false
+	}
+
+	inf := new(big.Float).SetInf(false)
+	z.setMid(new(big.Float).SetPrec(z.resultPrec(x, y)).Set(inf), inf, inf)
+
+	return true
+}
+
+// resultPrec returns the precision of z, or if it is 0 the largest of the
+// precisions of the operands.
+func (z *Ball) resultPrec(operands ...*Ball) uint {
+	if prec := z.Prec(); prec != 0 {
+		return prec
+	}
+
+	var prec uint
+	for _, x := range operands {
+		prec = max(prec, x.Prec())
+	}
+
+	return prec
+}
+
+// setMid sets z to the ball with midpoint m containing the ball with
+// midpoint c and radius r, so its radius is r + |m - c| rounded up, and
+// returns z. m must not share memory with z's radius.
+func (z *Ball) setMid(m, c, r *big.Float) *Ball {
+	rad := newUp(ballRadiusPrec).Set(r)
+	if m.Cmp(c) != 0 && !m.IsInf() {
+		d := new(big.Float).SetPrec(max(m.Prec(), c.Prec())+1).SetMode(big.AwayFromZero).Sub(m, c)
+		rad.Add(rad, d.Abs(d))
+	}
+
+	z.mid.SetPrec(m.Prec()).Set(m)
+	z.rad.SetPrec(ballRadiusPrec).SetMode(big.ToPositiveInf).Set(rad)
+
+	return z
+}
+
+// setRounded sets z to the ball with midpoint m, the result of a single
+// rounded operation, and radius r plus the error of that rounding, and
+// returns z.
+func (z *Ball) setRounded(m, r *big.Float) *Ball {
+	if m.Acc() != big.Exact && !m.IsInf() && m.Sign() != 0 {
+		// The rounding error is at most one unit in the last place of m.
+		r.Add(r, new(big.Float).SetMantExp(one, m.MantExp(nil)-int(m.Prec())))
+	}
+
+	z.mid.SetPrec(m.Prec()).Set(m)
+	z.rad.SetPrec(ballRadiusPrec).SetMode(big.ToPositiveInf).Set(r)
+
+	return z
+}
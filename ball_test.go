@@ -0,0 +1,173 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+var ballFunctions = []struct {
+	name    string
+	ball    func(z, x *Ball) *Ball
+	complex func(z, x *Complex) *Complex
+}{
+	{"Sqrt", (*Ball).Sqrt, (*Complex).Sqrt},
+	{"Exp", (*Ball).Exp, (*Complex).Exp},
+	{"Log", (*Ball).Log, (*Complex).Log},
+	{"Sin", (*Ball).Sin, (*Complex).Sin},
+	{"Cos", (*Ball).Cos, (*Complex).Cos},
+	{"Atan", (*Ball).Atan, (*Complex).Atan},
+	{"Gamma", (*Ball).Gamma, (*Complex).Gamma},
+}
+
+func TestBallFunctions(t *testing.T) {
+	for _, fn := range ballFunctions {
+		for _, prec := range []uint{53, 256, 1000} {
+			for _, s := range []string{"0.1", "2.5", "31.7"} {
+				t.Run(fmt.Sprintf("%s(%s)/precision_%d", fn.name, s, prec), func(t *testing.T) {
+					x, _ := new(Ball).SetPrec(prec).SetString(s)
+					got := fn.ball(new(Ball), x)
+					if got.Prec() != prec {
+						t.Errorf("%s(%s) has precision %d, want %d", fn.name, s, got.Prec(), prec)
+					}
+
+					r, _ := new(big.Rat).SetString(s)
+					c := NewComplex(new(big.Float).SetPrec(prec+128).SetRat(r), new(big.Float))
+					want := fn.complex(new(Complex).SetPrec(prec+128), c).Real()
+					if !got.Contains(want) {
+						t.Errorf("%s(%s) = %v does not contain %s", fn.name, s, got, want.Text('g', 20))
+					}
+
+					// The radius is a few units in the last place of the
+					// midpoint, so nearly every bit is certified.
+					if bits := got.AccuracyBits(); bits < int(prec)-8 {
+						t.Errorf("%s(%s) = %v has only %d certified bits", fn.name, s, got, bits)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestBallArithmetic(t *testing.T) {
+	const prec = 100
+	x := NewBall(big.NewFloat(1.5), big.NewFloat(0.25))
+	y := NewBall(big.NewFloat(-4), big.NewFloat(0.5))
+
+	tests := []struct {
+		op       string
+		got      *Ball
+		mid, rad float64
+	}{
+		{"+", new(Ball).SetPrec(prec).Add(x, y), -2.5, 0.75},
+		{"-", new(Ball).SetPrec(prec).Sub(x, y), 5.5, 0.75},
+		{"·", new(Ball).SetPrec(prec).Mul(x, y), -6, 1.5*0.5 + 4*0.25 + 0.25*0.5},
+		{"/", new(Ball).SetPrec(prec).Quo(x, y), -0.375, (1.5*0.5 + 4*0.25) / (4 * 3.5)},
+	}
+	for _, test := range tests {
+		mid, _ := test.got.Mid().Float64()
+		rad, _ := test.got.Rad().Float64()
+		if mid != test.mid || rad < test.rad || rad > test.rad*(1+1e-9) {
+			t.Errorf("x %s y = %v, want [%g ± %g]", test.op, test.got, test.mid, test.rad)
+		}
+	}
+
+	// The extremes of the operands stay inside the result.
+	for _, a := range []float64{1.25, 1.75} {
+		for _, b := range []float64{-4.5, -3.5} {
+			if got := new(Ball).Mul(x, y); !got.Contains(big.NewFloat(a * b)) {
+				t.Errorf("%v · %v = %v does not contain %g", x, y, got, a*b)
+			}
+			if got := new(Ball).Quo(x, y); !got.Contains(new(big.Float).Quo(big.NewFloat(a), big.NewFloat(b))) {
+				t.Errorf("%v / %v = %v does not contain %g", x, y, got, a/b)
+			}
+		}
+	}
+
+	// Rounding the midpoint adds to the radius.
+	third, _ := new(Ball).SetPrec(prec).SetString("1/3")
+	sum := new(Ball).SetPrec(20).Add(third, third)
+	if sum.IsExact() || !sum.Contains(new(big.Float).SetPrec(200).Quo(big.NewFloat(2), big.NewFloat(3))) {
+		t.Errorf("1/3 + 1/3 at 20 bits = %v", sum)
+	}
+
+	if got := new(Ball).Quo(x, NewBall(big.NewFloat(0.1), big.NewFloat(0.2))); !got.Rad().IsInf() {
+		t.Errorf("x / [0.1 ± 0.2] = %v, want an infinite radius", got)
+	}
+	if got := new(Ball).Log(NewBall(big.NewFloat(-2), big.NewFloat(0.5))); !got.Mid().IsInf() || !got.Rad().IsInf() {
+		t.Errorf("Log([-2 ± 0.5]) = %v, want [+Inf ± +Inf]", got)
+	}
+}
+
+func TestBallCertifiedDigits(t *testing.T) {
+	exact := NewBall(big.NewFloat(3), new(big.Float))
+	if !exact.IsExact() || exact.CertifiedDigits() != math.MaxInt {
+		t.Errorf("%v has %d certified digits, want exact", exact, exact.CertifiedDigits())
+	}
+
+	tests := []struct {
+		mid, rad float64
+		digits   int
+	}{
+		{1, 1e-10, 9},
+		{123456, 1e-3, 8},
+		{1, 2, 0},
+		{0, 1e-30, 0},
+	}
+	for _, test := range tests {
+		x := NewBall(big.NewFloat(test.mid), big.NewFloat(test.rad))
+		// The bound is within a digit of log10(|mid|/rad).
+		if got := x.CertifiedDigits(); got > test.digits+1 || got < test.digits-1 {
+			t.Errorf("%v has %d certified digits, want %d", x, got, test.digits)
+		}
+	}
+
+	// The README claims Exp at 256 bits has a relative error below 1e-70,
+	// which a ball makes checkable.
+	a := new(big.Float).SetPrec(256).SetFloat64(2.75)
+	b := new(Ball).SetPrec(256).Exp(new(Ball).SetFloat(a))
+	if b.CertifiedDigits() < 70 {
+		t.Errorf("Exp(2.75) = %v has only %d certified digits", b, b.CertifiedDigits())
+	}
+	relErr := b.ErrorBound(Exp(a))
+	relErr.Quo(relErr, b.Mid())
+	if relErr.Cmp(big.NewFloat(1e-70)) >= 0 {
+		t.Errorf("Exp(2.75) has a relative error up to %g", relErr)
+	}
+}
+
+func TestBallText(t *testing.T) {
+	x := NewBall(big.NewFloat(1.5), big.NewFloat(0.001))
+	if got, want := x.String(), "[1.5 ± 0.001]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func BenchmarkBall(b *testing.B) {
+	for _, fn := range ballFunctions {
+		for _, prec := range []uint{53, 256, 1000} {
+			b.Run(fmt.Sprintf("%s/precision_%d", fn.name, prec), func(b *testing.B) {
+				x, _ := new(Ball).SetPrec(prec).SetString("2.5")
+				z := new(Ball)
+				for b.Loop() {
+					fn.ball(z.SetPrec(0), x)
+				}
+			})
+		}
+	}
+}
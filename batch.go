@@ -0,0 +1,193 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// The Slice functions evaluate a function over a batch of arguments. They
+// compute the constants that the whole batch needs, such as π and ln(2),
+// once up front at the largest precision of the batch, so that the
+// evaluations, which may run on the workers set by SetMaxWorkers, only
+// round the cached values.
+
+// batchGuardBits is the number of bits beyond the precision of a batch,
+// and the magnitude of its largest argument, to which its constants are
+// computed up front. It covers the first approximation of every function.
+const batchGuardBits = 2*zivGuardBits + 64
+
+// SinSlice sets dst[i] to the sine of xs[i] for each i, as SinTo(dst[i],
+// xs[i]) does, and returns dst[:len(xs)]. If dst is shorter than xs it is
+// extended first, and nil elements are replaced by new big.Floats, which
+// take the precision of their argument. The elements of dst must be
+// distinct, though dst[i] may be xs[i].
+func SinSlice(dst, xs []*big.Float) []*big.Float {
+	return batchTo(dst, xs, warmPi, SinTo)
+}
+
+// CosSlice is like SinSlice for the cosine, as CosTo computes it.
+func CosSlice(dst, xs []*big.Float) []*big.Float {
+	return batchTo(dst, xs, warmPi, CosTo)
+}
+
+// ExpSlice is like SinSlice for e**x, as ExpTo computes it.
+func ExpSlice(dst, xs []*big.Float) []*big.Float {
+	return batchTo(dst, xs, warmLn2, ExpTo)
+}
+
+// LogSlice is like SinSlice for the natural logarithm, as LogTo computes
+// it.
+func LogSlice(dst, xs []*big.Float) []*big.Float {
+	return batchTo(dst, xs, warmPiLn2, LogTo)
+}
+
+// GammaSlice is like SinSlice for the Gamma function. Gamma(xs[i]) is
+// rounded to the precision of dst[i], or taken as it is for a new element.
+// The constants computed up front are π and ln(2), which Gamma reads
+// through Exp, Pow and Log above 64 bits and in Stirling's approximation;
+// the float64 Lanczos approximation it uses otherwise needs neither.
+func GammaSlice(dst, xs []*big.Float) []*big.Float {
+	return batchTo(dst, xs, warmPiLn2, func(z, x *big.Float) *big.Float {
+		return z.Set(Gamma(x))
+	})
+}
+
+// batchTo implements the Slice functions. warm computes the constants the
+// batch needs to the given precision, and fn is the To form.
+func batchTo(dst, xs []*big.Float, warm func(prec uint), fn func(z, x *big.Float) *big.Float) []*big.Float {
+	if len(dst) < len(xs) {
+		dst = append(dst, make([]*big.Float, len(xs)-len(dst))...)
+	}
+	dst = dst[:len(xs)]
+	for i := range dst {
+		if dst[i] == nil {
+			dst[i] = new(big.Float)
+		}
+	}
+
+	if len(xs) > 0 {
+		warm(batchPrec(dst, xs) + batchGuardBits)
+	}
+
+	forEach(len(xs), func(i int) {
+		fn(dst[i], xs[i])
+	})
+
+	return dst
+}
+
+// batchPrec returns the precision a batch needs its constants to, before
+// the guard bits: the largest of the precisions of its results, each
+// widened by the magnitude of its argument.
+func batchPrec(dst, xs []*big.Float) uint {
+	var prec uint
+	for i, x := range xs {
+		p := dst[i].Prec()
+		if p == 0 {
+			p = x.Prec()
+		}
+		// Reducing a large argument takes as many more bits as its
+		// exponent, which matters up to that of the largest finite e**x.
+		extra := 0
+		if !x.IsInf() {
+			extra = min(max(0, x.MantExp(nil)), 32)
+		}
+		prec = max(prec, p+uint(extra))
+	}
+
+	return prec
+}
+
+// warmPi computes π to prec bits into its cache.
+func warmPi(prec uint) {
+	piCache.get(context.Background(), prec)
+}
+
+// warmLn2 computes ln(2) to prec bits into its cache.
+func warmLn2(prec uint) {
+	ln2Cache.get(context.Background(), prec)
+}
+
+// warmPiLn2 computes π and ln(2) to prec bits into their caches.
+func warmPiLn2(prec uint) {
+	warmPi(prec)
+	warmLn2(prec)
+}
+
+// Map returns the slice of fn(x) for each x in xs, in the same order,
+// computed by up to workers goroutines at once. If workers is less than 1,
+// runtime.GOMAXPROCS(0) is used. fn must be safe for concurrent use, as
+// the functions of the package are. If fn panics, Map waits for the other
+// goroutines to stop and then panics with the same value.
+//
+// For example
+//
+//	ys := bigmath.Map(bigmath.Gamma, xs, 0)
+//
+// evaluates the Gamma function over xs on all of the processors.
+func Map[T, R any](fn func(T) R, xs []T, workers int) []R {
+	out := make([]R, len(xs))
+	if len(xs) == 0 {
+		return out
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(xs))
+
+	var (
+		next      atomic.Int64
+		stop      atomic.Bool
+		wg        sync.WaitGroup
+		once      sync.Once
+		panicked  bool
+		recovered any
+	)
+	work := func() {
+		defer wg.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				stop.Store(true)
+				once.Do(func() { panicked, recovered = true, r })
+			}
+		}()
+
+		for !stop.Load() {
+			i := int(next.Add(1) - 1)
+			if i >= len(xs) {
+				return
+			}
+			out[i] = fn(xs[i])
+		}
+	}
+
+	wg.Add(workers)
+	for range workers - 1 {
+		go work()
+	}
+	work()
+	wg.Wait()
+
+	if panicked {
+		panic(recovered)
+	}
+
+	return out
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+var sliceFunctions = []struct {
+	name  string
+	slice func(dst, xs []*big.Float) []*big.Float
+	to    func(z, x *big.Float) *big.Float
+}{
+	{"SinSlice", SinSlice, SinTo},
+	{"CosSlice", CosSlice, CosTo},
+	{"ExpSlice", ExpSlice, ExpTo},
+	{"LogSlice", LogSlice, LogTo},
+	{"GammaSlice", GammaSlice, func(z, x *big.Float) *big.Float { return z.Set(Gamma(x)) }},
+}
+
+// batchInputs returns n positive arguments of the given precision.
+func batchInputs(n int, prec uint) []*big.Float {
+	xs := make([]*big.Float, n)
+	for i := range xs {
+		xs[i] = newRatFloat(fmt.Sprintf("%d/7", i+1), prec)
+	}
+
+	return xs
+}
+
+func TestSliceFunctions(t *testing.T) {
+	for _, f := range sliceFunctions {
+		for _, workers := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%s/workers_%d", f.name, workers), func(t *testing.T) {
+				withWorkers(t, workers)
+
+				xs := batchInputs(20, 200)
+				got := f.slice(nil, xs)
+				if len(got) != len(xs) {
+					t.Fatalf("%s(nil, xs) has length %d, want %d", f.name, len(got), len(xs))
+				}
+				for i, x := range xs {
+					want := f.to(new(big.Float), x)
+					if !sameValue(got[i], want) {
+						t.Errorf("%s[%d] = %s, want %s", f.name, i, got[i].Text('g', 20), want.Text('g', 20))
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSliceDestination(t *testing.T) {
+	xs := batchInputs(4, 100)
+
+	// A short dst is extended, and its existing elements keep their
+	// precision and rounding mode.
+	z := new(big.Float).SetPrec(30).SetMode(big.ToZero)
+	dst := SinSlice([]*big.Float{z}, xs)
+	if len(dst) != len(xs) || dst[0] != z {
+		t.Fatalf("SinSlice did not reuse the elements of dst")
+	}
+	if want := SinTo(new(big.Float).SetPrec(30).SetMode(big.ToZero), xs[0]); !sameValue(z, want) {
+		t.Errorf("SinSlice into a 30-bit element = %s, want %s", z.Text('g', 10), want.Text('g', 10))
+	}
+	for i := 1; i < len(xs); i++ {
+		if dst[i].Prec() != xs[i].Prec() {
+			t.Errorf("new element %d has precision %d, want %d", i, dst[i].Prec(), xs[i].Prec())
+		}
+	}
+
+	// dst may be xs.
+	want := SinSlice(nil, xs)
+	got := SinSlice(xs, xs)
+	for i := range want {
+		if !sameValue(got[i], want[i]) {
+			t.Errorf("SinSlice(xs, xs)[%d] = %s, want %s", i, got[i].Text('g', 20), want[i].Text('g', 20))
+		}
+	}
+
+	if got := ExpSlice(nil, nil); len(got) != 0 {
+		t.Errorf("ExpSlice(nil, nil) has length %d, want 0", len(got))
+	}
+}
+
+func TestGammaSliceWarmsCaches(t *testing.T) {
+	// Gamma reads ln(2) through Exp and Pow above 64 bits, and π too
+	// through the Log of Pow in Stirling's approximation. Computed up front
+	// to the precision of the batch, neither cache grows while it runs.
+	xs := []*big.Float{
+		new(big.Float).SetPrec(300).SetFloat64(7.5),
+		new(big.Float).SetPrec(300).SetFloat64(30.5),
+		new(big.Float).SetPrec(300).SetFloat64(-3.5),
+		new(big.Float).SetPrec(2000).SetFloat64(7.5),
+		new(big.Float).SetPrec(2000).SetFloat64(30.5),
+	}
+	dst := make([]*big.Float, len(xs))
+	for i := range dst {
+		dst[i] = new(big.Float)
+	}
+	piCache.value.Store(nil)
+	ln2Cache.value.Store(nil)
+	warmPiLn2(batchPrec(dst, xs) + batchGuardBits)
+	pi, ln2 := piCache.value.Load(), ln2Cache.value.Load()
+
+	GammaSlice(dst, xs)
+	if piCache.value.Load() != pi {
+		t.Errorf("GammaSlice extended π from %d to %d bits", pi.Prec(), piCache.prec())
+	}
+	if ln2Cache.value.Load() != ln2 {
+		t.Errorf("GammaSlice extended ln(2) from %d to %d bits", ln2.Prec(), ln2Cache.prec())
+	}
+}
+
+func TestMap(t *testing.T) {
+	xs := make([]int64, 100)
+	for i := range xs {
+		xs[i] = int64(i)
+	}
+
+	for _, workers := range []int{0, 1, 3, 200} {
+		got := Map(Factorial, xs, workers)
+		if len(got) != len(xs) {
+			t.Fatalf("Map with %d workers has length %d, want %d", workers, len(got), len(xs))
+		}
+		for i, x := range xs {
+			if want := Factorial(x); got[i].Cmp(want) != 0 {
+				t.Errorf("Map with %d workers: [%d] = %v, want %v", workers, i, got[i], want)
+			}
+		}
+	}
+
+	if got := Map(Sin, nil, 4); len(got) != 0 {
+		t.Errorf("Map over nil has length %d, want 0", len(got))
+	}
+}
+
+func TestMapPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "bad" {
+			t.Errorf("Map panicked with %v, want bad", r)
+		}
+	}()
+
+	xs := make([]int, 50)
+	for i := range xs {
+		xs[i] = i
+	}
+	Map(func(i int) int {
+		if i == 17 {
+			panic("bad")
+		}
+
+		return i
+	}, xs, 4)
+	t.Errorf("Map returned after fn panicked")
+}
+
+// BenchmarkSlice compares a Slice function with calling the package-level
+// function in a loop, and with Map.
+func BenchmarkSlice(b *testing.B) {
+	for _, prec := range []uint{128, 1024} {
+		xs := batchInputs(256, prec)
+
+		b.Run(fmt.Sprintf("Sin_loop_prec_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				for _, x := range xs {
+					Sin(x)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("SinSlice_prec_%d", prec), func(b *testing.B) {
+			dst := SinSlice(nil, xs)
+			b.ReportAllocs()
+			for b.Loop() {
+				SinSlice(dst, xs)
+			}
+		})
+		b.Run(fmt.Sprintf("Map_Sin_prec_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				Map(Sin, xs, 0)
+			}
+		})
+	}
+}
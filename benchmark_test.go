@@ -106,3 +106,33 @@ func benchmarkBigmathVsStdlib2Args(b *testing.B, data benchAndCompare2Args, val,
 		}
 	})
 }
+
+// seriesKernels are the Taylor-based kernels that sum their series with
+// sumSeries.
+var seriesKernels = []benchAndCompare{
+	{"SinTaylor", sinTaylor, nil},
+	{"TanTaylor", tanTaylor, nil},
+	{"SecSeries", secSeries, nil},
+	{"Asin", Asin, nil},
+	{"Atan", Atan, nil},
+	{"LogTaylor", logTaylor, nil},
+	{"Exp", Exp, nil},
+}
+
+// BenchmarkSeriesKernels benchmarks the Taylor-based kernels across
+// precisions, reporting allocations so that the per-term cost of the
+// series engine shows.
+func BenchmarkSeriesKernels(b *testing.B) {
+	for _, data := range seriesKernels {
+		for _, prec := range precisions {
+			b.Run(fmt.Sprintf("%s_prec_%d", data.name, prec), func(b *testing.B) {
+				x := new(big.Float).SetPrec(prec).SetFloat64(0.3)
+
+				b.ReportAllocs()
+				for b.Loop() {
+					data.fnBigmath(x)
+				}
+			})
+		}
+	}
+}
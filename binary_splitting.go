@@ -0,0 +1,499 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+)
+
+// Series describes a hypergeometric-type series whose terms are ratios
+// of small integers
+//
+//	S = Σ_{n=0}^{N-1} a(n)/b(n) · p(0)·p(1)···p(n) / (q(0)·q(1)···q(n))
+//
+// which can be summed exactly by binary splitting on big.Int values and
+// only rounded once at the end. This is the method of choice for computing
+// constants such as e and π, and exp or atan of small rationals, to
+// millions of digits.
+//
+// Any of A, B, P or Q may be nil, in which case it is treated as the
+// constant 1.
+//
+// For example, e = Σ 1/n! is given by
+//
+//	Series{Q: func(n int64) *big.Int {
+//		if n == 0 {
+//			return big.NewInt(1)
+//		}
+//		return big.NewInt(n)
+//	}}
+type Series struct {
+	A, B, P, Q func(n int64) *big.Int
+}
+
+// SplitResult holds the partial products of a Series over a range of
+// terms [n1, n2).
+//
+//	P = p(n1)···p(n2-1)
+//	Q = q(n1)···q(n2-1)
+//	B = b(n1)···b(n2-1)
+//	T = B·Q·Σ_{n=n1}^{n2-1} a(n)/b(n) · p(n1)···p(n) / (q(n1)···q(n))
+//
+// B is nil when the Series has no B function.
+type SplitResult struct {
+	P, Q, B, T *big.Int
+}
+
+// Split computes the partial products of the series over the terms
+// [n1, n2) by recursively splitting the range in half and combining
+// the halves. An empty range, n2 <= n1, gives the identity P = Q = B = 1
+// and T = 0.
+func (s *Series) Split(n1, n2 int64) *SplitResult {
+	return s.split(context.Background(), n1, n2)
+}
+
+// split implements Split, checking ctx at every level of the recursion.
+func (s *Series) split(ctx context.Context, n1, n2 int64) *SplitResult {
+	checkCtx(ctx)
+
+	if n2 <= n1 {
+		r := &SplitResult{P: big.NewInt(1), Q: big.NewInt(1), T: new(big.Int)}
+		if s.B != nil {
+			r.B = big.NewInt(1)
+		}
+
+		return r
+	}
+
+	if n2-n1 == 1 {
+		r := &SplitResult{
+			P: s.term(s.P, n1),
+			Q: s.term(s.Q, n1),
+		}
+		r.T = new(big.Int).Mul(s.term(s.A, n1), r.P)
+		if s.B != nil {
+			r.B = s.B(n1)
+		}
+
+		return r
+	}
+
+	m := n1 + (n2-n1)/2
+	if n2-n1 >= parallelMinTerms && parallel() {
+		var left, right *SplitResult
+		fork(func() { left = s.split(ctx, n1, m) }, func() { right = s.split(ctx, m, n2) })
+
+		return s.combine(left, right)
+	}
+
+	left := s.split(ctx, n1, m)
+	right := s.split(ctx, m, n2)
+
+	return s.combine(left, right)
+}
+
+// combine merges the partial products of two adjacent ranges.
+//
+//	T = Br·Qr·Tl + Bl·Pl·Tr
+func (s *Series) combine(left, right *SplitResult) *SplitResult {
+	r := &SplitResult{
+		P: new(big.Int).Mul(left.P, right.P),
+		Q: new(big.Int).Mul(left.Q, right.Q),
+	}
+
+	t1 := new(big.Int).Mul(right.Q, left.T)
+	t2 := new(big.Int).Mul(left.P, right.T)
+	if s.B != nil {
+		r.B = new(big.Int).Mul(left.B, right.B)
+		t1.Mul(t1, right.B)
+		t2.Mul(t2, left.B)
+	}
+	r.T = t1.Add(t1, t2)
+
+	return r
+}
+
+// term evaluates one of the series functions, treating nil as 1.
+func (s *Series) term(fn func(int64) *big.Int, n int64) *big.Int {
+	if fn == nil {
+		return big.NewInt(1)
+	}
+
+	return fn(n)
+}
+
+// Sum returns the sum of the first terms terms of the series rounded
+// to the given precision.
+func (s *Series) Sum(terms int64, precision uint) *big.Float {
+	return s.sum(context.Background(), terms, precision)
+}
+
+// SumCtx is like Sum, but stops and returns ctx.Err() if ctx is done
+// before the sum is complete.
+func (s *Series) SumCtx(ctx context.Context, terms int64, precision uint) (result *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return s.sum(ctx, terms, precision), nil
+}
+
+// sum implements Sum.
+func (s *Series) sum(ctx context.Context, terms int64, precision uint) *big.Float {
+	if terms <= 0 {
+		return new(big.Float).SetPrec(precision)
+	}
+
+	return s.split(ctx, 0, terms).Value(precision)
+}
+
+// Value returns T / (B·Q) rounded to the given precision. For a
+// SplitResult over [0, N) this is the sum of the series.
+func (r *SplitResult) Value(precision uint) *big.Float {
+	den := r.Q
+	if r.B != nil {
+		den = new(big.Int).Mul(r.B, r.Q)
+	}
+
+	num := new(big.Float).SetInt(r.T)
+	result := new(big.Float).SetPrec(precision)
+
+	return result.Quo(num, new(big.Float).SetInt(den))
+}
+
+// seriesTerms returns the number of terms needed for a series whose n-th
+// term has magnitude roughly 2^log2Term(n) to converge to the given
+// precision. log2Term must eventually decrease without bound.
+func seriesTerms(precision uint, log2Term func(n int64) float64) int64 {
+	target := -float64(precision) - 16
+	prev := math.Inf(1)
+	for n := int64(1); ; n++ {
+		t := log2Term(n)
+		// Only stop once the terms are both small and decreasing.
+		if t < target && t < prev {
+			return n + 1
+		}
+		prev = t
+	}
+}
+
+// log2Factorial returns log2(n!).
+func log2Factorial(n int64) float64 {
+	lg, _ := math.Lgamma(float64(n) + 1)
+
+	return lg / math.Ln2
+}
+
+// computeEBinarySplit calculates e = Σ 1/n! with the given precision by
+// binary splitting.
+func computeEBinarySplit(ctx context.Context, precision uint) *big.Float {
+	terms := seriesTerms(precision, func(n int64) float64 {
+		return -log2Factorial(n)
+	})
+
+	s := &Series{
+		Q: func(n int64) *big.Int {
+			if n == 0 {
+				return big.NewInt(1)
+			}
+
+			return big.NewInt(n)
+		},
+	}
+
+	return s.sum(ctx, terms, precision)
+}
+
+// Constants for the Chudnovsky series.
+const (
+	chudnovskyA = 13591409
+	chudnovskyB = 545140134
+	// chudnovskyC3Over24 is 640320³/24.
+	chudnovskyC3Over24 = 10939058860032000
+	// chudnovskyBitsPerTerm is log2(640320³/(12³·2·6)), the number of bits
+	// gained by each term of the series.
+	chudnovskyBitsPerTerm = 47.11
+)
+
+// computePiChudnovsky calculates π with the given precision using the
+// Chudnovsky series evaluated by binary splitting.
+//
+//	1/π = 12 Σ (-1)^k (6k)! (13591409 + 545140134k) / ((3k)! (k!)³ 640320^(3k+3/2))
+//
+// Written in the Series form with p(k) = -(6k-5)(2k-1)(6k-1) and
+// q(k) = k³·640320³/24, π = 426880·√10005 / S.
+func computePiChudnovsky(ctx context.Context, precision uint) *big.Float {
+	work := precision + 32
+	terms := int64(float64(work)/chudnovskyBitsPerTerm) + 2
+
+	s := &Series{
+		A: func(k int64) *big.Int {
+			a := big.NewInt(chudnovskyB)
+			a.Mul(a, big.NewInt(k))
+
+			return a.Add(a, big.NewInt(chudnovskyA))
+		},
+		P: func(k int64) *big.Int {
+			if k == 0 {
+				return big.NewInt(1)
+			}
+			p := big.NewInt(6*k - 5)
+			p.Mul(p, big.NewInt(2*k-1))
+			p.Mul(p, big.NewInt(6*k-1))
+
+			return p.Neg(p)
+		},
+		Q: func(k int64) *big.Int {
+			if k == 0 {
+				return big.NewInt(1)
+			}
+			q := big.NewInt(k)
+			q.Mul(q, q)
+			q.Mul(q, big.NewInt(k))
+
+			return q.Mul(q, big.NewInt(chudnovskyC3Over24))
+		},
+	}
+
+	r := s.split(ctx, 0, terms)
+
+	// π = 426880·√10005·Q / T
+	pi := new(big.Float).SetPrec(work).SetInt64(10005)
+	pi.Sqrt(pi)
+	pi.Mul(pi, new(big.Float).SetPrec(work).SetInt64(426880))
+	pi.Mul(pi, new(big.Float).SetInt(r.Q))
+	pi.Quo(pi, new(big.Float).SetInt(r.T))
+
+	return pi.SetPrec(precision)
+}
+
+// expRat calculates e^(p/q) for a rational p/q with the given precision by
+// binary splitting the Taylor series
+//
+//	e^x = Σ xⁿ/n!
+//
+// It is intended for arguments of modest size; large arguments should be
+// reduced first.
+func expRat(ctx context.Context, x *big.Rat, precision uint) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(precision).SetInt64(1)
+	}
+
+	// The terms are summed to an absolute tolerance, so for x < 0, where
+	// the result is small, use e^x = 1/e^-x instead.
+	if x.Sign() < 0 {
+		result := expRat(ctx, new(big.Rat).Neg(x), precision+2)
+
+		return result.Quo(one, result).SetPrec(precision)
+	}
+
+	p, q := x.Num(), x.Denom()
+	log2X := ratLog2(x)
+	terms := seriesTerms(precision, func(n int64) float64 {
+		return float64(n)*log2X - log2Factorial(n)
+	})
+
+	s := &Series{
+		P: func(n int64) *big.Int {
+			if n == 0 {
+				return big.NewInt(1)
+			}
+
+			return p
+		},
+		Q: func(n int64) *big.Int {
+			if n == 0 {
+				return big.NewInt(1)
+			}
+
+			return new(big.Int).Mul(q, big.NewInt(n))
+		},
+	}
+
+	return s.sum(ctx, terms, precision)
+}
+
+// atanRat calculates atan(p/q) for a rational |p/q| < 1 with the given
+// precision by binary splitting the Taylor series
+//
+//	atan(x) = Σ (-1)ⁿ x^(2n+1)/(2n+1)
+func atanRat(ctx context.Context, x *big.Rat, precision uint) *big.Float {
+	return atanSeriesRat(ctx, x, precision, true)
+}
+
+// atanhRat calculates atanh(p/q) for a rational |p/q| < 1 with the given
+// precision by binary splitting the Taylor series
+//
+//	atanh(x) = Σ x^(2n+1)/(2n+1)
+func atanhRat(ctx context.Context, x *big.Rat, precision uint) *big.Float {
+	return atanSeriesRat(ctx, x, precision, false)
+}
+
+// atanSeriesRat sums the atan series, or the atanh series when alternate
+// is false, for a rational argument.
+func atanSeriesRat(ctx context.Context, x *big.Rat, precision uint, alternate bool) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(precision)
+	}
+
+	p, q := x.Num(), x.Denom()
+	p2 := new(big.Int).Mul(p, p)
+	if alternate {
+		p2.Neg(p2)
+	}
+	q2 := new(big.Int).Mul(q, q)
+
+	log2X := ratLog2(x)
+	terms := seriesTerms(precision, func(n int64) float64 {
+		return float64(2*n+1) * log2X
+	})
+
+	s := &Series{
+		B: func(n int64) *big.Int {
+			return big.NewInt(2*n + 1)
+		},
+		P: func(n int64) *big.Int {
+			if n == 0 {
+				return p
+			}
+
+			return p2
+		},
+		Q: func(n int64) *big.Int {
+			if n == 0 {
+				return q
+			}
+
+			return q2
+		},
+	}
+
+	return s.sum(ctx, terms, precision)
+}
+
+// ratLog2 returns an approximation of log2(|x|) for a non-zero rational.
+func ratLog2(x *big.Rat) float64 {
+	f, _ := new(big.Float).SetRat(x).Float64()
+	if f != 0 && !math.IsInf(f, 0) {
+		return math.Log2(math.Abs(f))
+	}
+
+	// Out of float64 range, fall back to the bit lengths.
+	return float64(x.Num().BitLen() - x.Denom().BitLen())
+}
+
+// computeLn2BinarySplit calculates ln(2) with the given precision using
+// a Machin-like formula evaluated by binary splitting.
+//
+//	ln(2) = 18·atanh(1/26) − 2·atanh(1/4801) + 8·atanh(1/8749)
+func computeLn2BinarySplit(ctx context.Context, precision uint) *big.Float {
+	work := precision + 16
+
+	ln2 := atanhRat(ctx, big.NewRat(1, 26), work)
+	ln2.Mul(ln2, new(big.Float).SetInt64(18))
+
+	t := atanhRat(ctx, big.NewRat(1, 4801), work)
+	t.Mul(t, two)
+	ln2.Sub(ln2, t)
+
+	t = atanhRat(ctx, big.NewRat(1, 8749), work)
+	t.Mul(t, eight)
+	ln2.Add(ln2, t)
+
+	return ln2.SetPrec(precision)
+}
+
+// sinRat calculates sin(p/q) for a rational p/q of modest size with the
+// given precision by binary splitting the Taylor series
+//
+//	sin(x) = Σ (-1)ⁿ x^(2n+1)/(2n+1)!
+func sinRat(ctx context.Context, x *big.Rat, precision uint) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(precision)
+	}
+
+	p, q := x.Num(), x.Denom()
+	p2 := new(big.Int).Mul(p, p)
+	p2.Neg(p2)
+	q2 := new(big.Int).Mul(q, q)
+
+	// The sum is accurate to an absolute tolerance, so carry enough extra
+	// bits to cover a small x.
+	log2X := ratLog2(x)
+	work := precision + uint(max(0, -log2X))
+	terms := seriesTerms(work, func(n int64) float64 {
+		return float64(2*n+1)*log2X - log2Factorial(2*n+1)
+	})
+
+	s := &Series{
+		P: func(n int64) *big.Int {
+			if n == 0 {
+				return p
+			}
+
+			return p2
+		},
+		Q: func(n int64) *big.Int {
+			if n == 0 {
+				return q
+			}
+			d := new(big.Int).Mul(q2, big.NewInt(2*n))
+
+			return d.Mul(d, big.NewInt(2*n+1))
+		},
+	}
+
+	return s.sum(ctx, terms, precision)
+}
+
+// cosRat calculates cos(p/q) for a rational p/q of modest size with the
+// given precision by binary splitting the Taylor series
+//
+//	cos(x) = Σ (-1)ⁿ x^(2n)/(2n)!
+func cosRat(ctx context.Context, x *big.Rat, precision uint) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(precision).SetInt64(1)
+	}
+
+	p, q := x.Num(), x.Denom()
+	p2 := new(big.Int).Mul(p, p)
+	p2.Neg(p2)
+	q2 := new(big.Int).Mul(q, q)
+
+	log2X := ratLog2(x)
+	terms := seriesTerms(precision, func(n int64) float64 {
+		return float64(2*n)*log2X - log2Factorial(2*n)
+	})
+
+	s := &Series{
+		P: func(n int64) *big.Int {
+			if n == 0 {
+				return big.NewInt(1)
+			}
+
+			return p2
+		},
+		Q: func(n int64) *big.Int {
+			if n == 0 {
+				return big.NewInt(1)
+			}
+			d := new(big.Int).Mul(q2, big.NewInt(2*n-1))
+
+			return d.Mul(d, big.NewInt(2*n))
+		},
+	}
+
+	return s.sum(ctx, terms, precision)
+}
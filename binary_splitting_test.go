@@ -0,0 +1,252 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// bitsOfAgreement returns how many bits a and b agree to, relative to the
+// magnitude of b.
+func bitsOfAgreement(a, b *big.Float) int {
+	diff := new(big.Float).SetPrec(a.Prec()).Sub(a, b)
+	if diff.Sign() == 0 {
+		return math.MaxInt32
+	}
+
+	return b.MantExp(nil) - diff.MantExp(nil)
+}
+
+func TestSeriesSum(t *testing.T) {
+	// Σ_{n=0}^{N-1} 1/2ⁿ⁺¹ = 1 - 2^-N exactly.
+	half := &Series{
+		Q: func(int64) *big.Int { return big.NewInt(2) },
+	}
+
+	for _, terms := range []int64{1, 2, 3, 10, 64, 100} {
+		got := half.Sum(terms, 256)
+		want := new(big.Float).SetMantExp(big.NewFloat(1), -int(terms)).SetPrec(256)
+		want.Sub(big.NewFloat(1), want)
+		if got.Cmp(want) != 0 {
+			t.Errorf("Σ 1/2^(n+1) over %d terms = %s, want %s", terms, got.Text('g', 30), want.Text('g', 30))
+		}
+	}
+
+	// Σ 1/((n+1)(n+2)) = 1 - 1/(N+1), using A and B but no P or Q.
+	telescope := &Series{
+		B: func(n int64) *big.Int { return big.NewInt((n + 1) * (n + 2)) },
+	}
+	got := telescope.Sum(99, 128)
+	want := new(big.Float).SetPrec(128).Quo(big.NewFloat(99), big.NewFloat(100))
+	if bitsOfAgreement(got, want) < 120 {
+		t.Errorf("telescoping sum = %s, want %s", got.Text('g', 30), want.Text('g', 30))
+	}
+
+	if got := telescope.Sum(0, 64); got.Sign() != 0 {
+		t.Errorf("Sum of no terms = %v, want 0", got)
+	}
+}
+
+func TestSeriesSplitEmpty(t *testing.T) {
+	telescope := &Series{
+		B: func(n int64) *big.Int { return big.NewInt((n + 1) * (n + 2)) },
+	}
+	for _, r := range [][2]int64{{0, 0}, {5, 5}, {7, 3}} {
+		got := telescope.Split(r[0], r[1])
+		if got.P.Cmp(big.NewInt(1)) != 0 || got.Q.Cmp(big.NewInt(1)) != 0 ||
+			got.B.Cmp(big.NewInt(1)) != 0 || got.T.Sign() != 0 {
+			t.Errorf("Split(%d, %d) = {P %v, Q %v, B %v, T %v}, want {1, 1, 1, 0}",
+				r[0], r[1], got.P, got.Q, got.B, got.T)
+		}
+	}
+
+	// The identity combines with a real range without changing it.
+	want := telescope.Split(0, 10)
+	got := telescope.combine(telescope.Split(4, 4), want)
+	if got.T.Cmp(want.T) != 0 || got.Q.Cmp(want.Q) != 0 || got.B.Cmp(want.B) != 0 {
+		t.Errorf("combine(empty, Split(0, 10)) = %v, want %v", got, want)
+	}
+}
+
+func TestComputeEBinarySplit(t *testing.T) {
+	// The old Taylor version topped out around 1,100 digits, so go well past that.
+	for _, prec := range []uint{53, 400, 4000, 10000, 40000} {
+		t.Run(fmt.Sprintf("precision_%d", prec), func(t *testing.T) {
+			e := ComputeE(prec)
+			if e.Prec() != prec {
+				t.Errorf("ComputeE(%d) has precision %d", prec, e.Prec())
+			}
+
+			// e · e⁻¹ = 1
+			product := new(big.Float).SetPrec(prec).Mul(e, expRat(context.Background(), big.NewRat(-1, 1), prec))
+			if got := bitsOfAgreement(product, big.NewFloat(1)); got < int(prec)-4 {
+				t.Errorf("ComputeE(%d)·e⁻¹ only agrees with 1 to %d bits", prec, got)
+			}
+		})
+	}
+
+	// Only the first ~595 digits of eKnown1000 are correct.
+	expected := new(big.Float).SetPrec(2000)
+	expected.SetString(eKnown1000[:592])
+	if got := bitsOfAgreement(ComputeE(2000), expected); got < 1950 {
+		t.Errorf("ComputeE(2000) only agrees with the known value to %d bits", got)
+	}
+}
+
+func TestComputePiChudnovsky(t *testing.T) {
+	for _, prec := range []uint{53, 100, 1000, 20000} {
+		t.Run(fmt.Sprintf("precision_%d", prec), func(t *testing.T) {
+			got := computePiChudnovsky(context.Background(), prec)
+			want := machinPi(prec + 64)
+			if agree := bitsOfAgreement(got, want); agree < int(prec)-2 {
+				t.Errorf("computePiChudnovsky(%d) only agrees with Machin's formula to %d bits", prec, agree)
+			}
+		})
+	}
+}
+
+func TestExpRat(t *testing.T) {
+	tests := []struct {
+		num, den int64
+	}{
+		{1, 1},
+		{-1, 1},
+		{1, 3},
+		{-7, 1024},
+		{5, 2},
+		{40, 1},
+		{1, 1 << 40},
+	}
+
+	for _, test := range tests {
+		x := big.NewRat(test.num, test.den)
+		xf, _ := x.Float64()
+
+		got := expRat(context.Background(), x, 53)
+		gotF, _ := got.Float64()
+		if math.Abs(gotF-math.Exp(xf)) > 4e-16*math.Exp(xf) {
+			t.Errorf("expRat(%v) = %v, want %v", x, gotF, math.Exp(xf))
+		}
+
+		// e^x · e^-x = 1 at high precision.
+		const prec = 3000
+		product := expRat(context.Background(), x, prec)
+		product.Mul(product, expRat(context.Background(), new(big.Rat).Neg(x), prec))
+		if agree := bitsOfAgreement(product, big.NewFloat(1)); agree < prec-4 {
+			t.Errorf("expRat(%v)·expRat(-%v) only agrees with 1 to %d bits", x, x, agree)
+		}
+	}
+}
+
+func TestAtanRat(t *testing.T) {
+	for _, x := range []*big.Rat{big.NewRat(1, 2), big.NewRat(-1, 3), big.NewRat(1, 239), big.NewRat(9, 10)} {
+		xf, _ := x.Float64()
+		got, _ := atanRat(context.Background(), x, 53).Float64()
+		if math.Abs(got-math.Atan(xf)) > 1e-15 {
+			t.Errorf("atanRat(%v) = %v, want %v", x, got, math.Atan(xf))
+		}
+
+		gotH, _ := atanhRat(context.Background(), x, 53).Float64()
+		if math.Abs(gotH-math.Atanh(xf)) > 1e-15 {
+			t.Errorf("atanhRat(%v) = %v, want %v", x, gotH, math.Atanh(xf))
+		}
+	}
+
+	const prec = 5000
+	if agree := bitsOfAgreement(machinPi(prec), computePiChudnovsky(context.Background(), prec+64)); agree < prec-4 {
+		t.Errorf("Machin's formula with atanRat only agrees with π to %d bits", agree)
+	}
+}
+
+// machinPi returns π with the given precision from Machin's formula,
+// π = 16·atan(1/5) − 4·atan(1/239), with atanRat, as a reference that
+// shares nothing with the Chudnovsky series.
+func machinPi(prec uint) *big.Float {
+	pi := atanRat(context.Background(), big.NewRat(1, 5), prec)
+	pi.Mul(pi, big.NewFloat(16))
+	t := atanRat(context.Background(), big.NewRat(1, 239), prec)
+	t.Mul(t, big.NewFloat(4))
+
+	return pi.Sub(pi, t)
+}
+
+func TestComputeLn2BinarySplit(t *testing.T) {
+	for _, prec := range []uint{53, 1000, 10000} {
+		got := computeLn2BinarySplit(context.Background(), prec)
+		want := computeLn2AGM(prec + 64)
+		if agree := bitsOfAgreement(got, want); agree < int(prec)-2 {
+			t.Errorf("computeLn2BinarySplit(%d) only agrees with the AGM to %d bits", prec, agree)
+		}
+	}
+}
+
+func BenchmarkComputePiMethods(b *testing.B) {
+	methods := []struct {
+		name string
+		fn   func(uint) *big.Float
+	}{
+		{"Machin", computePiMachin},
+		{"Chudnovsky", func(prec uint) *big.Float { return computePiChudnovsky(context.Background(), prec) }},
+	}
+
+	for _, m := range methods {
+		for _, prec := range precisions {
+			b.Run(fmt.Sprintf("%s_precision_%d", m.name, prec), func(b *testing.B) {
+				for b.Loop() {
+					_ = m.fn(prec)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkComputeEMethods(b *testing.B) {
+	methods := []struct {
+		name string
+		fn   func(uint) *big.Float
+	}{
+		{"Taylor", computeETaylor},
+		{"BinarySplit", func(prec uint) *big.Float { return computeEBinarySplit(context.Background(), prec) }},
+	}
+
+	for _, m := range methods {
+		for _, prec := range precisions {
+			b.Run(fmt.Sprintf("%s_precision_%d", m.name, prec), func(b *testing.B) {
+				for b.Loop() {
+					_ = m.fn(prec)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkComputeVeryHighPrecision(b *testing.B) {
+	for _, prec := range []uint{10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("Pi_precision_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				_ = ComputePi(prec)
+			}
+		})
+		b.Run(fmt.Sprintf("E_precision_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				_ = ComputeE(prec)
+			}
+		})
+	}
+}
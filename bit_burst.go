@@ -0,0 +1,254 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// The bit-burst algorithm splits a reduced argument r into chunks
+//
+//	r = r₀ + r₁ + r₂ + ...
+//
+// where r₀ holds the first bitBurstFirstChunk bits after the binary point
+// and each following chunk holds twice as many bits as the one before.
+// Chunk rⱼ is then a rational with a small numerator and a power of two
+// denominator, so e^rⱼ (or sin and cos of rⱼ) is cheap to sum by binary
+// splitting, and the results are multiplied together. This gives an
+// asymptotically fast O(M(p)·log²p) evaluation at very high precision.
+
+// bitBurstFirstChunk is the number of bits in the first chunk.
+const bitBurstFirstChunk = 8
+
+// bitBurstChunks splits |r| < 1 into the rational chunks described above.
+// The sign of each chunk matches the sign of r.
+func bitBurstChunks(r *big.Float) []*big.Rat {
+	var chunks []*big.Rat
+	if r.Sign() == 0 {
+		return chunks
+	}
+
+	// r = mant·2^exp exactly, with mant an integer.
+	mant := new(big.Int)
+	exp := r.MantExp(nil) - int(r.MinPrec())
+	new(big.Float).SetMantExp(r, -exp).Int(mant)
+	neg := mant.Sign() < 0
+	mant.Abs(mant)
+
+	// Number of bits after the binary point.
+	fracBits := -exp
+	if fracBits <= 0 {
+		// r is an integer, which for |r| < 1 means it is zero.
+		return chunks
+	}
+
+	taken := 0
+	for size := bitBurstFirstChunk; taken < fracBits; size *= 2 {
+		end := min(taken+size, fracBits)
+
+		// The bits in positions (taken, end] after the binary point.
+		num := new(big.Int).Rsh(mant, uint(fracBits-end))
+		lower := new(big.Int).Lsh(new(big.Int).Rsh(mant, uint(fracBits-taken)), uint(end-taken))
+		num.Sub(num, lower)
+
+		if num.Sign() != 0 {
+			if neg {
+				num.Neg(num)
+			}
+			den := new(big.Int).Lsh(big.NewInt(1), uint(end))
+			chunks = append(chunks, new(big.Rat).SetFrac(num, den))
+		}
+		taken = end
+	}
+
+	return chunks
+}
+
+// expBitBurst calculates e^x using argument reduction by ln(2) followed
+// by the bit-burst algorithm.
+func expBitBurst(ctx context.Context, x *big.Float) *big.Float {
+	prec := x.Prec()
+	if result := expSpecial(x); result != nil {
+		return result
+	}
+
+	work := prec + 32 + uint(bits.Len(prec))
+	r, k := reduceLn2(ctx, x, work)
+
+	// The chunks are independent, and their exponentials are multiplied in
+	// order however many workers summed them.
+	chunks := bitBurstChunks(r)
+	exps := make([]*big.Float, len(chunks))
+	forEach(len(chunks), func(i int) {
+		exps[i] = expRat(ctx, chunks[i], work)
+	})
+
+	result := new(big.Float).SetPrec(work).SetInt64(1)
+	for _, e := range exps {
+		result.Mul(result, e)
+	}
+
+	result.SetMantExp(result, int(k))
+
+	return result.SetPrec(prec)
+}
+
+// sinCosBitBurst calculates sin(x) and cos(x) together using argument
+// reduction by π/2 followed by the bit-burst algorithm.
+func sinCosBitBurst(ctx context.Context, x *big.Float) (sin, cos *big.Float) {
+	prec := x.Prec()
+
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x), new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	work := prec + 32 + uint(bits.Len(prec))
+	r, quadrant := reduceHalfPi(ctx, x, work)
+
+	// The sines and cosines of the chunks are independent.
+	chunks := bitBurstChunks(r)
+	sins := make([]*big.Float, len(chunks))
+	coss := make([]*big.Float, len(chunks))
+	forEach(2*len(chunks), func(i int) {
+		if i%2 == 0 {
+			sins[i/2] = sinRat(ctx, chunks[i/2], work)
+		} else {
+			coss[i/2] = cosRat(ctx, chunks[i/2], work)
+		}
+	})
+
+	// Combine them in order with the angle addition formulas.
+	s := new(big.Float).SetPrec(work)
+	c := new(big.Float).SetPrec(work).SetInt64(1)
+	t1 := new(big.Float).SetPrec(work)
+	t2 := new(big.Float).SetPrec(work)
+	for j := range chunks {
+		sj, cj := sins[j], coss[j]
+
+		// s, c = s·cj + c·sj, c·cj − s·sj
+		t1.Mul(s, cj)
+		t2.Mul(c, sj)
+		t1.Add(t1, t2)
+
+		t2.Mul(s, sj)
+		c.Mul(c, cj)
+		c.Sub(c, t2)
+		s.Set(t1)
+	}
+
+	return unreduceHalfPi(s, c, quadrant, prec)
+}
+
+// expSpecial returns e^x for ±0, ±Inf and arguments so large that e^x is
+// out of the range of big.Float, with the precision of x, and nil for
+// every other x.
+func expSpecial(x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	switch {
+	case x.IsInf() && x.Signbit():
+		return new(big.Float).SetPrec(prec)
+	case x.IsInf():
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	xf, _ := x.Float64()
+	k := math.Round(xf / math.Ln2)
+	if k > math.MaxInt32 {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+	if k < math.MinInt32 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return nil
+}
+
+// reduceLn2 returns r with work bits and k such that x = k·ln(2) + r and
+// |r| <= ln(2)/2, so that e^x = 2^k·e^r. x must not be one of the special
+// cases of expSpecial.
+func reduceLn2(ctx context.Context, x *big.Float, work uint) (*big.Float, int) {
+	xf, _ := x.Float64()
+	k := math.Round(xf / math.Ln2)
+	kBits := uint(bits.Len64(uint64(math.Abs(k))))
+
+	ln2 := ln2Cache.get(ctx, work+kBits)
+	r := new(big.Float).SetPrec(work + kBits).SetInt64(int64(k))
+	r.Mul(r, ln2)
+	r.Sub(x, r)
+
+	return r.SetPrec(work), int(k)
+}
+
+// reduceHalfPi returns r with work bits and the quadrant q in 0..3 such
+// that x = n·π/2 + r with |r| <= π/4 and n ≡ q mod 4. x must be finite and
+// non-zero.
+func reduceHalfPi(ctx context.Context, x *big.Float, work uint) (*big.Float, int64) {
+	// Extra bits are needed to reduce large arguments, and to keep the
+	// relative precision of r when x is very close to a multiple of π/2.
+	extra := uint(max(0, x.MantExp(nil))) + 8
+
+	for {
+		halfPi := piCache.get(ctx, work+extra)
+		halfPi.Quo(halfPi, two)
+
+		q := new(big.Float).SetPrec(work+extra).Quo(x, halfPi)
+		qInt := new(big.Int)
+		q.Add(q, new(big.Float).SetFloat64(0.5*float64(q.Sign())))
+		q.Int(qInt)
+
+		r := new(big.Float).SetPrec(work + extra).SetInt(qInt)
+		r.Mul(r, halfPi)
+		r.Sub(x, r)
+
+		// The leading bits of r cancelled away; go again with more of them.
+		if r.Sign() != 0 && r.MantExp(nil) < -int(extra)+int(max(0, x.MantExp(nil))) {
+			extra += uint(-r.MantExp(nil)) + 8
+
+			continue
+		}
+
+		return r.SetPrec(work), new(big.Int).And(qInt, big.NewInt(3)).Int64()
+	}
+}
+
+// unreduceHalfPi returns sin(x) and cos(x) rounded to prec bits, given
+// s = sin(r) and c = cos(r) for the reduction x = n·π/2 + r of
+// reduceHalfPi into quadrant.
+func unreduceHalfPi(s, c *big.Float, quadrant int64, prec uint) (sin, cos *big.Float) {
+	sin = new(big.Float).SetPrec(prec)
+	cos = new(big.Float).SetPrec(prec)
+	switch quadrant {
+	case 0:
+		sin.Set(s)
+		cos.Set(c)
+	case 1:
+		sin.Set(c)
+		cos.Neg(s)
+	case 2:
+		sin.Neg(s)
+		cos.Neg(c)
+	default:
+		sin.Neg(c)
+		cos.Set(s)
+	}
+
+	return sin, cos
+}
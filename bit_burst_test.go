@@ -0,0 +1,194 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBitBurstChunks(t *testing.T) {
+	tests := []string{
+		"0.5",
+		"-0.34657359027997265470861606072908828403775006718012762706034",
+		"0.000000000000000000000000000000123456789",
+		"0.693147180559945309417232121458176568075500134360255254120680009",
+	}
+
+	for _, test := range tests {
+		r, _ := new(big.Float).SetPrec(300).SetString(test)
+
+		sum := new(big.Rat)
+		for i, chunk := range bitBurstChunks(r) {
+			// A chunk ending at bit e holds at most (e+8)/2 bits.
+			end := chunk.Denom().BitLen() - 1
+			if chunk.Num().BitLen() > (end+bitBurstFirstChunk)/2 {
+				t.Errorf("chunk %d of %s has a %d bit numerator", i, test, chunk.Num().BitLen())
+			}
+			sum.Add(sum, chunk)
+		}
+
+		want, _ := r.Rat(nil)
+		if sum.Cmp(want) != 0 {
+			t.Errorf("chunks of %s sum to %s", test, sum.FloatString(60))
+		}
+	}
+
+	if chunks := bitBurstChunks(new(big.Float)); len(chunks) != 0 {
+		t.Errorf("bitBurstChunks(0) = %v, want none", chunks)
+	}
+}
+
+func TestExpBitBurst(t *testing.T) {
+	values := []float64{1e-20, 0.3, -0.3, 1, 2.7, -12.5, 100, -745.1, 5000}
+
+	for _, prec := range []uint{53, 256, 2000, 20000} {
+		for _, v := range values {
+			t.Run(fmt.Sprintf("exp(%g)_prec_%d", v, prec), func(t *testing.T) {
+				x := new(big.Float).SetPrec(prec).SetFloat64(v)
+				got := expBitBurst(context.Background(), x)
+				if got.Prec() != prec {
+					t.Errorf("expBitBurst(%g) has precision %d, want %d", v, got.Prec(), prec)
+				}
+
+				// The float64 value is exactly representable as a rational.
+				xr, _ := x.Rat(nil)
+				want := expRat(context.Background(), xr, prec+64)
+				if agree := bitsOfAgreement(got, want); agree < int(prec)-2 {
+					t.Errorf("expBitBurst(%g) only agrees to %d bits", v, agree)
+				}
+			})
+		}
+	}
+
+	// e^1 = e
+	for _, prec := range []uint{1000, 50000} {
+		got := expBitBurst(context.Background(), new(big.Float).SetPrec(prec).SetInt64(1))
+		if agree := bitsOfAgreement(got, ComputeE(prec+64)); agree < int(prec)-2 {
+			t.Errorf("expBitBurst(1) at %d bits only agrees with e to %d bits", prec, agree)
+		}
+	}
+}
+
+func TestExpBitBurstEdgeCases(t *testing.T) {
+	if got := expBitBurst(context.Background(), new(big.Float).SetPrec(200)); got.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("expBitBurst(0) = %v, want 1", got)
+	}
+
+	if got := expBitBurst(context.Background(), new(big.Float).SetPrec(200).SetInf(false)); !got.IsInf() || got.Signbit() {
+		t.Errorf("expBitBurst(+Inf) = %v, want +Inf", got)
+	}
+
+	if got := expBitBurst(context.Background(), new(big.Float).SetPrec(200).SetInf(true)); got.Sign() != 0 {
+		t.Errorf("expBitBurst(-Inf) = %v, want 0", got)
+	}
+
+	huge := new(big.Float).SetPrec(200).SetFloat64(1e300)
+	if got := expBitBurst(context.Background(), huge); !got.IsInf() {
+		t.Errorf("expBitBurst(1e300) = %v, want +Inf", got)
+	}
+}
+
+func TestSinCosBitBurst(t *testing.T) {
+	values := []float64{1e-30, 0.1, -0.5, 1, math.Pi / 2, 3, -5, 100, 1e10, 3.14159265358979}
+
+	for _, prec := range []uint{53, 1000, 10000} {
+		for _, v := range values {
+			t.Run(fmt.Sprintf("sincos(%g)_prec_%d", v, prec), func(t *testing.T) {
+				x := new(big.Float).SetPrec(prec).SetFloat64(v)
+				sin, cos := sinCosBitBurst(context.Background(), x)
+
+				sinF, _ := sin.Float64()
+				cosF, _ := cos.Float64()
+				if math.Abs(sinF-math.Sin(v)) > 1e-15 || math.Abs(cosF-math.Cos(v)) > 1e-15 {
+					t.Errorf("sinCosBitBurst(%g) = %v, %v, want %v, %v", v, sinF, cosF, math.Sin(v), math.Cos(v))
+				}
+
+				// sin² + cos² = 1
+				sum := new(big.Float).SetPrec(prec+64).Mul(sin, sin)
+				sum.Add(sum, new(big.Float).SetPrec(prec+64).Mul(cos, cos))
+				if agree := bitsOfAgreement(sum, big.NewFloat(1)); agree < int(prec)-2 {
+					t.Errorf("sin²(%g) + cos²(%g) only agrees with 1 to %d bits", v, v, agree)
+				}
+			})
+		}
+	}
+
+	// sin(π/6) = 1/2 and cos(π/3) = 1/2
+	for _, prec := range []uint{1000, 30000} {
+		sixth := ComputePi(prec)
+		sixth.Quo(sixth, big.NewFloat(6))
+		sin, _ := sinCosBitBurst(context.Background(), sixth)
+		if agree := bitsOfAgreement(sin, big.NewFloat(0.5)); agree < int(prec)-2 {
+			t.Errorf("sin(π/6) at %d bits only agrees with 1/2 to %d bits", prec, agree)
+		}
+
+		third := ComputePi(prec)
+		third.Quo(third, big.NewFloat(3))
+		_, cos := sinCosBitBurst(context.Background(), third)
+		if agree := bitsOfAgreement(cos, big.NewFloat(0.5)); agree < int(prec)-2 {
+			t.Errorf("cos(π/3) at %d bits only agrees with 1/2 to %d bits", prec, agree)
+		}
+	}
+}
+
+func TestSinBitBurstNearMultipleOfPi(t *testing.T) {
+	// The 400 bit approximation of π is within 2^-400 of π, so its sine is
+	// tiny and all of its leading bits cancel during argument reduction.
+	const prec = 1200
+	x := ComputePi(400)
+	x.SetPrec(prec)
+
+	sin, _ := sinCosBitBurst(context.Background(), x)
+
+	// sin(π - δ) = sin(δ) = δ - δ³/6 + O(δ⁵) where δ = π - x ≈ 2^-403.
+	delta := ComputePi(prec + 600)
+	delta.Sub(delta, x)
+	want := new(big.Float).SetPrec(prec+200).Mul(delta, delta)
+	want.Mul(want, delta)
+	want.Quo(want, big.NewFloat(6))
+	want.Sub(delta, want)
+	if agree := bitsOfAgreement(sin, want); agree < prec-4 {
+		t.Errorf("sin(π₄₀₀) = %s only agrees with sin(π - π₄₀₀) = %s to %d bits",
+			sin.Text('g', 20), want.Text('g', 20), agree)
+	}
+}
+
+func BenchmarkExpBitBurst(b *testing.B) {
+	x := big.NewFloat(2.7)
+	for _, prec := range []uint{128, 1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("precision_%d", prec), func(b *testing.B) {
+			xp := new(big.Float).SetPrec(prec).Set(x)
+			for b.Loop() {
+				_ = expBitBurst(context.Background(), xp)
+			}
+		})
+	}
+}
+
+func BenchmarkSinCosBitBurst(b *testing.B) {
+	x := big.NewFloat(2.7)
+	for _, prec := range []uint{1000, 10000, 100000} {
+		b.Run(fmt.Sprintf("precision_%d", prec), func(b *testing.B) {
+			xp := new(big.Float).SetPrec(prec).Set(x)
+			for b.Loop() {
+				_, _ = sinCosBitBurst(context.Background(), xp)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// On [-π/4, π/4], with a = π/4 and r = a·t, sin and cos have the Chebyshev
+// series
+//
+//	cos(a·t) = J₀(a) + 2·Σ (−1)ᵏ J₂ₖ(a)·T₂ₖ(t)
+//	sin(a·t) = 2·Σ (−1)ᵏ J₂ₖ₊₁(a)·T₂ₖ₊₁(t)
+//
+// whose coefficients, the Bessel functions Jₙ(a) ≈ (a/2)ⁿ/n!, fall faster
+// than geometrically, so the series truncated before the first below
+// 2^-(w+3) is within 2^-w of the function everywhere on the interval. The
+// terms of the series for a precision are generated when it is first
+// asked for, from the power series of each Jₙ(a).
+//
+// T₂ₖ(t) = Tₖ(u) and T₂ₖ₊₁(t) = t·Vₖ(u) for u = 2t² − 1, where Vₖ are the
+// Chebyshev polynomials of the third kind, which follow the same
+// recurrence from V₀ = 1 and V₁ = 2u − 1. Summed in u by Clenshaw's
+// recurrence, each series takes half the steps, and sin keeps its relative
+// precision for small t.
+//
+// Truncating the series gives nearly the minimax polynomial of its degree,
+// the error being dominated by its first term left out, which equioscillates
+// like the error of the minimax polynomial. Rewritten in powers of r, the
+// same polynomial is evaluated by Horner's rule.
+
+// chebyshevMaxCachedPrec is the largest precision whose coefficients are
+// cached. The series of w bits have about w/7 terms of w bits, and their
+// powers take time growing as their square, so above it each call computes
+// its own.
+const chebyshevMaxCachedPrec = 8192
+
+// A chebyshevTable holds the Chebyshev series of sin and cos on
+// [-π/4, π/4] to one precision, and the same polynomials in powers of r.
+type chebyshevTable struct {
+	a   *big.Float   // π/4
+	sin []*big.Float // 2·(−1)ᵏ J₂ₖ₊₁(a), the coefficients of t·Vₖ(u)
+	cos []*big.Float // 2·(−1)ᵏ J₂ₖ(a), but J₀(a) for k = 0, of Tₖ(u)
+
+	once   sync.Once
+	sinPow []*big.Float // sin r = r·Σ sinPow[j]·r^2j
+	cosPow []*big.Float // cos r = Σ cosPow[j]·r^2j
+}
+
+// chebyshevTables holds the tables of each precision computed so far.
+var chebyshevTables = struct {
+	sync.Mutex
+	m map[uint]*chebyshevTable
+}{m: make(map[uint]*chebyshevTable)}
+
+// chebyshevTableFor returns the table of precision prec, from the cache if
+// it has been computed already. It must not be modified.
+func chebyshevTableFor(ctx context.Context, prec uint) *chebyshevTable {
+	if prec > chebyshevMaxCachedPrec {
+		return newChebyshevTable(ctx, prec)
+	}
+
+	chebyshevTables.Lock()
+	t, ok := chebyshevTables.m[prec]
+	chebyshevTables.Unlock()
+	if ok {
+		return t
+	}
+
+	t = newChebyshevTable(ctx, prec)
+
+	chebyshevTables.Lock()
+	defer chebyshevTables.Unlock()
+	if old, ok := chebyshevTables.m[prec]; ok {
+		// Another call computed it first.
+		return old
+	}
+	chebyshevTables.m[prec] = t
+
+	return t
+}
+
+// newChebyshevTable computes the Chebyshev series of precision prec.
+func newChebyshevTable(ctx context.Context, prec uint) *chebyshevTable {
+	s := getScratch()
+	defer s.release()
+
+	t := &chebyshevTable{a: piCache.get(ctx, prec)}
+	t.a.SetMantExp(t.a, -2)
+
+	// Jₙ(a) = (a/2)ⁿ/n! · Σ (−(a/2)²)ᵐ/(m!·(n+1)(n+2)…(n+m)).
+	work := seriesWork(prec)
+	h := piCache.get(ctx, work)
+	h.SetMantExp(h, -3)
+	y := s.float(work).Mul(h, h)
+	y.Neg(y)
+	first := s.float(work).SetInt64(1)
+	next := s.float(work)
+	nf := s.float(64)
+	sum := s.float(work)
+	for n := uint64(0); ; n++ {
+		if n > 0 {
+			next.Mul(first, h)
+			first.Quo(next, nf.SetUint64(n))
+		}
+		// |Jₙ(a)| <= (a/2)ⁿ/n!, and the rest of the terms sum to less.
+		if first.MantExp(nil) < -int(prec)-3 {
+			break
+		}
+
+		// Each series takes its own scratch, so that they are reused.
+		ss := getScratch()
+		sumSeries(ctx, ss, sum, first, y, func(m uint64) (uint64, uint64) { return 1, m * (m + n) })
+		ss.release()
+
+		c := new(big.Float).SetPrec(prec).Set(sum)
+		if n > 0 {
+			c.SetMantExp(c, 1)
+		}
+		if n%4 >= 2 {
+			c.Neg(c)
+		}
+		if n%2 == 0 {
+			t.cos = append(t.cos, c)
+		} else {
+			t.sin = append(t.sin, c)
+		}
+	}
+
+	return t
+}
+
+// powers returns the coefficients of the series of t in powers of r,
+// computing them on the first call.
+func (t *chebyshevTable) powers() (sin, cos []*big.Float) {
+	t.once.Do(t.computePowers)
+
+	return t.sinPow, t.cosPow
+}
+
+// computePowers sets sinPow and cosPow from the Chebyshev series.
+func (t *chebyshevTable) computePowers() {
+	prec := t.a.Prec()
+	n := len(t.sin) + len(t.cos)
+
+	// Every term of the Chebyshev series is at most 1 on the interval, and
+	// so is every power of t, so the coefficients of the powers of t are
+	// needed only to an absolute 2^-prec, with one rounding per term.
+	work := prec + uint(bits.Len(uint(n))) + 8
+	p := make([]*big.Float, n)
+	for m := range p {
+		p[m] = new(big.Float).SetPrec(work)
+	}
+
+	// T₀ = 1, T₁ = t and Tₖ₊₁ = 2t·Tₖ − Tₖ₋₁, with exact coefficients.
+	prev := []*big.Int{big.NewInt(1)}
+	cur := []*big.Int{big.NewInt(0), big.NewInt(1)}
+	f := new(big.Float).SetPrec(work)
+	for k := range n {
+		tk := cur
+		if k == 0 {
+			tk = prev
+		}
+		c := t.cos[k/2]
+		if k%2 == 1 {
+			c = t.sin[k/2]
+		}
+		for m, coef := range tk {
+			if coef.Sign() != 0 {
+				p[m].Add(p[m], f.Mul(f.SetInt(coef), c))
+			}
+		}
+
+		if k > 0 {
+			next := make([]*big.Int, len(cur)+1)
+			next[0] = new(big.Int).Neg(prev[0])
+			for m := 1; m < len(next); m++ {
+				next[m] = new(big.Int).Lsh(cur[m-1], 1)
+				if m < len(prev) {
+					next[m].Sub(next[m], prev[m])
+				}
+			}
+			prev, cur = cur, next
+		}
+	}
+
+	// The coefficient of r^m is that of t^m divided by a^m.
+	inv := new(big.Float).SetPrec(work).Quo(one, t.a)
+	pow := new(big.Float).SetPrec(work).SetInt64(1)
+	for m := range p {
+		c := new(big.Float).SetPrec(prec).Mul(p[m], pow)
+		if m%2 == 0 {
+			t.cosPow = append(t.cosPow, c)
+		} else {
+			t.sinPow = append(t.sinPow, c)
+		}
+		pow.Mul(pow, inv)
+	}
+}
+
+// clenshaw returns Σ c[k]·φₖ(u) with the precision of u, where φ₀ = 1 and
+// φₖ₊₁ = 2u·φₖ − φₖ₋₁, from φ₁ = u for the Chebyshev polynomials Tₖ, or
+// φ₁ = 2u − 1 for those of the third kind, Vₖ, if third is true.
+func clenshaw(s *scratch, c []*big.Float, u *big.Float, third bool) *big.Float {
+	prec := u.Prec()
+	u2 := s.float(prec).SetMantExp(u, 1)
+
+	// bₖ = c[k] + 2u·bₖ₊₁ − bₖ₊₂, down to b₁.
+	b1 := s.float(prec)
+	b2 := s.float(prec)
+	b := s.float(prec)
+	for k := len(c) - 1; k >= 1; k-- {
+		b.Mul(u2, b1)
+		b.Sub(b, b2)
+		b.Add(b, c[k])
+		b1, b2, b = b, b1, b2
+	}
+
+	// The sum is c[0] + φ₁·b₁ − b₂.
+	phi := s.float(prec).Set(u)
+	if third {
+		phi.Sub(u2, one)
+	}
+	result := new(big.Float).SetPrec(prec).Mul(phi, b1)
+	result.Sub(result, b2)
+
+	return result.Add(result, c[0])
+}
+
+// horner returns Σ c[j]·yʲ with the precision of y.
+func horner(c []*big.Float, y *big.Float) *big.Float {
+	result := new(big.Float).SetPrec(y.Prec()).Set(c[len(c)-1])
+	for j := len(c) - 2; j >= 0; j-- {
+		result.Mul(result, y)
+		result.Add(result, c[j])
+	}
+
+	return result
+}
+
+// chebyshevSinCos returns sin x and cos x with the precision of x, to
+// within a unit in the last place, from the Chebyshev series on
+// [-π/4, π/4] or, if powers is true, the same polynomials in powers of x.
+// x must be finite.
+func chebyshevSinCos(ctx context.Context, x *big.Float, powers bool) (sin, cos *big.Float) {
+	prec := x.Prec()
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x), new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	// Rounded up to whole words, the precisions share fewer tables.
+	work := (seriesWork(prec) + 63) &^ 63
+	t := chebyshevTableFor(ctx, work)
+	r, quadrant := reduceHalfPi(ctx, x, work)
+
+	s := getScratch()
+	defer s.release()
+
+	var sr, cr *big.Float
+	if powers {
+		// sin r = r·S(r²) keeps the relative precision of r.
+		sinPow, cosPow := t.powers()
+		r2 := s.float(work).Mul(r, r)
+		sr = horner(sinPow, r2)
+		sr.Mul(sr, r)
+		cr = horner(cosPow, r2)
+	} else {
+		tr := s.float(work).Quo(r, t.a)
+		u := s.float(work).Mul(tr, tr)
+		u.SetMantExp(u, 1)
+		u.Sub(u, one)
+		sr = clenshaw(s, t.sin, u, true)
+		sr.Mul(sr, tr)
+		cr = clenshaw(s, t.cos, u, false)
+	}
+
+	return unreduceHalfPi(sr, cr, quadrant, prec)
+}
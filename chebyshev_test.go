@@ -0,0 +1,134 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+var chebyshevTestValues = []string{"1/3", "-7/5", "1/1000000000000", "355/113", "785398/1000000", "1000000", "-11/7"}
+
+func TestChebyshevKernels(t *testing.T) {
+	for _, prec := range []uint{53, 256, 512, 999, 3000} {
+		for _, s := range chebyshevTestValues {
+			x := newRatFloat(s, prec)
+			want := Sin(x)
+			for _, k := range []struct {
+				name string
+				fn   func(*big.Float) *big.Float
+			}{
+				{"sinChebyshev", sinChebyshev},
+				{"sinMinimax", sinMinimax},
+			} {
+				if got := k.fn(x); got.Prec() != prec || !withinUlp(got, want) {
+					t.Errorf("%s(%s) at %d bits = %s, want %s", k.name, s, prec, got.Text('g', 40), want.Text('g', 40))
+				}
+			}
+		}
+	}
+
+	if got := sinMinimax(new(big.Float).SetPrec(100)); got.Sign() != 0 {
+		t.Errorf("sinMinimax(0) = %v", got)
+	}
+}
+
+// TestSinMidPrecision checks that Sin is correctly rounded at the
+// precisions the polynomial kernels were once used for, against CORDIC
+// with 64 more bits.
+func TestSinMidPrecision(t *testing.T) {
+	for _, prec := range []uint{256, 400, 640, 999} {
+		for _, s := range chebyshevTestValues {
+			x := newRatFloat(s, prec)
+			want := sinCORDIC(new(big.Float).SetPrec(prec + 64).Set(x))
+			want.SetPrec(prec)
+			if got := Sin(x); got.Cmp(want) != 0 {
+				t.Errorf("Sin(%s) at %d bits = %s, want %s", s, prec, got.Text('g', 40), want.Text('g', 40))
+			}
+		}
+	}
+}
+
+func TestChebyshevTable(t *testing.T) {
+	const prec = 320
+	table := chebyshevTableFor(context.Background(), prec)
+	if again := chebyshevTableFor(context.Background(), prec); again != table {
+		t.Errorf("the table of %d bits was computed again", prec)
+	}
+
+	// The first coefficients are J₀(π/4), 2·J₁(π/4) and −2·J₂(π/4).
+	a := math.Pi / 4
+	for _, tt := range []struct {
+		c    *big.Float
+		want float64
+	}{
+		{table.cos[0], math.J0(a)},
+		{table.sin[0], 2 * math.J1(a)},
+		{table.cos[1], -2 * math.Jn(2, a)},
+		{table.sin[1], -2 * math.Jn(3, a)},
+	} {
+		if got, _ := tt.c.Float64(); math.Abs(got-tt.want) > 1e-15 {
+			t.Errorf("coefficient = %v, want %v", got, tt.want)
+		}
+	}
+
+	// The last coefficient is just below the precision.
+	if e := table.sin[len(table.sin)-1].MantExp(nil); e > -prec+16 || e < -prec-16 {
+		t.Errorf("the last sin coefficient is 2^%d at %d bits", e, prec)
+	}
+
+	// In powers of r the polynomials are close to the Taylor series, though
+	// not to the full precision, as they are fitted to the interval.
+	sinPow, cosPow := table.powers()
+	for _, tt := range []struct {
+		c    *big.Float
+		want string
+	}{
+		{sinPow[0], "1"},
+		{sinPow[1], "-1/6"},
+		{cosPow[0], "1"},
+		{cosPow[1], "-1/2"},
+		{cosPow[2], "1/24"},
+	} {
+		want := newRatFloat(tt.want, prec)
+		if d := new(big.Float).Sub(tt.c, want); d.Sign() != 0 && d.MantExp(nil) > -prec/2 {
+			t.Errorf("power coefficient = %s, want %s", tt.c.Text('g', 40), tt.want)
+		}
+	}
+}
+
+func BenchmarkChebyshev(b *testing.B) {
+	for _, prec := range []uint{64, 256, 1024} {
+		x := newRatFloat("7/10", prec)
+
+		for _, k := range []struct {
+			name string
+			fn   func(*big.Float) *big.Float
+		}{
+			{"Sin", Sin},
+			{"sinChebyshev", sinChebyshev},
+			{"sinMinimax", sinMinimax},
+		} {
+			b.Run(fmt.Sprintf("%s/prec_%d", k.name, prec), func(b *testing.B) {
+				for b.Loop() {
+					k.fn(x)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Bigmathtune measures the precisions at which Exp, Sin and Cos of package
+// bigmath switch from power series to the bit-burst algorithm on this
+// machine, and writes them as a profile for bigmath.LoadThresholds.
+//
+// Usage:
+//
+//	bigmathtune [-o file] [-d duration]
+//
+// The profile is written to standard output unless -o is given. It suits
+// go:generate, to embed a profile measured on the build machine:
+//
+//	//go:generate go run github.com/rsned/bigmath/cmd/bigmathtune -o thresholds.json
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rsned/bigmath"
+)
+
+func main() {
+	out := flag.String("o", "", "write the profile to `file` rather than standard output")
+	duration := flag.Duration("d", 0, "least time to time each kernel on each argument (default 10ms)")
+	flag.Parse()
+
+	if err := run(*out, *duration); err != nil {
+		fmt.Fprintln(os.Stderr, "bigmathtune:", err)
+		os.Exit(1)
+	}
+}
+
+// run tunes the thresholds and writes them to the named file, or to
+// standard output if name is empty.
+func run(name string, duration time.Duration) error {
+	t, err := bigmath.Tune(context.Background(), &bigmath.TuneOptions{Duration: duration})
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		return t.Save(os.Stdout)
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := t.Save(f); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,350 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+const (
+	// binomialPrimeMinK is the smallest k for which Binomial uses the
+	// prime factorization of C(n, k). For smaller k the quotient of two
+	// short products is cheaper than sieving the primes up to n.
+	binomialPrimeMinK = 64
+
+	// binomialPrimeMaxRatio is the largest n/k for which Binomial uses
+	// the prime factorization. By benchmarking, the cost of the sieve
+	// dominates when k is a smaller fraction of n than this.
+	binomialPrimeMaxRatio = 128
+
+	// binomialPrimeMaxN is the largest n for which Binomial will sieve
+	// the primes up to n.
+	binomialPrimeMaxN = 1 << 28
+
+	// pochhammerProductLimit is the largest integer n for which the
+	// rising and falling factorials of a non-integer x are computed as a
+	// product of n terms rather than as a ratio of Gamma functions.
+	pochhammerProductLimit = 1 << 16
+)
+
+// Binomial returns the binomial coefficient C(n, k), the number of ways of
+// choosing k items from n, exactly.
+//
+// For 0 <= k <= n, C(n, k) = n! / (k!·(n-k)!). Outside of that range it is
+// 0, except for negative n where the generalized coefficient
+// C(n, k) = (-1)^k·C(k-n-1, k) is returned. nil is returned if the result
+// is too large to be represented.
+func Binomial(n, k int64) *big.Int {
+	if n < 0 {
+		if k < 0 {
+			return big.NewInt(0)
+		}
+		m := k - n - 1
+		if m < 0 {
+			// k - n - 1 overflowed int64.
+			return nil
+		}
+		result := Binomial(m, k)
+		if result != nil && k&1 == 1 {
+			result.Neg(result)
+		}
+
+		return result
+	}
+
+	if k < 0 || k > n {
+		return big.NewInt(0)
+	}
+
+	k = min(k, n-k)
+	if k < binomialPrimeMinK || n/k > binomialPrimeMaxRatio || n > binomialPrimeMaxN {
+		return new(big.Int).Binomial(n, k)
+	}
+
+	return binomialPrime(uint64(n), uint64(k))
+}
+
+// binomialPrime computes C(n, k) from its prime factorization.
+//
+// By Kummer's theorem, the exponent of a prime p in C(n, k) is the number
+// of borrows when k is subtracted from n in base p, and p raised to that
+// exponent never exceeds n.
+func binomialPrime(n, k uint64) *big.Int {
+	k = min(k, n-k)
+	factors := make([]uint64, 0, 64)
+
+	// The power of two.
+	twos := uint(bits.OnesCount64(k) + bits.OnesCount64(n-k) - bits.OnesCount64(n))
+
+	for _, p := range oddPrimesUpTo(n) {
+		// With k <= n-k, primes above n-k divide n!/(n-k)! once and do not
+		// divide k!, while there is never a borrow for those between n/2
+		// and n-k.
+		if p > n-k {
+			factors = append(factors, p)
+
+			continue
+		}
+		if p > n/2 {
+			continue
+		}
+
+		pe := uint64(1)
+		borrow := uint64(0)
+		for nn, kk := n, k; nn > 0; nn, kk = nn/p, kk/p {
+			if kk%p+borrow > nn%p {
+				pe *= p
+				borrow = 1
+			} else {
+				borrow = 0
+			}
+		}
+		if pe > 1 {
+			factors = append(factors, pe)
+		}
+	}
+
+	result := productTree(context.Background(), factors)
+
+	return result.Lsh(result, twos)
+}
+
+// Multinomial returns the multinomial coefficient
+//
+//	(k₁ + k₂ + … + kₘ)! / (k₁!·k₂!···kₘ!)
+//
+// the number of ways of dividing k₁ + k₂ + … + kₘ items into groups of
+// those sizes. It is 0 if any kᵢ is negative, and nil if the sum of the
+// kᵢ overflows an int64.
+func Multinomial(ks ...int64) *big.Int {
+	result := big.NewInt(1)
+	sum := int64(0)
+	for _, k := range ks {
+		if k < 0 {
+			return big.NewInt(0)
+		}
+		if sum > math.MaxInt64-k {
+			return nil
+		}
+		sum += k
+
+		// The product of C(k₁+…+kᵢ, kᵢ) telescopes into the multinomial.
+		result.Mul(result, Binomial(sum, k))
+	}
+
+	return result
+}
+
+// DoubleFactorial returns n!! = n·(n-2)·(n-4)···, the product of the
+// positive integers up to n with the same parity as n.
+//
+// By convention 0!! = (-1)!! = 1. The double factorial of other negative
+// numbers is not an integer, so 0 is returned for them.
+func DoubleFactorial(n int64) *big.Int {
+	switch {
+	case n < -1:
+		return big.NewInt(0)
+	case n < 2:
+		return big.NewInt(1)
+	case n&1 == 0:
+		// (2m)!! = 2^m · m!
+		m := n / 2
+		result := Factorial(m)
+
+		return result.Lsh(result, uint(m))
+	}
+
+	// The product of the odd numbers 3·5···n.
+	odds := make([]uint64, 0, n/2)
+	for i := uint64(3); i <= uint64(n); i += 2 {
+		odds = append(odds, i)
+	}
+
+	return productTree(context.Background(), odds)
+}
+
+// Subfactorial returns !n, the number of derangements of n items, which
+// are the permutations that leave no item in its original position.
+//
+//	!n = n! · Σ_{k=0}^{n} (-1)^k / k!
+//
+// The sum is evaluated exactly by binary splitting. Negative values of n
+// return 0.
+func Subfactorial(n int64) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+
+	// With q(k) = k, the Q of the split over [0, n+1) is n! and T is
+	// n! times the sum, which is the integer we want.
+	s := &Series{
+		P: func(k int64) *big.Int {
+			if k == 0 {
+				return big.NewInt(1)
+			}
+
+			return big.NewInt(-1)
+		},
+		Q: func(k int64) *big.Int {
+			if k == 0 {
+				return big.NewInt(1)
+			}
+
+			return big.NewInt(k)
+		},
+	}
+
+	return s.Split(0, n+1).T
+}
+
+// Primorial returns n#, the product of all of the primes less than or
+// equal to n. Values of n less than 2 return 1.
+func Primorial(n int64) *big.Int {
+	if n < 2 {
+		return big.NewInt(1)
+	}
+
+	result := productTree(context.Background(), oddPrimesUpTo(uint64(n)))
+
+	return result.Lsh(result, 1)
+}
+
+// Pochhammer returns the Pochhammer symbol (x)ₙ, which is the rising
+// factorial of x. See RisingFactorial.
+func Pochhammer(x, n *big.Float) *big.Float {
+	return RisingFactorial(x, n)
+}
+
+// RisingFactorial returns the rising factorial
+//
+//	x^(n) = x·(x+1)···(x+n-1) = Γ(x+n) / Γ(x)
+//
+// to the precision of x.
+//
+// For integer n the product is computed directly, and exactly when x is
+// also an integer. Otherwise the ratio of Gamma functions is used, which is
+// limited by the accuracy of Gamma. If Γ(x) has a pole and Γ(x+n) does not,
+// the result is 0.
+func RisingFactorial(x, n *big.Float) *big.Float {
+	prec := x.Prec()
+
+	if count, ok := smallInt(n); ok {
+		if count < 0 {
+			// x^(-m) = 1 / (x-1)_(m), the falling factorial of x-1.
+			xm1 := new(big.Float).SetPrec(prec).Sub(x, one)
+			result := FallingFactorial(xm1, new(big.Float).SetInt64(-count))
+
+			return result.Quo(new(big.Float).SetPrec(prec).SetInt64(1), result)
+		}
+
+		if start, ok := smallInt(x); ok && start <= math.MaxInt64-count {
+			return new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(start, start+count-1))
+		}
+
+		if count <= pochhammerProductLimit {
+			return factorialProduct(x, count, one)
+		}
+	}
+
+	xn := new(big.Float).SetPrec(prec).Add(x, n)
+
+	return gammaRatio(xn, x, prec)
+}
+
+// FallingFactorial returns the falling factorial
+//
+//	(x)ₙ = x·(x-1)···(x-n+1) = Γ(x+1) / Γ(x-n+1)
+//
+// to the precision of x.
+//
+// For integer n the product is computed directly, and exactly when x is
+// also an integer. Otherwise the ratio of Gamma functions is used, which is
+// limited by the accuracy of Gamma. If Γ(x-n+1) has a pole and Γ(x+1) does
+// not, the result is 0.
+func FallingFactorial(x, n *big.Float) *big.Float {
+	prec := x.Prec()
+
+	if count, ok := smallInt(n); ok {
+		if count < 0 {
+			// (x)_(-m) = 1 / (x+1)^(m), the rising factorial of x+1.
+			xp1 := new(big.Float).SetPrec(prec).Add(x, one)
+			result := RisingFactorial(xp1, new(big.Float).SetInt64(-count))
+
+			return result.Quo(new(big.Float).SetPrec(prec).SetInt64(1), result)
+		}
+
+		if end, ok := smallInt(x); ok && end >= math.MinInt64+count {
+			return new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(end-count+1, end))
+		}
+
+		if count <= pochhammerProductLimit {
+			return factorialProduct(x, count, negOne)
+		}
+	}
+
+	xp1 := new(big.Float).SetPrec(prec).Add(x, one)
+	den := new(big.Float).SetPrec(prec).Sub(xp1, n)
+
+	return gammaRatio(xp1, den, prec)
+}
+
+// negOne is the step for the falling factorial product.
+var negOne = big.NewFloat(-1)
+
+// factorialProduct returns x·(x+step)···(x+(n-1)·step) to the precision
+// of x, using enough guard bits to absorb the rounding of n products.
+func factorialProduct(x *big.Float, n int64, step *big.Float) *big.Float {
+	prec := x.Prec()
+	work := prec + uint(bits.Len64(uint64(n))) + 8
+
+	result := new(big.Float).SetPrec(work).SetInt64(1)
+	term := new(big.Float).SetPrec(work).Set(x)
+	for i := int64(0); i < n; i++ {
+		result.Mul(result, term)
+		term.Add(term, step)
+	}
+
+	return result.SetPrec(prec)
+}
+
+// gammaRatio returns Γ(a) / Γ(b) rounded to the given precision.
+func gammaRatio(a, b *big.Float, prec uint) *big.Float {
+	den := Gamma(new(big.Float).Copy(b))
+	if den.IsInf() {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	num := Gamma(new(big.Float).Copy(a))
+	if num.IsInf() {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	return new(big.Float).SetPrec(prec).Quo(num, den)
+}
+
+// smallInt reports whether x is an integer that fits in an int64, and
+// returns it if it is.
+func smallInt(x *big.Float) (int64, bool) {
+	if x.IsInf() || !x.IsInt() {
+		return 0, false
+	}
+
+	i, acc := x.Int64()
+
+	return i, acc == big.Exact
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBinomial(t *testing.T) {
+	tests := []struct {
+		n, k int64
+		want string
+	}{
+		{0, 0, "1"},
+		{5, 0, "1"},
+		{5, 2, "10"},
+		{5, 5, "1"},
+		{5, 6, "0"},
+		{5, -1, "0"},
+		{52, 5, "2598960"},
+		{100, 50, "100891344545564193334812497256"},
+		// Generalized coefficients of negative n.
+		{-1, 3, "-1"},
+		{-4, 2, "10"},
+		{-4, 3, "-20"},
+		{-4, -2, "0"},
+	}
+
+	for _, test := range tests {
+		if got := Binomial(test.n, test.k); got.String() != test.want {
+			t.Errorf("Binomial(%d, %d) = %v, want %s", test.n, test.k, got, test.want)
+		}
+	}
+
+	if got := Binomial(math.MinInt64+1, math.MaxInt64); got != nil {
+		t.Errorf("Binomial(MinInt64+1, MaxInt64) = %v, want nil", got)
+	}
+}
+
+func TestBinomialMethods(t *testing.T) {
+	// Every coefficient of the small rows.
+	for n := uint64(0); n < 200; n++ {
+		for k := uint64(0); k <= n; k++ {
+			want := new(big.Int).Binomial(int64(n), int64(k))
+			if got := binomialPrime(n, k); got.Cmp(want) != 0 {
+				t.Errorf("binomialPrime(%d, %d) = %v, want %v", n, k, got, want)
+			}
+		}
+	}
+
+	// Larger values that go through each path of Binomial.
+	tests := [][2]int64{{1000, 63}, {1000, 64}, {1000, 500}, {1 << 16, 511}, {1 << 16, 512}, {20000, 9999}}
+	for _, test := range tests {
+		n, k := test[0], test[1]
+		want := new(big.Int).Binomial(n, k)
+		if got := Binomial(n, k); got.Cmp(want) != 0 {
+			t.Errorf("Binomial(%d, %d) did not match big.Int.Binomial", n, k)
+		}
+	}
+}
+
+func TestBinomialIdentities(t *testing.T) {
+	// Σ_k C(n, k) = 2^n
+	for _, n := range []int64{10, 257, 1000} {
+		sum := new(big.Int)
+		for k := int64(0); k <= n; k++ {
+			sum.Add(sum, Binomial(n, k))
+		}
+		if want := new(big.Int).Lsh(big.NewInt(1), uint(n)); sum.Cmp(want) != 0 {
+			t.Errorf("Σ Binomial(%d, k) = %v, want 2^%d", n, sum, n)
+		}
+	}
+
+	// C(2n, n) = (2n)! / n!²
+	n := int64(3000)
+	want := Factorial(2 * n)
+	fn := Factorial(n)
+	want.Quo(want, fn.Mul(fn, fn))
+	if got := Binomial(2*n, n); got.Cmp(want) != 0 {
+		t.Errorf("Binomial(%d, %d) did not match (2n)!/n!²", 2*n, n)
+	}
+}
+
+func TestMultinomial(t *testing.T) {
+	tests := []struct {
+		ks   []int64
+		want string
+	}{
+		{nil, "1"},
+		{[]int64{5}, "1"},
+		{[]int64{3, 2}, "10"},
+		{[]int64{2, 2, 2}, "90"},
+		{[]int64{1, 4, 4, 2}, "34650"}, // MISSISSIPPI
+		{[]int64{0, 0, 3}, "1"},
+		{[]int64{3, -1}, "0"},
+	}
+
+	for _, test := range tests {
+		if got := Multinomial(test.ks...); got.String() != test.want {
+			t.Errorf("Multinomial(%v) = %v, want %s", test.ks, got, test.want)
+		}
+	}
+
+	if got := Multinomial(math.MaxInt64, 1); got != nil {
+		t.Errorf("Multinomial(MaxInt64, 1) = %v, want nil", got)
+	}
+}
+
+func TestDoubleFactorial(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{-3, "0"},
+		{-1, "1"},
+		{0, "1"},
+		{1, "1"},
+		{2, "2"},
+		{7, "105"},
+		{8, "384"},
+		{9, "945"},
+		{20, "3715891200"},
+		{25, "7905853580625"},
+	}
+
+	for _, test := range tests {
+		if got := DoubleFactorial(test.n); got.String() != test.want {
+			t.Errorf("DoubleFactorial(%d) = %v, want %s", test.n, got, test.want)
+		}
+	}
+
+	// n!! · (n-1)!! = n!
+	for _, n := range []int64{100, 1001, 5000} {
+		got := DoubleFactorial(n)
+		got.Mul(got, DoubleFactorial(n-1))
+		if got.Cmp(Factorial(n)) != 0 {
+			t.Errorf("DoubleFactorial(%d)·DoubleFactorial(%d) != %d!", n, n-1, n)
+		}
+	}
+}
+
+func TestSubfactorial(t *testing.T) {
+	want := []string{"1", "0", "1", "2", "9", "44", "265", "1854", "14833", "133496", "1334961"}
+	for n, w := range want {
+		if got := Subfactorial(int64(n)); got.String() != w {
+			t.Errorf("Subfactorial(%d) = %v, want %s", n, got, w)
+		}
+	}
+
+	if got := Subfactorial(-1); got.Sign() != 0 {
+		t.Errorf("Subfactorial(-1) = %v, want 0", got)
+	}
+
+	// !n = n·!(n-1) + (-1)^n
+	prev := Subfactorial(499)
+	want500 := new(big.Int).Mul(prev, big.NewInt(500))
+	want500.Add(want500, big.NewInt(1))
+	if got := Subfactorial(500); got.Cmp(want500) != 0 {
+		t.Errorf("Subfactorial(500) did not match 500·!499 + 1")
+	}
+}
+
+func TestPrimorial(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{-5, "1"},
+		{1, "1"},
+		{2, "2"},
+		{3, "6"},
+		{10, "210"},
+		{13, "30030"},
+		{30, "6469693230"},
+	}
+
+	for _, test := range tests {
+		if got := Primorial(test.n); got.String() != test.want {
+			t.Errorf("Primorial(%d) = %v, want %s", test.n, got, test.want)
+		}
+	}
+}
+
+func TestRisingFallingFactorial(t *testing.T) {
+	tests := []struct {
+		x, n      float64
+		rising    float64
+		falling   float64
+		tolerance float64
+	}{
+		// Integer x and n are exact.
+		{5, 3, 210, 60, 0},
+		{3, 5, 2520, 0, 0},
+		{-3, 2, 6, 12, 0},
+		{7, 0, 1, 1, 0},
+		// Non-integer x and integer n use the product.
+		{0.5, 3, 0.5 * 1.5 * 2.5, 0.5 * -0.5 * -1.5, 1e-30},
+		{2.5, -2, 1 / (1.5 * 0.5), 1 / (3.5 * 4.5), 1e-30},
+		// Non-integer n falls back to Gamma.
+		{1, 0.5, 0.886226925452758, 1.1283791670955126, 1e-10},
+		{0.5, 2.5, 2 / math.Sqrt(math.Pi), 0, 1e-10}, // Γ(-1) is a pole.
+	}
+
+	for _, test := range tests {
+		x := new(big.Float).SetPrec(200).SetFloat64(test.x)
+		n := new(big.Float).SetPrec(200).SetFloat64(test.n)
+
+		check := func(name string, got *big.Float, want, tolerance float64) {
+			t.Helper()
+			if got.Prec() != 200 {
+				t.Errorf("%s(%v, %v) has precision %d, want 200", name, test.x, test.n, got.Prec())
+			}
+			gotF, _ := got.Float64()
+			if math.Abs(gotF-want) > tolerance*math.Max(1, math.Abs(want)) {
+				t.Errorf("%s(%v, %v) = %v, want %v", name, test.x, test.n, gotF, want)
+			}
+		}
+
+		check("RisingFactorial", RisingFactorial(x, n), test.rising, test.tolerance)
+		check("FallingFactorial", FallingFactorial(x, n), test.falling, test.tolerance)
+		check("Pochhammer", Pochhammer(x, n), test.rising, test.tolerance)
+
+		// Check the arguments were not modified.
+		if xf, _ := x.Float64(); xf != test.x {
+			t.Errorf("x was changed from %v to %v", test.x, xf)
+		}
+	}
+}
+
+func TestRisingFactorialHighPrecision(t *testing.T) {
+	// (1/3)^(200) computed as a product must match the exact rational.
+	const prec = 1000
+	want := big.NewRat(1, 1)
+	for i := int64(0); i < 200; i++ {
+		want.Mul(want, big.NewRat(1+3*i, 3))
+	}
+	wantF := new(big.Float).SetPrec(prec + 64).SetRat(want)
+
+	x := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+	got := RisingFactorial(x, big.NewFloat(200))
+
+	// x itself is only 1/3 to within half an ulp, which costs a few bits.
+	if agree := bitsOfAgreement(got, wantF); agree < prec-12 {
+		t.Errorf("RisingFactorial(1/3, 200) only agrees to %d bits", agree)
+	}
+}
+
+func BenchmarkBinomial(b *testing.B) {
+	tests := [][2]int64{{1000, 10}, {1000, 500}, {100000, 1000}, {100000, 50000}}
+
+	for _, test := range tests {
+		n, k := test[0], test[1]
+		b.Run(fmt.Sprintf("Binomial_%d_%d", n, k), func(b *testing.B) {
+			for b.Loop() {
+				_ = Binomial(n, k)
+			}
+		})
+		b.Run(fmt.Sprintf("StdLib_%d_%d", n, k), func(b *testing.B) {
+			for b.Loop() {
+				_ = new(big.Int).Binomial(n, k)
+			}
+		})
+	}
+}
+
+func BenchmarkSubfactorial(b *testing.B) {
+	for _, n := range []int64{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("Subfactorial_%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_ = Subfactorial(n)
+			}
+		})
+	}
+}
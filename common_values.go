@@ -14,7 +14,13 @@
 
 package bigmath
 
-import "math/big"
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/bits"
+	"sync/atomic"
+)
 
 // Predefine some example values for pi and e to use.
 var (
@@ -74,8 +80,109 @@ func E() *big.Float {
 	return new(big.Float).Copy(bigE)
 }
 
-// ComputeE calculates e with the given precision using series expansion
+const (
+	// constantCacheMinPrec is the smallest precision a constantCache
+	// computes its constant at, so that the common precisions are all
+	// served by the first value.
+	constantCacheMinPrec = 1024
+
+	// constantCacheMaxPrec is the largest precision a constantCache keeps.
+	// Above it the constant is computed afresh on every call, so that one
+	// call at a huge precision does not leave the cache holding the value
+	// for the life of the program: each cache holds at most 256 KiB, where
+	// keeping every precision would hold 2 MiB after a single call at 2^24
+	// bits.
+	constantCacheMaxPrec = 1 << 20
+
+	// constantCacheGuardBits is how many bits the cached value must have
+	// beyond the requested precision, so that rounding it once more gives
+	// a result as accurate as computing it directly.
+	constantCacheGuardBits = 32
+)
+
+// constantCache holds the most precise value of a constant computed so
+// far. Kernels that need π or ln 2 on every call get it by rounding the
+// cached value, rather than summing its series again. It is safe for
+// concurrent use.
+type constantCache struct {
+	value   atomic.Pointer[big.Float]
+	compute func(ctx context.Context, precision uint) *big.Float
+}
+
+var (
+	piCache     = &constantCache{compute: computePiChudnovsky}
+	ln2Cache    = &constantCache{compute: computeLn2BinarySplit}
+	log2PiCache = &constantCache{compute: computeLog2Pi}
+)
+
+// computeLog2Pi calculates ln 2π with the given precision, for the
+// Stirling series of log Γ and the functional equation of ζ.
+func computeLog2Pi(ctx context.Context, precision uint) *big.Float {
+	twoPi := piCache.get(ctx, precision+8)
+	twoPi.Mul(twoPi, two)
+	log2Pi, _ := logE(ctx, twoPi)
+
+	return log2Pi.SetPrec(precision)
+}
+
+// get returns the constant rounded to prec bits.
+func (c *constantCache) get(ctx context.Context, prec uint) *big.Float {
+	return c.setTo(ctx, new(big.Float).SetPrec(prec))
+}
+
+// setTo sets z to the constant rounded to the precision of z, and returns
+// z.
+func (c *constantCache) setTo(ctx context.Context, z *big.Float) *big.Float {
+	need := z.Prec() + constantCacheGuardBits
+	if need > constantCacheMaxPrec {
+		return z.Set(c.compute(ctx, z.Prec()))
+	}
+
+	v := c.value.Load()
+	if v == nil || v.Prec() < need {
+		v = c.compute(ctx, max(need, 2*c.prec(), constantCacheMinPrec))
+
+		// Keep whichever of the concurrently computed values is best.
+		for {
+			old := c.value.Load()
+			if old != nil && old.Prec() >= v.Prec() || c.value.CompareAndSwap(old, v) {
+				break
+			}
+		}
+	}
+
+	return z.Set(v)
+}
+
+// prec returns the precision of the cached value, or 0 if there is none.
+func (c *constantCache) prec() uint {
+	if v := c.value.Load(); v != nil {
+		return v.Prec()
+	}
+
+	return 0
+}
+
+// ComputeE calculates e with the given precision using the series
+// e = Σ 1/n! evaluated by binary splitting.
 func ComputeE(precision uint) *big.Float {
+	return computeEBinarySplit(context.Background(), precision)
+}
+
+// ComputeECtx is like ComputeE, but stops and returns ctx.Err() if ctx is
+// done before the result is ready.
+func ComputeECtx(ctx context.Context, precision uint) (e *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return computeEBinarySplit(ctx, precision), nil
+}
+
+// computeETaylor calculates e with the given precision by summing the series
+// one term at a time.
+//
+// Because it stops after 200 terms this is limited to roughly 1,100 digits.
+// It is kept for performance comparison with the binary splitting method.
+func computeETaylor(precision uint) *big.Float {
 	e := new(big.Float).SetPrec(precision)
 	term := new(big.Float).SetPrec(precision).SetInt64(1)
 	factorial := new(big.Float).SetPrec(precision).SetInt64(1)
@@ -99,8 +206,30 @@ func ComputeE(precision uint) *big.Float {
 	return e
 }
 
-// ComputePi calculates π with the given precision using Machin's formula
+// ComputePi calculates π with the given precision using the Chudnovsky
+// series evaluated by binary splitting.
+//
+// By benchmarking, this is faster than Machin's formula at every precision
+// from 53 bits on up. It is faster than the Gauss–Legendre iteration too:
+// 2.2 times at 1024 bits, 6 times at 16384 and 10 times at 2^20, as each
+// step of the iteration takes a full precision square root and division,
+// which math/big does not speed up as it does the integer products of the
+// binary splitting.
 func ComputePi(precision uint) *big.Float {
+	return computePiChudnovsky(context.Background(), precision)
+}
+
+// ComputePiCtx is like ComputePi, but stops and returns ctx.Err() if ctx
+// is done before the result is ready.
+func ComputePiCtx(ctx context.Context, precision uint) (pi *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return computePiChudnovsky(ctx, precision), nil
+}
+
+// computePiMachin calculates π with the given precision using Machin's formula.
+// This is a package-private method for performance comparison.
+func computePiMachin(precision uint) *big.Float {
 	pi := new(big.Float).SetPrec(precision)
 
 	// Use Machin's formula: π/4 = 4*arctan(1/5) - arctan(1/239)
@@ -122,3 +251,96 @@ func ComputePi(precision uint) *big.Float {
 
 	return pi
 }
+
+// ComputeLn2 calculates ln(2) with the given precision using a Machin-like
+// atanh formula evaluated by binary splitting.
+func ComputeLn2(precision uint) *big.Float {
+	return computeLn2BinarySplit(context.Background(), precision)
+}
+
+// ComputeLn2Ctx is like ComputeLn2, but stops and returns ctx.Err() if ctx
+// is done before the result is ready.
+func ComputeLn2Ctx(ctx context.Context, precision uint) (ln2 *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return computeLn2BinarySplit(ctx, precision), nil
+}
+
+// computeLn2AGM calculates ln(2) with the given precision using the
+// arithmetic-geometric mean. For m large enough that 2^-2m is negligible,
+//
+//	ln(2) ≈ π / (2m·AGM(1, 4/2^m))
+//
+// This is a package-private method for performance comparison.
+func computeLn2AGM(precision uint) *big.Float {
+	work := precision + 64
+	m := int(work/2) + 2
+
+	b := new(big.Float).SetMantExp(four, -m).SetPrec(work)
+	a := agm(context.Background(), new(big.Float).SetPrec(work).SetInt64(1), b)
+	a.Mul(a, new(big.Float).SetPrec(work).SetInt64(int64(2*m)))
+
+	ln2 := piCache.get(context.Background(), work)
+	ln2.Quo(ln2, a)
+
+	return ln2.SetPrec(precision)
+}
+
+// ComputeEulerGamma calculates the Euler–Mascheroni constant
+//
+//	γ = lim_{n→∞} (H(n) - ln(n)) = 0.57721566490153286…
+//
+// with the given precision using the Brent–McMillan algorithm. With
+//
+//	A(k) = (A(k-1)·n²/k + B(k)) / k,  A(0) = -ln(n)
+//	B(k) = B(k-1)·n²/k²,              B(0) = 1
+//
+// γ ≈ ΣA(k) / ΣB(k), with an error of about π·e^(-4n).
+func ComputeEulerGamma(precision uint) *big.Float {
+	return computeEulerGamma(context.Background(), precision)
+}
+
+// ComputeEulerGammaCtx is like ComputeEulerGamma, but stops and returns
+// ctx.Err() if ctx is done before the result is ready.
+func ComputeEulerGammaCtx(ctx context.Context, precision uint) (gamma *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return computeEulerGamma(ctx, precision), nil
+}
+
+// computeEulerGamma implements ComputeEulerGamma.
+func computeEulerGamma(ctx context.Context, precision uint) *big.Float {
+	work := precision + 64 + uint(bits.Len(precision))
+
+	// π·e^(-4n) < 2^-work
+	n := int64(float64(work)*math.Ln2/4) + 2
+	terms := int64(math.Ceil(4.32 * float64(n)))
+
+	n2 := new(big.Float).SetPrec(work).SetInt64(n * n)
+
+	a := logAGM(ctx, new(big.Float).SetPrec(work).SetInt64(n))
+	a.Neg(a)
+	b := new(big.Float).SetPrec(work).SetInt64(1)
+	u := new(big.Float).SetPrec(work).Set(a)
+	v := new(big.Float).SetPrec(work).Set(b)
+
+	kf := new(big.Float).SetPrec(work)
+	for k := int64(1); k <= terms; k++ {
+		checkCtx(ctx)
+		kf.SetInt64(k)
+
+		b.Mul(b, n2)
+		b.Quo(b, kf)
+		b.Quo(b, kf)
+
+		a.Mul(a, n2)
+		a.Quo(a, kf)
+		a.Add(a, b)
+		a.Quo(a, kf)
+
+		u.Add(u, a)
+		v.Add(v, b)
+	}
+
+	return u.Quo(u, v).SetPrec(precision)
+}
@@ -15,6 +15,7 @@
 package bigmath
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strings"
@@ -111,6 +112,35 @@ func TestComputePi(t *testing.T) {
 	}
 }
 
+func TestComputeLn2(t *testing.T) {
+	// ln(2) from https://oeis.org/A002162
+	const ln2 = "0.693147180559945309417232121458176568075500134360255254120680009"
+
+	for _, prec := range []uint{53, 128, 200, 1000} {
+		t.Run(fmt.Sprintf("precision_%d", prec), func(t *testing.T) {
+			computed := ComputeLn2(prec)
+			expected, _ := new(big.Float).SetPrec(prec).SetString(ln2)
+
+			diff := new(big.Float).Sub(computed, expected)
+			if diff.Sign() != 0 && diff.MantExp(nil) > max(-int(prec)+2, -200) {
+				t.Errorf("ComputeLn2(%d) = %s, want %s", prec, computed.Text('g', 60), ln2)
+			}
+		})
+	}
+}
+
+func TestLog2PiCache(t *testing.T) {
+	for _, prec := range []uint{53, 200, 2000} {
+		twoPi, _ := new(big.Float).SetPrec(prec + 64).SetString(piKnown1000)
+		twoPi.Mul(twoPi, two)
+		want := new(big.Float).SetPrec(prec).Set(Log(twoPi))
+
+		if got := log2PiCache.get(context.Background(), prec); got.Cmp(want) != 0 {
+			t.Errorf("log2PiCache.get(%d) = %s, want %s", prec, got.Text('g', 40), want.Text('g', 40))
+		}
+	}
+}
+
 func TestComputeEDigitByDigit(t *testing.T) {
 	// Test individual digits of e for accuracy
 	computed := ComputeE(4000) // High precision
@@ -222,3 +252,51 @@ func BenchmarkComputePi(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkComputeLn2(b *testing.B) {
+	for _, prec := range precisions {
+		b.Run(fmt.Sprintf("precision_%d", prec), func(b *testing.B) {
+			b.ResetTimer()
+			for b.Loop() {
+				_ = ComputeLn2(prec)
+			}
+		})
+	}
+}
+
+func TestComputeEulerGamma(t *testing.T) {
+	const want = "0.5772156649015328606065120900824024310421593359399235988057672348848677267776646709369470632917467495"
+
+	for _, prec := range []uint{53, 200, 332} {
+		got := ComputeEulerGamma(prec)
+		if got.Prec() != prec {
+			t.Errorf("ComputeEulerGamma(%d) has precision %d", prec, got.Prec())
+		}
+
+		wantF, _ := new(big.Float).SetPrec(prec + 64).SetString(want)
+		if agree := bitsOfAgreement(got, wantF); agree < int(prec)-1 {
+			t.Errorf("ComputeEulerGamma(%d) only agrees to %d bits", prec, agree)
+		}
+	}
+
+	// The limit definition, H(n) - ln(n) - 1/(2n) = γ - 1/(12n²) + O(1/n⁴).
+	const n = 1 << 14
+	const prec = 120
+	h := new(big.Float).SetPrec(prec).SetRat(Harmonic(n))
+	h.Sub(h, Log(new(big.Float).SetPrec(prec).SetInt64(n)))
+	h.Sub(h, new(big.Float).SetPrec(prec).SetFloat64(1.0/(2*n)))
+	h.Add(h, new(big.Float).SetPrec(prec).SetFloat64(1.0/(12.0*n*n)))
+	if agree := bitsOfAgreement(h, ComputeEulerGamma(prec)); agree < 60 {
+		t.Errorf("H(n) - ln(n) only agrees with γ to %d bits", agree)
+	}
+}
+
+func BenchmarkComputeEulerGamma(b *testing.B) {
+	for _, prec := range precisions {
+		b.Run(fmt.Sprintf("precision_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				_ = ComputeEulerGamma(prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,1038 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+// complexGuardBits is the number of extra bits of working precision used by
+// the Complex functions.
+const complexGuardBits = 64
+
+// Complex is an arbitrary precision complex number re + im·i, with both
+// parts held as big.Float values of the same precision.
+//
+// The zero value is 0 + 0i with precision 0, and as with big.Float the
+// precision of the receiver of an operation is set from its operands when
+// it is 0. Like big.Float, a Complex must not be copied by value; use Set.
+//
+// The elementary functions mirror math/cmplx, including its branch cuts and
+// the handling of signed zeros. big.Float has no NaN, so where math/cmplx
+// would return NaN for a part, +Inf is used instead. Arguments with an
+// infinite part are handled by the math/cmplx special cases, with any
+// finite part rounded to a float64 to pick the case.
+type Complex struct {
+	re, im big.Float
+}
+
+// NewComplex returns a new Complex with the value re + im·i and the larger
+// of the precisions of re and im.
+func NewComplex(re, im *big.Float) *Complex {
+	prec := max(re.Prec(), im.Prec())
+	z := new(Complex)
+	z.re.SetPrec(prec).Set(re)
+	z.im.SetPrec(prec).Set(im)
+
+	return z
+}
+
+// NewComplex128 returns a new Complex with the value of c and a precision
+// of 53 bits.
+func NewComplex128(c complex128) *Complex {
+	return new(Complex).SetComplex128(c)
+}
+
+// Real returns the real part of x. The result is a reference to x's real
+// part; it may change if a new value is assigned to x, and vice versa.
+func (x *Complex) Real() *big.Float {
+	return &x.re
+}
+
+// Imag returns the imaginary part of x. The result is a reference to x's
+// imaginary part; it may change if a new value is assigned to x, and vice
+// versa.
+func (x *Complex) Imag() *big.Float {
+	return &x.im
+}
+
+// Prec returns the precision of x in bits.
+func (x *Complex) Prec() uint {
+	return max(x.re.Prec(), x.im.Prec())
+}
+
+// SetPrec sets the precision of both parts of z to prec, rounding them if
+// needed, and returns z.
+func (z *Complex) SetPrec(prec uint) *Complex {
+	z.re.SetPrec(prec)
+	z.im.SetPrec(prec)
+
+	return z
+}
+
+// Set sets z to x, rounded to the precision of z, and returns z. If z's
+// precision is 0, it is changed to the precision of x first.
+func (z *Complex) Set(x *Complex) *Complex {
+	if z != x {
+		prec := z.resultPrec(x)
+		z.re.SetPrec(prec).Set(&x.re)
+		z.im.SetPrec(prec).Set(&x.im)
+	}
+
+	return z
+}
+
+// SetComplex128 sets z to c and returns z. If z's precision is 0, it is
+// changed to 53.
+func (z *Complex) SetComplex128(c complex128) *Complex {
+	prec := z.Prec()
+	if prec == 0 {
+		prec = 53
+	}
+
+	return z.setSpecial(c, prec)
+}
+
+// Complex128 returns the complex128 value nearest to x.
+func (x *Complex) Complex128() complex128 {
+	re, _ := x.re.Float64()
+	im, _ := x.im.Float64()
+
+	return complex(re, im)
+}
+
+// IsInf reports whether either part of x is infinite.
+func (x *Complex) IsInf() bool {
+	return x.re.IsInf() || x.im.IsInf()
+}
+
+// Text converts x to a string of the form (re+imi), formatting each part
+// with big.Float.Text using the given format and number of digits.
+func (x *Complex) Text(format byte, digits int) string {
+	im := x.im.Text(format, digits)
+	if !x.im.Signbit() {
+		im = "+" + im
+	}
+
+	return "(" + x.re.Text(format, digits) + im + "i)"
+}
+
+// String formats x like x.Text('g', 10).
+func (x *Complex) String() string {
+	return x.Text('g', 10)
+}
+
+// Neg sets z to -x and returns z.
+func (z *Complex) Neg(x *Complex) *Complex {
+	z.Set(x)
+	z.re.Neg(&z.re)
+	z.im.Neg(&z.im)
+
+	return z
+}
+
+// Conj sets z to the complex conjugate of x and returns z.
+func (z *Complex) Conj(x *Complex) *Complex {
+	z.Set(x)
+	z.im.Neg(&z.im)
+
+	return z
+}
+
+// Add sets z to x + y and returns z.
+func (z *Complex) Add(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+	if x.IsInf() || y.IsInf() {
+		return z.setSpecial(x.Complex128()+y.Complex128(), prec)
+	}
+
+	re := new(big.Float).SetPrec(prec).Add(&x.re, &y.re)
+	im := new(big.Float).SetPrec(prec).Add(&x.im, &y.im)
+
+	return z.setParts(re, im, prec)
+}
+
+// Sub sets z to x - y and returns z.
+func (z *Complex) Sub(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+	if x.IsInf() || y.IsInf() {
+		return z.setSpecial(x.Complex128()-y.Complex128(), prec)
+	}
+
+	re := new(big.Float).SetPrec(prec).Sub(&x.re, &y.re)
+	im := new(big.Float).SetPrec(prec).Sub(&x.im, &y.im)
+
+	return z.setParts(re, im, prec)
+}
+
+// Mul sets z to x·y and returns z. Each part of the result is correctly
+// rounded.
+func (z *Complex) Mul(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+	if x.IsInf() || y.IsInf() {
+		return z.setSpecial(x.Complex128()*y.Complex128(), prec)
+	}
+
+	// The products are exact, so only the sums are rounded.
+	re := new(big.Float).SetPrec(prec).Sub(exactMul(&x.re, &y.re), exactMul(&x.im, &y.im))
+	im := new(big.Float).SetPrec(prec).Add(exactMul(&x.re, &y.im), exactMul(&x.im, &y.re))
+
+	return z.setParts(re, im, prec)
+}
+
+// Quo sets z to x/y and returns z.
+func (z *Complex) Quo(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+	if x.IsInf() || y.IsInf() || (y.re.Sign() == 0 && y.im.Sign() == 0) {
+		return z.setSpecial(x.Complex128()/y.Complex128(), prec)
+	}
+
+	// (a+bi)/(c+di) = ((ac+bd) + (bc-ad)i) / (c²+d²)
+	work := prec + complexGuardBits
+	den := new(big.Float).SetPrec(work).Add(exactMul(&y.re, &y.re), exactMul(&y.im, &y.im))
+	re := new(big.Float).SetPrec(work).Add(exactMul(&x.re, &y.re), exactMul(&x.im, &y.im))
+	im := new(big.Float).SetPrec(work).Sub(exactMul(&x.im, &y.re), exactMul(&x.re, &y.im))
+	re.Quo(re, den)
+	im.Quo(im, den)
+
+	return z.setParts(re, im, prec)
+}
+
+// Abs returns the absolute value, or modulus, of x with the precision of x.
+//
+// The special case is:
+//
+//	Abs(x) = +Inf if either part of x is infinite
+func (x *Complex) Abs() *big.Float {
+	prec := x.Prec()
+	if x.IsInf() {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	return x.abs(prec)
+}
+
+// abs returns |x| rounded to the given precision.
+func (x *Complex) abs(prec uint) *big.Float {
+	work := prec + complexGuardBits
+	result := new(big.Float).SetPrec(work).Add(exactMul(&x.re, &x.re), exactMul(&x.im, &x.im))
+	result.Sqrt(result)
+
+	return result.SetPrec(prec)
+}
+
+// Arg returns the argument, or phase, of x in the range [-π, π] with the
+// precision of x. It follows the special cases of Atan2(imag, real).
+func (x *Complex) Arg() *big.Float {
+	return Atan2(&x.im, &x.re)
+}
+
+// Polar returns the absolute value r and argument θ of x, such that
+// x = r·e^(θi).
+func (x *Complex) Polar() (r, θ *big.Float) {
+	return x.Abs(), x.Arg()
+}
+
+// SetPolar sets z to r·e^(θi) and returns z. If z's precision is 0, it
+// is changed to the larger of the precisions of r and θ.
+func (z *Complex) SetPolar(r, θ *big.Float) *Complex {
+	prec := z.Prec()
+	if prec == 0 {
+		prec = max(r.Prec(), θ.Prec())
+	}
+	if r.IsInf() || θ.IsInf() {
+		rf, _ := r.Float64()
+		tf, _ := θ.Float64()
+
+		return z.setSpecial(cmplx.Rect(rf, tf), prec)
+	}
+
+	work := prec + complexGuardBits
+	s, c := sinCos(new(big.Float).SetPrec(work).Set(θ))
+	re := mulZero(c, r)
+	im := mulZero(s, r)
+
+	return z.setParts(re, im, prec)
+}
+
+// Sqrt sets z to the square root of x and returns z. The result has a
+// non-negative real part, and the branch cut along the negative real axis
+// is continuous with the upper half plane when the imaginary part is +0
+// and with the lower half plane when it is -0.
+func (z *Complex) Sqrt(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Sqrt(x.Complex128()), prec)
+	}
+
+	work := prec + complexGuardBits
+	a := new(big.Float).SetPrec(work).Set(&x.re)
+	b := new(big.Float).SetPrec(work).Set(&x.im)
+
+	if b.Sign() == 0 {
+		// Keep the sign of the imaginary zero.
+		switch a.Sign() {
+		case 0:
+			return z.setParts(new(big.Float), b, prec)
+		case -1:
+			a.Neg(a).Sqrt(a)
+			if b.Signbit() {
+				a.Neg(a)
+			}
+
+			return z.setParts(new(big.Float), a, prec)
+		}
+
+		return z.setParts(a.Sqrt(a), b, prec)
+	}
+
+	// With r = |x|, the part of the root that does not suffer from
+	// cancellation is t = √((r + |a|)/2), and the other is |b|/2t.
+	r := x.abs(work)
+	t := new(big.Float).SetPrec(work).Abs(a)
+	t.Add(t, r)
+	t.Quo(t, two)
+	t.Sqrt(t)
+
+	u := new(big.Float).SetPrec(work).Abs(b)
+	u.Quo(u, t)
+	u.Quo(u, two)
+
+	if a.Sign() < 0 {
+		t, u = u, t
+	}
+	if b.Signbit() {
+		u.Neg(u)
+	}
+
+	return z.setParts(t, u, prec)
+}
+
+// Exp sets z to e^x, the base-e exponential of x, and returns z.
+func (z *Complex) Exp(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Exp(x.Complex128()), prec)
+	}
+
+	work := prec + complexGuardBits
+	r := Exp(new(big.Float).SetPrec(work).Set(&x.re))
+	s, c := sinCos(new(big.Float).SetPrec(work).Set(&x.im))
+
+	return z.setParts(mulZero(r, c), mulZero(r, s), prec)
+}
+
+// Log sets z to the natural logarithm of x and returns z. The imaginary
+// part is the argument of x, in the range [-π, π], with the branch cut
+// along the negative real axis.
+func (z *Complex) Log(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() || (x.re.Sign() == 0 && x.im.Sign() == 0) {
+		return z.setSpecial(cmplx.Log(x.Complex128()), prec)
+	}
+
+	work := prec + complexGuardBits
+
+	return z.setParts(logAbs(x, work), Atan2(&x.im, &x.re), prec)
+}
+
+// logAbs returns ln|x| to the given precision for a finite non-zero x.
+func logAbs(x *Complex, prec uint) *big.Float {
+	a, b := &x.re, &x.im
+	if new(big.Float).Abs(a).Cmp(new(big.Float).Abs(b)) < 0 {
+		a, b = b, a
+	}
+
+	// |x|² = a² + b², which is computed exactly before rounding.
+	m2 := new(big.Float).SetPrec(prec).Add(exactMul(a, a), exactMul(b, b))
+	if m2.MantExp(nil) < 0 || m2.MantExp(nil) > 1 {
+		// |x|² is outside of [1/2, 2), so there is no cancellation.
+		result := Log(m2)
+
+		return result.Quo(result, two)
+	}
+
+	// Near |x| = 1, ln|x| = ½·log1p(d) where d = (a-1)(a+1) + b² must be
+	// computed without rounding a² + b² first.
+	am1 := new(big.Float).SetPrec(a.Prec()+2).Sub(a, one)
+	ap1 := new(big.Float).SetPrec(a.Prec()+2).Add(a, one)
+	d := new(big.Float).SetPrec(prec).Add(exactMul(am1, ap1), exactMul(b, b))
+	if d.Sign() == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	result := log1p(d)
+
+	return result.Quo(result, two)
+}
+
+// Log10 sets z to the decimal logarithm of x and returns z.
+func (z *Complex) Log10(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() || (x.re.Sign() == 0 && x.im.Sign() == 0) {
+		return z.setSpecial(cmplx.Log10(x.Complex128()), prec)
+	}
+
+	work := prec + complexGuardBits
+	w := new(Complex).SetPrec(work).Log(x)
+	ln10 := Log(new(big.Float).SetPrec(work).SetInt64(10))
+	w.re.Quo(&w.re, ln10)
+	w.im.Quo(&w.im, ln10)
+
+	return z.setParts(&w.re, &w.im, prec)
+}
+
+// Pow sets z to x**y, the base-x exponential of y, and returns z.
+//
+// For generalized compatibility with math.Pow:
+//
+//	Pow(0, ±0) returns 1+0i
+//	Pow(0, c) for real(c)<0 returns Inf+0i if imag(c) is zero, otherwise Inf+Inf i.
+func (z *Complex) Pow(x, y *Complex) *Complex {
+	prec := z.resultPrec(x, y)
+	if x.IsInf() || y.IsInf() || (x.re.Sign() == 0 && x.im.Sign() == 0) {
+		return z.setSpecial(cmplx.Pow(x.Complex128(), y.Complex128()), prec)
+	}
+
+	// x**y = e^(y·ln(x)). The exponent is needed to enough bits after the
+	// binary point, not just enough significant bits, so widen the working
+	// precision by its magnitude.
+	work := prec + complexGuardBits
+	for {
+		w := new(Complex).SetPrec(work).Log(x)
+		w.Mul(w, y)
+
+		extra := max(w.re.MantExp(nil), w.im.MantExp(nil), 0)
+		if extra <= complexGuardBits/2 || work > prec+complexGuardBits+uint(extra) {
+			return z.setParts(new(Complex).SetPrec(work).Exp(w).parts(prec))
+		}
+		work = prec + complexGuardBits + uint(extra)
+	}
+}
+
+// Sin sets z to the sine of x and returns z.
+func (z *Complex) Sin(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Sin(x.Complex128()), prec)
+	}
+
+	// sin(a+bi) = sin(a)·cosh(b) + cos(a)·sinh(b)·i
+	work := prec + complexGuardBits
+	s, c := sinCos(new(big.Float).SetPrec(work).Set(&x.re))
+	sh, ch := sinhCosh(new(big.Float).SetPrec(work).Set(&x.im))
+
+	return z.setParts(mulZero(s, ch), mulZero(c, sh), prec)
+}
+
+// Cos sets z to the cosine of x and returns z.
+func (z *Complex) Cos(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Cos(x.Complex128()), prec)
+	}
+
+	// cos(a+bi) = cos(a)·cosh(b) - sin(a)·sinh(b)·i
+	work := prec + complexGuardBits
+	s, c := sinCos(new(big.Float).SetPrec(work).Set(&x.re))
+	sh, ch := sinhCosh(new(big.Float).SetPrec(work).Set(&x.im))
+	im := mulZero(s, sh)
+
+	return z.setParts(mulZero(c, ch), im.Neg(im), prec)
+}
+
+// Tan sets z to the tangent of x and returns z.
+func (z *Complex) Tan(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Tan(x.Complex128()), prec)
+	}
+
+	// tan(a+bi) = (sin(2a) + sinh(2b)·i) / (cos(2a) + cosh(2b))
+	return z.setParts(tanParts(&x.re, &x.im, prec, false))
+}
+
+// Cot sets z to the cotangent of x and returns z.
+func (z *Complex) Cot(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() || (x.re.Sign() == 0 && x.im.Sign() == 0) {
+		return z.setSpecial(cmplx.Cot(x.Complex128()), prec)
+	}
+
+	// cot(a+bi) = (sin(2a) - sinh(2b)·i) / (cosh(2b) - cos(2a))
+	return z.setParts(tanParts(&x.re, &x.im, prec, true))
+}
+
+// tanParts returns the parts of tan(a+bi), or cot(a+bi) if cot is true,
+// rounded to prec. The denominator can cancel badly near the poles, in
+// which case the calculation is repeated with enough extra precision.
+func tanParts(a, b *big.Float, prec uint, cot bool) (re, im *big.Float, p uint) {
+	work := prec + complexGuardBits
+	for {
+		a2 := new(big.Float).SetPrec(work).Mul(a, two)
+		b2 := new(big.Float).SetPrec(work).Mul(b, two)
+		s, c := sinCos(a2)
+		sh, ch := sinhCosh(b2)
+
+		d := new(big.Float).SetPrec(work)
+		if cot {
+			d.Sub(ch, c)
+			sh.Neg(sh)
+		} else {
+			d.Add(ch, c)
+		}
+
+		if d.Sign() == 0 {
+			inf := new(big.Float).SetInf(false)
+
+			return inf, inf, prec
+		}
+		if ch.IsInf() {
+			// |b| is so large that tan(a+bi) = ±i to any precision.
+			im := new(big.Float).SetInt64(int64(b.Sign()))
+			if cot {
+				im.Neg(im)
+			}
+
+			return new(big.Float).SetPrec(prec).Mul(s, new(big.Float)), im, prec
+		}
+
+		// Up to -exp(d) bits of the denominator were lost to cancellation.
+		lost := -d.MantExp(nil)
+		if lost <= complexGuardBits/2 || work >= prec+complexGuardBits+uint(lost) {
+			return s.Quo(s, d), sh.Quo(sh, d), prec
+		}
+		work = prec + complexGuardBits + uint(lost)
+	}
+}
+
+// Asin sets z to the inverse sine of x and returns z.
+func (z *Complex) Asin(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Asin(x.Complex128()), prec)
+	}
+
+	work := inverseWork(x, prec)
+	switch {
+	case x.im.Sign() == 0 && (x.re.MantExp(nil) <= 0 || isUnit(&x.re)):
+		// Real arguments in [-1, 1].
+		return z.setParts(asinReal(new(big.Float).SetPrec(work).Set(&x.re)), &x.im, prec)
+	case x.re.Sign() == 0 && (x.im.MantExp(nil) <= 0 || isUnit(&x.im)):
+		// Imaginary arguments in [-i, i].
+		return z.setParts(&x.re, asinhReal(new(big.Float).SetPrec(work).Set(&x.im)), prec)
+	case x.im.Sign() == 0:
+		// On the branch cuts, the imaginary part takes the sign of the
+		// imaginary zero.
+		re := piCache.get(context.Background(), work)
+		re.Quo(re, two)
+		if x.re.Sign() < 0 {
+			re.Neg(re)
+		}
+		im := acoshReal(new(big.Float).SetPrec(work).Abs(&x.re))
+		if x.im.Signbit() {
+			im.Neg(im)
+		}
+
+		return z.setParts(re, im, prec)
+	case x.re.Sign() == 0:
+		// The real zero is negative only when both zeros of the sum in
+		// the formula below are.
+		re := new(big.Float)
+		if x.re.Signbit() && x.im.Sign() < 0 {
+			re.Neg(re)
+		}
+
+		return z.setParts(re, asinhReal(new(big.Float).SetPrec(work).Set(&x.im)), prec)
+	}
+
+	// asin(x) = -i·ln(ix + √(1 - x²)). The two terms cancel when
+	// imag(x) > 0, where the reciprocal √(1 - x²) - ix is used instead.
+	s := new(Complex).SetPrec(work).Mul(x, x)
+	s.re.Sub(one, &s.re)
+	s.im.Neg(&s.im)
+	s.Sqrt(s)
+
+	w := new(Complex).SetPrec(work)
+	if x.im.Sign() > 0 {
+		w.re.Add(&s.re, &x.im)
+		w.im.Sub(&s.im, &x.re)
+		w.Log(w)
+
+		return z.setParts(w.im.Neg(&w.im), &w.re, prec)
+	}
+
+	w.re.Sub(&s.re, &x.im)
+	w.im.Add(&s.im, &x.re)
+	w.Log(w)
+
+	return z.setParts(&w.im, w.re.Neg(&w.re), prec)
+}
+
+// Acos sets z to the inverse cosine of x and returns z.
+func (z *Complex) Acos(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Acos(x.Complex128()), prec)
+	}
+
+	work := inverseWork(x, prec)
+	if x.im.Sign() == 0 && (x.re.MantExp(nil) <= 0 || isUnit(&x.re)) {
+		// acos(a) = atan2(√((1-a)(1+a)), a) avoids cancellation near a = 1.
+		a := new(big.Float).SetPrec(work).Set(&x.re)
+		im := new(big.Float).SetPrec(prec).Neg(&x.im)
+
+		return z.setParts(Atan2(sqrtOneMinusSquare(a), a), im, prec)
+	}
+
+	// acos(x) = π/2 - asin(x)
+	w := new(Complex).SetPrec(work).Asin(x)
+	halfPi := piCache.get(context.Background(), work)
+	halfPi.Quo(halfPi, two)
+	w.re.Sub(halfPi, &w.re)
+	w.im.Neg(&w.im)
+
+	return z.setParts(&w.re, &w.im, prec)
+}
+
+// Atan sets z to the inverse tangent of x and returns z.
+func (z *Complex) Atan(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Atan(x.Complex128()), prec)
+	}
+
+	work := inverseWork(x, prec)
+	a := new(big.Float).SetPrec(work).Set(&x.re)
+	b := new(big.Float).SetPrec(work).Set(&x.im)
+
+	switch {
+	case b.Sign() == 0:
+		return z.setParts(Atan(a), &x.im, prec)
+	case a.Sign() == 0 && (b.MantExp(nil) <= 0 || isUnit(b)):
+		return z.setParts(&x.re, atanhReal(b), prec)
+	}
+
+	// The real part is ½·atan2(2a, 1 - a² - b²), with the denominator
+	// computed exactly.
+	den := new(big.Float).SetPrec(work).Add(exactMul(a, a), exactMul(b, b))
+	den.Sub(one, den)
+	re := Atan2(new(big.Float).SetPrec(work).Mul(a, two), den)
+	re.Quo(re, two)
+	if a.Sign() == 0 {
+		// On the branch cuts, math/cmplx reduces ±π/2 into [-π/2, π/2).
+		re.Neg(re.Abs(re))
+	}
+
+	// The imaginary part is ¼·ln(c) where c = (a² + (b+1)²) / (a² + (b-1)²),
+	// and c - 1 = 4b / (a² + (b-1)²).
+	bm1 := new(big.Float).SetPrec(work).Sub(b, one)
+	q := new(big.Float).SetPrec(work).Add(exactMul(a, a), exactMul(bm1, bm1))
+	if q.Sign() == 0 {
+		// x = i is a pole.
+		return z.setParts(re, new(big.Float).SetInf(false), prec)
+	}
+	q.Quo(new(big.Float).SetPrec(work).Mul(b, four), q)
+	im := log1p(q)
+	im.Quo(im, four)
+
+	return z.setParts(re, im, prec)
+}
+
+// Sinh sets z to the hyperbolic sine of x and returns z.
+func (z *Complex) Sinh(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Sinh(x.Complex128()), prec)
+	}
+
+	// sinh(a+bi) = cos(b)·sinh(a) + sin(b)·cosh(a)·i
+	work := prec + complexGuardBits
+	s, c := sinCos(new(big.Float).SetPrec(work).Set(&x.im))
+	sh, ch := sinhCosh(new(big.Float).SetPrec(work).Set(&x.re))
+
+	return z.setParts(mulZero(c, sh), mulZero(s, ch), prec)
+}
+
+// Cosh sets z to the hyperbolic cosine of x and returns z.
+func (z *Complex) Cosh(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Cosh(x.Complex128()), prec)
+	}
+
+	// cosh(a+bi) = cos(b)·cosh(a) + sin(b)·sinh(a)·i
+	work := prec + complexGuardBits
+	s, c := sinCos(new(big.Float).SetPrec(work).Set(&x.im))
+	sh, ch := sinhCosh(new(big.Float).SetPrec(work).Set(&x.re))
+
+	return z.setParts(mulZero(c, ch), mulZero(s, sh), prec)
+}
+
+// Tanh sets z to the hyperbolic tangent of x and returns z.
+func (z *Complex) Tanh(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Tanh(x.Complex128()), prec)
+	}
+
+	// tanh(x) = -i·tan(ix)
+	re, im, _ := tanParts(new(big.Float).Neg(&x.im), &x.re, prec, false)
+	if re.IsInf() && im.IsInf() {
+		return z.setParts(re, im, prec)
+	}
+
+	return z.setParts(im, re.Neg(re), prec)
+}
+
+// Asinh sets z to the inverse hyperbolic sine of x and returns z.
+func (z *Complex) Asinh(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Asinh(x.Complex128()), prec)
+	}
+
+	work := inverseWork(x, prec)
+	switch {
+	case x.im.Sign() == 0 && (x.re.MantExp(nil) <= 0 || isUnit(&x.re)):
+		return z.setParts(asinhReal(new(big.Float).SetPrec(work).Set(&x.re)), &x.im, prec)
+	case x.re.Sign() == 0 && (x.im.MantExp(nil) <= 0 || isUnit(&x.im)):
+		return z.setParts(&x.re, asinReal(new(big.Float).SetPrec(work).Set(&x.im)), prec)
+	case x.im.Sign() == 0:
+		// Following the formula below, the imaginary zero keeps its sign
+		// only when real(x) > 0.
+		im := new(big.Float)
+		if x.re.Sign() > 0 {
+			im.Set(&x.im)
+		}
+
+		return z.setParts(asinhReal(new(big.Float).SetPrec(work).Set(&x.re)), im, prec)
+	case x.re.Sign() == 0:
+		// On the branch cuts, the real part takes the sign of the real zero.
+		re := acoshReal(new(big.Float).SetPrec(work).Abs(&x.im))
+		if x.re.Signbit() {
+			re.Neg(re)
+		}
+		im := piCache.get(context.Background(), work)
+		im.Quo(im, two)
+		if x.im.Sign() < 0 {
+			im.Neg(im)
+		}
+
+		return z.setParts(re, im, prec)
+	case x.re.Sign() < 0:
+		// asinh is odd, and ln(x + √(1 + x²)) cancels for real(x) < 0.
+		w := new(Complex).SetPrec(work).Neg(x)
+		w.Asinh(w)
+
+		return z.setParts(w.re.Neg(&w.re), w.im.Neg(&w.im), prec)
+	}
+
+	// asinh(x) = ln(x + √(1 + x²))
+	w := new(Complex).SetPrec(work).Mul(x, x)
+	w.re.Add(&w.re, one)
+	w.Sqrt(w)
+	w.Add(w, x)
+	w.Log(w)
+
+	return z.setParts(&w.re, &w.im, prec)
+}
+
+// Acosh sets z to the inverse hyperbolic cosine of x and returns z.
+func (z *Complex) Acosh(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Acosh(x.Complex128()), prec)
+	}
+
+	if x.re.Sign() == 0 && x.im.Sign() == 0 {
+		halfPi := piCache.get(context.Background(), prec+1)
+		halfPi.Quo(halfPi, two)
+		if x.im.Signbit() {
+			halfPi.Neg(halfPi)
+		}
+
+		return z.setParts(new(big.Float), halfPi, prec)
+	}
+
+	// acosh(x) = ±i·acos(x), choosing the sign that gives a non-negative
+	// real part.
+	w := new(Complex).SetPrec(inverseWork(x, prec)).Acos(x)
+	if w.im.Sign() <= 0 {
+		return z.setParts(w.im.Neg(&w.im), &w.re, prec)
+	}
+
+	return z.setParts(&w.im, w.re.Neg(&w.re), prec)
+}
+
+// Atanh sets z to the inverse hyperbolic tangent of x and returns z.
+func (z *Complex) Atanh(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if x.IsInf() {
+		return z.setSpecial(cmplx.Atanh(x.Complex128()), prec)
+	}
+
+	// atanh(x) = -i·atan(ix)
+	w := new(Complex).SetPrec(inverseWork(x, prec))
+	w.re.Neg(&x.im)
+	w.im.Set(&x.re)
+	w.Atan(w)
+
+	return z.setParts(&w.im, w.re.Neg(&w.re), prec)
+}
+
+// resultPrec returns the precision of z, or if that is 0, the largest
+// precision of the operands.
+func (z *Complex) resultPrec(operands ...*Complex) uint {
+	if prec := z.Prec(); prec != 0 {
+		return prec
+	}
+
+	var prec uint
+	for _, x := range operands {
+		prec = max(prec, x.Prec())
+	}
+
+	return prec
+}
+
+// setParts sets z to re + im·i rounded to prec and returns z.
+func (z *Complex) setParts(re, im *big.Float, prec uint) *Complex {
+	z.re.SetPrec(prec).Set(re)
+	z.im.SetPrec(prec).Set(im)
+
+	return z
+}
+
+// parts returns the parts of x rounded to prec, in the order setParts
+// takes them.
+func (x *Complex) parts(prec uint) (re, im *big.Float, p uint) {
+	return &x.re, &x.im, prec
+}
+
+// setSpecial sets z to the value of c, which comes from a special case of
+// math/cmplx, and returns z. NaN parts become +Inf, and finite values other
+// than 0 and ±1 are taken to be multiples of π/4.
+func (z *Complex) setSpecial(c complex128, prec uint) *Complex {
+	part := func(f *big.Float, v float64) {
+		f.SetPrec(prec)
+		switch {
+		case math.IsNaN(v):
+			f.SetInf(false)
+		case math.IsInf(v, 0) || v == 0 || math.Abs(v) == 1:
+			f.SetFloat64(v)
+		default:
+			if k := math.Round(v / (math.Pi / 4)); k != 0 && math.Abs(v-k*math.Pi/4) <= 4e-16*math.Abs(v) {
+				f.Set(piCache.get(context.Background(), prec+8))
+				f.Mul(f, big.NewFloat(k))
+				f.Quo(f, four)
+			} else {
+				f.SetFloat64(v)
+			}
+		}
+	}
+
+	part(&z.re, real(c))
+	part(&z.im, imag(c))
+
+	return z
+}
+
+// exactMul returns x·y without rounding.
+func exactMul(x, y *big.Float) *big.Float {
+	return new(big.Float).SetPrec(x.Prec()+y.Prec()).Mul(x, y)
+}
+
+// mulZero returns x·y with the precision of x, treating 0·Inf as a zero
+// of the appropriate sign rather than panicking.
+func mulZero(x, y *big.Float) *big.Float {
+	result := new(big.Float).SetPrec(x.Prec())
+	if x.Sign() == 0 || y.Sign() == 0 {
+		if x.Signbit() != y.Signbit() {
+			result.Neg(result)
+		}
+
+		return result
+	}
+
+	return result.Mul(x, y)
+}
+
+// inverseWork returns the working precision for the inverse functions of
+// x. Their formulas cancel when |x| is very large or very small, in
+// proportion to the size of its exponent.
+func inverseWork(x *Complex, prec uint) uint {
+	e := 0
+	if x.re.Sign() != 0 {
+		e = max(e, abs(x.re.MantExp(nil)))
+	}
+	if x.im.Sign() != 0 {
+		e = max(e, abs(x.im.MantExp(nil)))
+	}
+
+	return prec + complexGuardBits + 2*uint(e)
+}
+
+// abs returns the absolute value of an int.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
+
+// isUnit reports whether |x| = 1.
+func isUnit(x *big.Float) bool {
+	return new(big.Float).Abs(x).Cmp(one) == 0
+}
+
+// sinCos returns the sine and cosine of x with the precision of x.
+func sinCos(x *big.Float) (sin, cos *big.Float) {
+	return sinCosApprox(context.Background(), x)
+}
+
+// sinhCosh returns the hyperbolic sine and cosine of x with the precision
+// of x.
+func sinhCosh(x *big.Float) (sinh, cosh *big.Float) {
+	prec := x.Prec()
+
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x), new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	if x.MantExp(nil) < -int(prec/2)-2 {
+		// sinh(x) = x and cosh(x) = 1 + x²/2 to within rounding.
+		cosh = new(big.Float).SetPrec(prec).Mul(x, x)
+		cosh.Quo(cosh, two)
+		cosh.Add(cosh, one)
+
+		return new(big.Float).SetPrec(prec).Set(x), cosh
+	}
+
+	// e^x - e^-x cancels for small x.
+	work := prec + 16 + uint(max(0, -x.MantExp(nil)))
+	e := Exp(new(big.Float).SetPrec(work).Set(x))
+	switch {
+	case e.IsInf():
+		return new(big.Float).SetPrec(prec).SetInf(false), new(big.Float).SetPrec(prec).SetInf(false)
+	case e.Sign() == 0:
+		return new(big.Float).SetPrec(prec).SetInf(true), new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	inv := new(big.Float).SetPrec(work).Quo(one, e)
+	sinh = new(big.Float).SetPrec(work).Sub(e, inv)
+	cosh = new(big.Float).SetPrec(work).Add(e, inv)
+	sinh.Quo(sinh, two)
+	cosh.Quo(cosh, two)
+
+	return sinh.SetPrec(prec), cosh.SetPrec(prec)
+}
+
+// log1p returns ln(1 + d) with the precision of d, without the loss of
+// precision ln(1 + d) suffers for small d.
+func log1p(d *big.Float) *big.Float {
+	prec := d.Prec()
+
+	e := d.MantExp(nil)
+	if d.Sign() == 0 || e < -int(prec/2)-2 {
+		// ln(1 + d) = d - d²/2 to within rounding.
+		result := new(big.Float).SetPrec(prec).Mul(d, d)
+		result.Quo(result, two)
+
+		return result.Sub(d, result)
+	}
+
+	// 1 + d holds every bit of d when it has -exp(d) more bits.
+	work := prec + 8 + uint(max(0, -e))
+	result := new(big.Float).SetPrec(work).Add(d, one)
+	if result.Sign() <= 0 {
+		return new(big.Float).SetPrec(prec).SetInf(result.Sign() < 0)
+	}
+
+	return Log(result).SetPrec(prec)
+}
+
+// sqrtOneMinusSquare returns √(1 - x²), computed as √((1-x)(1+x)) to avoid
+// cancellation, with the precision of x.
+func sqrtOneMinusSquare(x *big.Float) *big.Float {
+	prec := x.Prec()
+	d := new(big.Float).SetPrec(prec+2).Sub(one, x)
+	d.Mul(d, new(big.Float).SetPrec(prec+2).Add(one, x))
+
+	return d.Sqrt(d).SetPrec(prec)
+}
+
+// asinReal returns asin(x) = atan2(x, √(1 - x²)) for |x| <= 1.
+func asinReal(x *big.Float) *big.Float {
+	return Atan2(x, sqrtOneMinusSquare(x))
+}
+
+// asinhReal returns asinh(x) = sign(x)·log1p(|x| + x²/(1 + √(1 + x²))),
+// which is accurate for small x.
+func asinhReal(x *big.Float) *big.Float {
+	prec := x.Prec()
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
+
+	ax := new(big.Float).SetPrec(prec).Abs(x)
+	x2 := new(big.Float).SetPrec(prec).Mul(ax, ax)
+	t := new(big.Float).SetPrec(prec).Add(x2, one)
+	t.Sqrt(t)
+	t.Add(t, one)
+	t.Quo(x2, t)
+	t.Add(t, ax)
+
+	result := log1p(t)
+	if x.Sign() < 0 {
+		result.Neg(result)
+	}
+
+	return result
+}
+
+// acoshReal returns acosh(x) = log1p((x-1) + √((x-1)(x+1))) for x >= 1,
+// which is accurate near 1.
+func acoshReal(x *big.Float) *big.Float {
+	prec := x.Prec()
+	xm1 := new(big.Float).SetPrec(prec).Sub(x, one)
+	t := new(big.Float).SetPrec(prec).Add(x, one)
+	t.Mul(t, xm1)
+	t.Sqrt(t)
+
+	return log1p(t.Add(t, xm1))
+}
+
+// atanhReal returns atanh(x) = ½·log1p(2x/(1 - x)) for |x| <= 1.
+func atanhReal(x *big.Float) *big.Float {
+	prec := x.Prec()
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
+	if isUnit(x) {
+		return new(big.Float).SetPrec(prec).SetInf(x.Sign() < 0)
+	}
+
+	t := new(big.Float).SetPrec(prec).Sub(one, x)
+	t.Quo(new(big.Float).SetPrec(prec).Mul(x, two), t)
+
+	result := log1p(t)
+
+	return result.Quo(result, two)
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+// Gamma sets z to the Gamma function of x and returns z.
+//
+// Arguments with real(x) < 0 use the reflection formula
+// Γ(x) = π / (sin(πx)·Γ(1-x)), and the others e^LogGamma(x).
+//
+// The special cases are:
+//
+//	Gamma(x) = +Inf for x = 0, -1, -2, …
+//	Gamma(+Inf) = +Inf
+//	Gamma(x) = +Inf+Inf i for any other x with an infinite part
+func (z *Complex) Gamma(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if z.gammaSpecial(x, prec) {
+		return z
+	}
+
+	work := prec + complexGuardBits
+	if x.re.Sign() < 0 {
+		y := new(Complex).SetPrec(work)
+		y.re.Sub(one, &x.re)
+		y.im.Neg(&x.im)
+
+		g := new(Complex).SetPrec(work).Gamma(y)
+		g.Mul(g, sinPi(x, work))
+		pi := NewComplex(piCache.get(context.Background(), work), new(big.Float).SetPrec(work))
+
+		return z.setParts(new(Complex).SetPrec(work).Quo(pi, g).parts(prec))
+	}
+
+	// The exponent is needed to enough bits after the binary point, so
+	// widen the working precision by its magnitude.
+	for {
+		w := logGamma(x, work)
+
+		extra := max(complexExponent(w), 0)
+		if extra <= complexGuardBits/2 || work >= prec+complexGuardBits+uint(extra) {
+			return z.setParts(w.Exp(w).parts(prec))
+		}
+		work = prec + complexGuardBits + uint(extra)
+	}
+}
+
+// LogGamma sets z to the logarithm of the Gamma function of x and returns z.
+//
+// The result is the principal branch of log Γ: it is analytic except along
+// the non-positive real axis, it is real for real x > 0, and
+//
+//	LogGamma(x+1) = LogGamma(x) + Log(x)
+//
+// Its imaginary part is not reduced to [-π, π] the way it would be by
+// taking the Log of Gamma(x).
+//
+// It uses the Stirling series after shifting x far enough from the origin,
+// and for real(x) < 0 the reflection formula
+//
+//	log Γ(x) = ln π - log sin(πx) - log Γ(1-x) + 2πi·sign(imag(x))·⌊real(x)/2 + 1/4⌋
+//
+// whose last term selects the branch.
+//
+// The special cases are:
+//
+//	LogGamma(x) = +Inf for x = 0, -1, -2, …
+//	LogGamma(+Inf) = +Inf
+//	LogGamma(x) = +Inf+Inf i for any other x with an infinite part
+func (z *Complex) LogGamma(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	if z.gammaSpecial(x, prec) {
+		return z
+	}
+
+	return z.setParts(logGamma(x, prec+complexGuardBits).parts(prec))
+}
+
+// gammaSpecial sets z to the value of Gamma and LogGamma at their poles
+// and infinite arguments, and reports whether it did so.
+func (z *Complex) gammaSpecial(x *Complex, prec uint) bool {
+	switch {
+	case x.im.Sign() == 0 && x.re.Sign() <= 0 && x.re.IsInt():
+		z.setSpecial(complex(math.Inf(1), 0), prec)
+	case x.im.Sign() == 0 && x.re.IsInf() && x.re.Sign() > 0:
+		z.setSpecial(complex(math.Inf(1), 0), prec)
+	case x.IsInf():
+		z.setSpecial(cmplx.Inf(), prec)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// logGamma returns the principal branch of log Γ(x) with the given
+// precision, for finite x that are not poles.
+func logGamma(x *Complex, work uint) *Complex {
+	if x.re.Sign() < 0 {
+		return logGammaReflection(x, work)
+	}
+
+	// The Stirling series is accurate to 2^-work once |x| > work·ln(2)/2π,
+	// and with a larger radius it needs fewer terms.
+	radius := float64(work/4 + 8)
+
+	// Shift x out to the radius using log Γ(x) = log Γ(x+n) - log P where
+	// P = x(x+1)···(x+n-1). The arguments of the factors are summed to find
+	// the branch of log P.
+	y := new(Complex).SetPrec(work).Set(x)
+	var logP *Complex
+	if c := x.Complex128(); cmplx.Abs(c) < radius {
+		n := int(math.Ceil(radius - real(c)))
+		p := new(Complex).SetPrec(work).SetComplex128(1)
+		arg := 0.0
+		for range n {
+			p.Mul(p, y)
+			arg += cmplx.Phase(y.Complex128())
+			y.re.Add(&y.re, one)
+		}
+
+		logP = p.Log(p)
+		phase, _ := logP.im.Float64()
+		if turns := math.Round((arg - phase) / (2 * math.Pi)); turns != 0 {
+			twoPi := piCache.get(context.Background(), work)
+			twoPi.Mul(twoPi, big.NewFloat(2*turns))
+			logP.im.Add(&logP.im, twoPi)
+		}
+	}
+
+	result := logGammaStirling(y, work)
+	if logP != nil {
+		result.Sub(result, logP)
+	}
+
+	return result
+}
+
+// logGammaStirling returns log Γ(x) for large |x| with real(x) >= 0 using
+// the Stirling series
+//
+//	log Γ(x) = (x - 1/2)·log x - x + ln(2π)/2 + Σ B(2k) / (2k(2k-1)·x^(2k-1))
+func logGammaStirling(x *Complex, work uint) *Complex {
+	halfLog2Pi := log2PiCache.get(context.Background(), work)
+	halfLog2Pi.Quo(halfLog2Pi, two)
+
+	h := new(Complex).SetPrec(work).Set(x)
+	h.re.Sub(&h.re, big.NewFloat(0.5))
+	result := new(Complex).SetPrec(work).Log(x)
+	result.Mul(result, h)
+	result.Sub(result, x)
+	result.re.Add(&result.re, halfLog2Pi)
+
+	inv := new(Complex).SetPrec(work).SetComplex128(1)
+	inv.Quo(inv, x)
+	inv2 := new(Complex).SetPrec(work).Mul(inv, inv)
+
+	bernoulli := bernoulliEven(int(work/4) + 16)
+	c := new(big.Float).SetPrec(work)
+	term := new(Complex).SetPrec(work)
+	for k := 1; k < len(bernoulli); k++ {
+		c.SetRat(bernoulli[k])
+		c.Quo(c, new(big.Float).SetInt64(int64(2*k*(2*k-1))))
+		term.re.Mul(&inv.re, c)
+		term.im.Mul(&inv.im, c)
+		result.Add(result, term)
+
+		if complexExponent(term) < max(complexExponent(result), 0)-int(work) {
+			break
+		}
+		inv.Mul(inv, inv2)
+	}
+
+	return result
+}
+
+// logGammaReflection returns log Γ(x) for real(x) < 0 from log Γ(1-x).
+func logGammaReflection(x *Complex, work uint) *Complex {
+	y := new(Complex).SetPrec(work)
+	y.re.Sub(one, &x.re)
+	y.im.Neg(&x.im)
+
+	result := logGamma(y, work)
+	s := sinPi(x, work)
+	result.Add(result, s.Log(s))
+	result.Neg(result)
+
+	// ln π = ln 2π − ln 2
+	ctx := context.Background()
+	pi := piCache.get(ctx, work)
+	logPi := log2PiCache.get(ctx, work)
+	result.re.Add(&result.re, logPi.Sub(logPi, ln2Cache.get(ctx, work)))
+
+	// 2πi·sign(imag(x))·⌊real(x)/2 + 1/4⌋
+	k := new(big.Float).SetPrec(max(work, x.re.Prec())+2).Quo(&x.re, two)
+	k.Add(k, big.NewFloat(0.25))
+	if turns := floorInt(k); turns.Sign() != 0 {
+		branch := new(big.Float).SetPrec(work).SetInt(turns)
+		branch.Mul(branch, pi)
+		branch.Mul(branch, two)
+		if x.im.Signbit() {
+			branch.Neg(branch)
+		}
+		result.im.Add(&result.im, branch)
+	}
+
+	return result
+}
+
+// sinPi returns sin(πx) with the given precision. The real part of x is
+// reduced modulo 2 exactly before it is multiplied by π, and the sine and
+// cosine are exact at multiples of 1/2. In particular the imaginary part is
+// a zero with the sign of imag(x) when real(x) is an odd multiple of 1/2,
+// which keeps the branch of LogGamma consistent.
+func sinPi(x *Complex, work uint) *Complex {
+	r := new(big.Float).SetPrec(max(work, x.re.Prec())).Set(&x.re)
+	k := new(big.Float).SetPrec(r.Prec()).Quo(r, two)
+	k.Add(k, big.NewFloat(0.5))
+	k.SetInt(floorInt(k))
+	r.Sub(r, k.Mul(k, two))
+
+	pi := piCache.get(context.Background(), work)
+	var sin, cos *big.Float
+	if twice := new(big.Float).Mul(r, two); twice.IsInt() {
+		// r is one of -1, -1/2, 0, 1/2 or 1.
+		n, _ := twice.Int64()
+		sin = new(big.Float).SetPrec(work).SetInt64([]int64{0, -1, 0, 1, 0}[n+2])
+		cos = new(big.Float).SetPrec(work).SetInt64([]int64{-1, 0, 1, 0, -1}[n+2])
+	} else {
+		sin, cos = sinCos(r.SetPrec(work).Mul(r, pi))
+	}
+
+	sinh, cosh := sinhCosh(new(big.Float).SetPrec(work).Mul(&x.im, pi))
+	result := new(Complex).SetPrec(work)
+	result.re.Set(mulZero(sin, cosh))
+	result.im.Set(mulZero(cos, sinh))
+
+	return result
+}
+
+// floorInt returns ⌊x⌋ for finite x.
+func floorInt(x *big.Float) *big.Int {
+	i, acc := x.Int(nil)
+	if acc == big.Above {
+		i.Sub(i, intOne)
+	}
+
+	return i
+}
+
+// complexExponent returns the larger of the binary exponents of the
+// non-zero parts of x, or math.MinInt if x is 0.
+func complexExponent(x *Complex) int {
+	e := math.MinInt
+	if x.re.Sign() != 0 {
+		e = x.re.MantExp(nil)
+	}
+	if x.im.Sign() != 0 {
+		e = max(e, x.im.MantExp(nil))
+	}
+
+	return e
+}
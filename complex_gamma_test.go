@@ -0,0 +1,153 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestComplexGammaReal(t *testing.T) {
+	values := []float64{0.5, 1, 2.5, 5, 7.25, 30.5, -0.5, -1.5, -2.7, -10.25}
+
+	for _, v := range values {
+		got, _ := new(Complex).Gamma(NewComplex128(complex(v, 0))).Real().Float64()
+		if want := math.Gamma(v); math.Abs(got-want) > 1e-14*math.Abs(want) {
+			t.Errorf("Gamma(%v) = %v, want %v", v, got, want)
+		}
+
+		lg := new(Complex).LogGamma(NewComplex128(complex(v, 0))).Complex128()
+		want, sign := math.Lgamma(v)
+		if math.Abs(real(lg)-want) > 1e-14*math.Max(1, math.Abs(want)) {
+			t.Errorf("LogGamma(%v) = %v, want real part %v", v, lg, want)
+		}
+		if (sign > 0) != (math.Mod(math.Abs(imag(lg))/math.Pi+0.5, 2) < 1) {
+			t.Errorf("LogGamma(%v) = %v, but the sign of Gamma is %d", v, lg, sign)
+		}
+	}
+}
+
+func TestComplexGammaKnownValues(t *testing.T) {
+	const prec = 300
+
+	// Γ(i) = -0.15494982830181068512… - 0.49801566811835604271…i
+	re, _ := new(big.Float).SetPrec(prec).SetString("-0.1549498283018106851249551304838")
+	im, _ := new(big.Float).SetPrec(prec).SetString("-0.4980156681183560427136911174622")
+	x := new(Complex).SetPrec(prec).SetComplex128(1i)
+	checkComplexAgreement(t, "Gamma(i)", new(Complex).Gamma(x), NewComplex(re, im), 100)
+
+	// Γ(1/2) = √π
+	x.SetComplex128(0.5)
+	sqrtPi := ComputePi(prec)
+	sqrtPi.Sqrt(sqrtPi)
+	checkComplexAgreement(t, "Gamma(1/2)", new(Complex).Gamma(x), NewComplex(sqrtPi, new(big.Float)), prec)
+
+	// Γ(31) = 30!
+	x.SetComplex128(31)
+	f := new(big.Float).SetPrec(prec).SetInt(Factorial(30))
+	checkComplexAgreement(t, "Gamma(31)", new(Complex).Gamma(x), NewComplex(f, new(big.Float)), prec)
+}
+
+func TestComplexGammaIdentities(t *testing.T) {
+	values := []complex128{
+		complex(0.5, 0.5), complex(3, -7), complex(0.1, 25), complex(-2.7, 0.3),
+		complex(-10.5, 2), complex(-0.5, -1), complex(40, 3), complex(1e-3, -1e-3),
+	}
+
+	for _, prec := range []uint{64, 256, 1000} {
+		for _, v := range values {
+			x := new(Complex).SetPrec(prec).SetComplex128(v)
+			x1 := new(Complex).Set(x)
+			x1.Real().Add(x1.Real(), big.NewFloat(1))
+
+			// Γ(x+1) = x·Γ(x)
+			g := new(Complex).Gamma(x)
+			checkComplexAgreement(t, fmt.Sprintf("Gamma(%v+1)", v), new(Complex).Gamma(x1), g.Mul(g, x), prec-4)
+
+			// log Γ(x+1) = log Γ(x) + log x, with no multiple of 2πi.
+			lg := new(Complex).LogGamma(x)
+			lg.Add(lg, new(Complex).Log(x))
+			checkComplexAgreement(t, fmt.Sprintf("LogGamma(%v+1)", v), new(Complex).LogGamma(x1), lg, prec-8)
+
+			// e^LogGamma(x) = Γ(x)
+			e := new(Complex).LogGamma(x)
+			checkComplexAgreement(t, fmt.Sprintf("exp(LogGamma(%v))", v), e.Exp(e), new(Complex).Gamma(x), prec-16)
+		}
+	}
+
+	// |Γ(1/2 + ti)|² = π / cosh(πt)
+	for _, prec := range []uint{128, 1000} {
+		x := new(Complex).SetPrec(prec).SetComplex128(complex(0.5, 3))
+		abs := new(Complex).Gamma(x).Abs()
+		abs.Mul(abs, abs)
+
+		pi := ComputePi(prec + 64)
+		_, cosh := sinhCosh(new(big.Float).SetPrec(prec+64).Mul(pi, big.NewFloat(3)))
+		want := new(big.Float).SetPrec(prec+64).Quo(pi, cosh)
+		if agree := bitsOfAgreement(abs, want); agree < int(prec)-8 {
+			t.Errorf("|Gamma(1/2+3i)|² at %d bits only agrees to %d bits", prec, agree)
+		}
+	}
+}
+
+func TestComplexLogGammaBranch(t *testing.T) {
+	// On the negative real axis the imaginary part is the limit from the
+	// upper half plane: log Γ(-1/2) = ln(2√π) - πi.
+	got := new(Complex).LogGamma(NewComplex128(-0.5)).Complex128()
+	if want := complex(math.Log(2*math.Sqrt(math.Pi)), -math.Pi); !complexClose(got, want) {
+		t.Errorf("LogGamma(-0.5) = %v, want %v", got, want)
+	}
+
+	// And conjugation from below.
+	got = new(Complex).LogGamma(NewComplex128(complex(-0.5, math.Copysign(0, -1)))).Complex128()
+	if want := complex(math.Log(2*math.Sqrt(math.Pi)), math.Pi); !complexClose(got, want) {
+		t.Errorf("LogGamma(-0.5-0i) = %v, want %v", got, want)
+	}
+
+	// Γ(-5/2) < 0, and log Γ(-5/2) is continued through three half turns.
+	got = new(Complex).LogGamma(NewComplex128(-2.5)).Complex128()
+	if want := complex(math.Log(8*math.Sqrt(math.Pi)/15), -3*math.Pi); !complexClose(got, want) {
+		t.Errorf("LogGamma(-2.5) = %v, want %v", got, want)
+	}
+}
+
+func TestComplexGammaSpecialCases(t *testing.T) {
+	for _, v := range []complex128{0, -1, -20, complex(math.Inf(1), 0)} {
+		if got := new(Complex).Gamma(NewComplex128(v)); !got.Real().IsInf() || got.Imag().Sign() != 0 {
+			t.Errorf("Gamma(%v) = %v, want +Inf", v, got)
+		}
+		if got := new(Complex).LogGamma(NewComplex128(v)); !got.Real().IsInf() || got.Imag().Sign() != 0 {
+			t.Errorf("LogGamma(%v) = %v, want +Inf", v, got)
+		}
+	}
+
+	if got := new(Complex).Gamma(NewComplex128(complex(1, math.Inf(-1)))); !got.Real().IsInf() || !got.Imag().IsInf() {
+		t.Errorf("Gamma(1-Inf i) = %v, want +Inf+Inf i", got)
+	}
+}
+
+func BenchmarkComplexGamma(b *testing.B) {
+	for _, prec := range []uint{53, 256, 1000} {
+		b.Run(fmt.Sprintf("precision_%d", prec), func(b *testing.B) {
+			x := new(Complex).SetPrec(prec).SetComplex128(complex(2.5, -3))
+			z := new(Complex)
+			for b.Loop() {
+				z.SetPrec(0).Gamma(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"testing"
+)
+
+var complexFunctions = []struct {
+	name string
+	big  func(z, x *Complex) *Complex
+	std  func(complex128) complex128
+}{
+	{"Sqrt", (*Complex).Sqrt, cmplx.Sqrt},
+	{"Exp", (*Complex).Exp, cmplx.Exp},
+	{"Log", (*Complex).Log, cmplx.Log},
+	{"Log10", (*Complex).Log10, cmplx.Log10},
+	{"Sin", (*Complex).Sin, cmplx.Sin},
+	{"Cos", (*Complex).Cos, cmplx.Cos},
+	{"Tan", (*Complex).Tan, cmplx.Tan},
+	{"Cot", (*Complex).Cot, cmplx.Cot},
+	{"Sinh", (*Complex).Sinh, cmplx.Sinh},
+	{"Cosh", (*Complex).Cosh, cmplx.Cosh},
+	{"Tanh", (*Complex).Tanh, cmplx.Tanh},
+	{"Asin", (*Complex).Asin, cmplx.Asin},
+	{"Acos", (*Complex).Acos, cmplx.Acos},
+	{"Atan", (*Complex).Atan, cmplx.Atan},
+	{"Asinh", (*Complex).Asinh, cmplx.Asinh},
+	{"Acosh", (*Complex).Acosh, cmplx.Acosh},
+	{"Atanh", (*Complex).Atanh, cmplx.Atanh},
+}
+
+// complexTestValues includes points on and either side of the branch cuts.
+var complexTestValues = []complex128{
+	complex(0.5, 0.25), complex(-3, 4), complex(1.5, -2), complex(-0.1, -0.7),
+	complex(2, 0), complex(2, math.Copysign(0, -1)),
+	complex(-2, 0), complex(-2, math.Copysign(0, -1)),
+	complex(0, 2), complex(math.Copysign(0, -1), 2),
+	complex(0, -2), complex(math.Copysign(0, -1), -2),
+	complex(0.5, 0), complex(-0.5, math.Copysign(0, -1)), complex(0, 0.5),
+	complex(1e-3, 1), complex(1, 1e-3), complex(1e-10, 0.999), complex(-1e-8, 3e-9),
+	complex(10, -20), complex(-0.75, 30),
+}
+
+// complexClose reports whether got is close to want and whether zero parts
+// have the same sign. math/cmplx loses some accuracy to cancellation for
+// large arguments and near |x| = 1, and tiny results are compared with an
+// absolute tolerance. The identity tests check the accuracy of Complex.
+func complexClose(got, want complex128) bool {
+	if cmplx.IsNaN(want) || cmplx.IsInf(want) {
+		return cmplx.IsInf(got) || cmplx.IsNaN(got)
+	}
+
+	tol := 2e-13 * math.Max(cmplx.Abs(want), 1)
+	for _, p := range [][2]float64{{real(got), real(want)}, {imag(got), imag(want)}} {
+		if math.Abs(p[0]-p[1]) > tol {
+			return false
+		}
+		if p[1] == 0 && math.Signbit(p[0]) != math.Signbit(p[1]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestComplexVsCmplx(t *testing.T) {
+	for _, fn := range complexFunctions {
+		for _, v := range complexTestValues {
+			t.Run(fmt.Sprintf("%s%v", fn.name, v), func(t *testing.T) {
+				got := fn.big(new(Complex), NewComplex128(v))
+				if got.Prec() != 53 {
+					t.Errorf("%s(%v) has precision %d, want 53", fn.name, v, got.Prec())
+				}
+				if want := fn.std(v); !complexClose(got.Complex128(), want) {
+					t.Errorf("%s(%v) = %v, want %v", fn.name, v, got.Complex128(), want)
+				}
+			})
+		}
+	}
+}
+
+func TestComplexSpecialCases(t *testing.T) {
+	inf := math.Inf(1)
+	values := []complex128{
+		0, complex(math.Copysign(0, -1), 0), complex(0, math.Copysign(0, -1)),
+		1, -1, 1i, -1i,
+		complex(inf, 0), complex(-inf, 0), complex(0, inf), complex(0, -inf),
+		complex(inf, 1), complex(-inf, -1), complex(1, inf), complex(inf, inf),
+	}
+
+	for _, fn := range complexFunctions {
+		for _, v := range values {
+			got := fn.big(new(Complex), NewComplex128(v)).Complex128()
+			if want := fn.std(v); !complexClose(got, want) {
+				t.Errorf("%s(%v) = %v, want %v", fn.name, v, got, want)
+			}
+		}
+	}
+}
+
+func TestComplexArithmetic(t *testing.T) {
+	values := []complex128{complex(1.5, -2), complex(-3, 0.25), 1i, complex(1e-20, 7)}
+
+	for _, x := range values {
+		for _, y := range values {
+			bx, by := NewComplex128(x), NewComplex128(y)
+			tests := []struct {
+				op   string
+				got  *Complex
+				want complex128
+			}{
+				{"+", new(Complex).Add(bx, by), x + y},
+				{"-", new(Complex).Sub(bx, by), x - y},
+				{"·", new(Complex).Mul(bx, by), x * y},
+				{"/", new(Complex).Quo(bx, by), x / y},
+				{"**", new(Complex).Pow(bx, by), cmplx.Pow(x, y)},
+			}
+			for _, test := range tests {
+				// The sign of a zero from exact cancellation is not checked.
+				if got := test.got.Complex128(); cmplx.Abs(got-test.want) > 1e-14*cmplx.Abs(test.want) {
+					t.Errorf("%v %s %v = %v, want %v", x, test.op, y, got, test.want)
+				}
+			}
+		}
+
+		bx := NewComplex128(x)
+		if got, _ := bx.Abs().Float64(); math.Abs(got-cmplx.Abs(x)) > 1e-15*cmplx.Abs(x) {
+			t.Errorf("Abs(%v) = %v, want %v", x, got, cmplx.Abs(x))
+		}
+		if got, _ := bx.Arg().Float64(); math.Abs(got-cmplx.Phase(x)) > 1e-15 {
+			t.Errorf("Arg(%v) = %v, want %v", x, got, cmplx.Phase(x))
+		}
+		if got := new(Complex).SetPolar(bx.Polar()).Complex128(); !complexClose(got, x) {
+			t.Errorf("SetPolar(Polar(%v)) = %v", x, got)
+		}
+	}
+
+	if got := new(Complex).Quo(NewComplex128(1), NewComplex128(0)); !got.IsInf() {
+		t.Errorf("1/0 = %v, want Inf", got)
+	}
+}
+
+func TestComplexIdentities(t *testing.T) {
+	values := []complex128{
+		complex(0.5, 0.25), complex(-3, 4), complex(1.5, -2), complex(-0.1, -0.7),
+		complex(-0.75, 30), complex(-1e-8, 3e-9), complex(1, 1e-3),
+	}
+
+	for _, prec := range []uint{200, 1000} {
+		for _, v := range values {
+			x := new(Complex).SetPrec(prec).SetComplex128(v)
+
+			// exp(log x) = x
+			checkComplexAgreement(t, fmt.Sprintf("exp(log %v)", v), new(Complex).Exp(new(Complex).Log(x)), x, prec)
+
+			// sqrt(x)² = x
+			r := new(Complex).Sqrt(x)
+			checkComplexAgreement(t, fmt.Sprintf("sqrt(%v)²", v), r.Mul(r, r), x, prec)
+
+			// sin² + cos² = 1, where the squares don't cancel.
+			if math.Abs(imag(v)) < 8 {
+				s := new(Complex).Sin(x)
+				c := new(Complex).Cos(x)
+				s.Mul(s, s)
+				c.Mul(c, c)
+				one := new(Complex).SetPrec(prec).SetComplex128(1)
+				checkComplexAgreement(t, fmt.Sprintf("sin²+cos² of %v", v), s.Add(s, c), one, prec-8)
+			}
+
+			// asin(sin x) = x, for x within the principal branch.
+			if math.Abs(real(v)) < math.Pi/2 {
+				checkComplexAgreement(t, fmt.Sprintf("asin(sin %v)", v), new(Complex).Asin(new(Complex).Sin(x)), x, prec-8)
+			}
+
+			// atanh(tanh x) = x
+			if math.Abs(imag(v)) < math.Pi/2 {
+				checkComplexAgreement(t, fmt.Sprintf("atanh(tanh %v)", v), new(Complex).Atanh(new(Complex).Tanh(x)), x, prec-8)
+			}
+		}
+	}
+
+	// e^(πi) = -1
+	for _, prec := range []uint{256, 2000} {
+		pi := ComputePi(prec)
+		got := new(Complex).Exp(NewComplex(new(big.Float).SetPrec(prec), pi))
+		want := new(Complex).SetPrec(prec).SetComplex128(-1)
+		checkComplexAgreement(t, "e^(πi)", got, want, prec)
+	}
+}
+
+// checkComplexAgreement reports an error unless got and want agree to bits
+// bits relative to |want|.
+func checkComplexAgreement(t *testing.T, name string, got, want *Complex, bits uint) {
+	t.Helper()
+
+	diff := new(Complex).SetPrec(got.Prec()).Sub(got, want)
+	d := diff.Abs()
+	if d.Sign() == 0 {
+		return
+	}
+	if agree := want.Abs().MantExp(nil) - d.MantExp(nil); agree < int(bits)-4 {
+		t.Errorf("%s at %d bits only agrees to %d bits", name, got.Prec(), agree)
+	}
+}
+
+func TestComplexText(t *testing.T) {
+	x := NewComplex128(complex(1.5, -2))
+	if got, want := x.String(), "(1.5-2i)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := x.Conj(x).Text('f', 2), "(1.50+2.00i)"; got != want {
+		t.Errorf("Text('f', 2) = %q, want %q", got, want)
+	}
+}
+
+func BenchmarkComplex(b *testing.B) {
+	for _, fn := range complexFunctions {
+		for _, prec := range []uint{53, 256, 1000} {
+			b.Run(fmt.Sprintf("%s/precision_%d", fn.name, prec), func(b *testing.B) {
+				x := new(Complex).SetPrec(prec).SetComplex128(complex(0.5, -1.25))
+				z := new(Complex)
+				for b.Loop() {
+					fn.big(z.SetPrec(0), x)
+				}
+			})
+		}
+	}
+}
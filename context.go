@@ -0,0 +1,55 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+)
+
+// Long computations have Ctx forms, such as ComputePiCtx and ExpCtx, which
+// stop early and return ctx.Err() once their context is done. The kernels
+// underneath take the context as their first argument and call checkCtx in
+// every series loop, iteration and binary splitting recursion; the plain
+// forms pass context.Background(), which is never done.
+//
+// Rather than return an error up through every level of the recursion,
+// checkCtx unwinds the computation with a panic, which the Ctx function at
+// the top recovers with recoverCtx. The panic never leaves the package.
+
+// ctxDone is the value checkCtx panics with.
+type ctxDone struct {
+	err error
+}
+
+// checkCtx unwinds the computation if ctx is done.
+func checkCtx(ctx context.Context) {
+	if err := ctx.Err(); err != nil {
+		panic(ctxDone{err})
+	}
+}
+
+// recoverCtx stores the error of a computation unwound by checkCtx in *err,
+// leaving any other panic alone. It must be deferred directly:
+//
+//	defer recoverCtx(&err)
+func recoverCtx(err *error) {
+	if r := recover(); r != nil {
+		done, ok := r.(ctxDone)
+		if !ok {
+			panic(r)
+		}
+		*err = done.err
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+var ctxFunctions = []struct {
+	name  string
+	fn    func(ctx context.Context) (any, error)
+	plain func() any
+}{
+	{"ComputePiCtx",
+		func(ctx context.Context) (any, error) { return ComputePiCtx(ctx, 2000) },
+		func() any { return ComputePi(2000) }},
+	{"ComputeECtx",
+		func(ctx context.Context) (any, error) { return ComputeECtx(ctx, 2000) },
+		func() any { return ComputeE(2000) }},
+	{"ComputeLn2Ctx",
+		func(ctx context.Context) (any, error) { return ComputeLn2Ctx(ctx, 2000) },
+		func() any { return ComputeLn2(2000) }},
+	{"ComputeEulerGammaCtx",
+		func(ctx context.Context) (any, error) { return ComputeEulerGammaCtx(ctx, 500) },
+		func() any { return ComputeEulerGamma(500) }},
+	{"ExpCtx",
+		func(ctx context.Context) (any, error) { return ExpCtx(ctx, newRatFloat("7/3", 500)) },
+		func() any { return Exp(newRatFloat("7/3", 500)) }},
+	{"LogCtx",
+		func(ctx context.Context) (any, error) { return LogCtx(ctx, newRatFloat("7/3", 500)) },
+		func() any { return Log(newRatFloat("7/3", 500)) }},
+	{"PowCtx",
+		func(ctx context.Context) (any, error) {
+			return PowCtx(ctx, newRatFloat("7/3", 500), newRatFloat("1/3", 24))
+		},
+		func() any { return Pow(newRatFloat("7/3", 500), newRatFloat("1/3", 24)) }},
+	{"SinCtx",
+		func(ctx context.Context) (any, error) { return SinCtx(ctx, newRatFloat("7/3", 500)) },
+		func() any { return Sin(newRatFloat("7/3", 500)) }},
+	{"CosCtx",
+		func(ctx context.Context) (any, error) { return CosCtx(ctx, newRatFloat("7/3", 500)) },
+		func() any { return Cos(newRatFloat("7/3", 500)) }},
+	{"AtanCtx",
+		func(ctx context.Context) (any, error) { return AtanCtx(ctx, newRatFloat("7/3", 500)) },
+		func() any { return Atan(newRatFloat("7/3", 500)) }},
+	{"GammaCtx",
+		func(ctx context.Context) (any, error) { return GammaCtx(ctx, newRatFloat("7/3", 500)) },
+		func() any { return Gamma(newRatFloat("7/3", 500)) }},
+	{"FactorialCtx",
+		func(ctx context.Context) (any, error) { return FactorialCtx(ctx, 5000) },
+		func() any { return Factorial(5000) }},
+	{"Series.SumCtx",
+		func(ctx context.Context) (any, error) { return eSeries.SumCtx(ctx, 500, 2000) },
+		func() any { return eSeries.Sum(500, 2000) }},
+}
+
+// eSeries is e = Σ 1/n! as a Series.
+var eSeries = &Series{Q: func(n int64) *big.Int {
+	if n == 0 {
+		return big.NewInt(1)
+	}
+
+	return big.NewInt(n)
+}}
+
+// sameValue reports whether a and b, both *big.Float or both *big.Int, are
+// equal.
+func sameValue(a, b any) bool {
+	switch a := a.(type) {
+	case *big.Float:
+		b, ok := b.(*big.Float)
+
+		return ok && a.Cmp(b) == 0 && a.Prec() == b.Prec()
+	case *big.Int:
+		b, ok := b.(*big.Int)
+
+		return ok && a.Cmp(b) == 0
+	case []*big.Float:
+		b, ok := b.([]*big.Float)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !sameValue(a[i], b[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func TestCtxCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, f := range ctxFunctions {
+		got, err := f.fn(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s with a canceled context returned error %v, want %v", f.name, err, context.Canceled)
+		}
+		if !isNil(got) {
+			t.Errorf("%s with a canceled context = %v, want nil", f.name, got)
+		}
+	}
+}
+
+// isNil reports whether v holds a nil *big.Float or *big.Int.
+func isNil(v any) bool {
+	switch v := v.(type) {
+	case *big.Float:
+		return v == nil
+	case *big.Int:
+		return v == nil
+	}
+
+	return v == nil
+}
+
+func TestCtxBackground(t *testing.T) {
+	for _, f := range ctxFunctions {
+		got, err := f.fn(context.Background())
+		if err != nil {
+			t.Errorf("%s returned error %v", f.name, err)
+		}
+		if want := f.plain(); !sameValue(got, want) {
+			t.Errorf("%s = %v, want %v", f.name, got, want)
+		}
+	}
+}
+
+func TestCtxDeadline(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(ctx context.Context) error
+	}{
+		{"ComputePiCtx", func(ctx context.Context) error {
+			_, err := ComputePiCtx(ctx, 50_000_000)
+
+			return err
+		}},
+		{"ExpCtx", func(ctx context.Context) error {
+			_, err := ExpCtx(ctx, newRatFloat("1/3", 5_000_000))
+
+			return err
+		}},
+		{"LogCtx", func(ctx context.Context) error {
+			_, err := LogCtx(ctx, newRatFloat("1/3", 5_000_000))
+
+			return err
+		}},
+		{"FactorialCtx", func(ctx context.Context) error {
+			_, err := FactorialCtx(ctx, 50_000_000)
+
+			return err
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := test.fn(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("%s returned error %v, want %v", test.name, err, context.DeadlineExceeded)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("%s took %v to stop after its deadline", test.name, elapsed)
+			}
+		})
+	}
+}
+
+func TestCtxOtherPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("recoverCtx swallowed a panic that was not a cancellation")
+		}
+	}()
+
+	func() (err error) {
+		defer recoverCtx(&err)
+		panic("boom")
+	}()
+}
@@ -0,0 +1,627 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// CORDIC turns a vector (x, y) in steps through the angles e_s, each of
+// which costs only shifts and additions:
+//
+//	x' = x − m·d·y·2^-s
+//	y' = y + d·x·2^-s
+//	z' = z − d·e_s
+//
+// with d = ±1. In rotation mode d is the sign of z, taking the angle z left
+// to turn through towards 0; in vectoring mode it is the sign that takes y
+// towards 0, adding the angle turned through to z. The mode m sets the
+// geometry:
+//
+//	circular,   m = 1:  e_s = atan(2^-s),  s = 0, 1, 2, 3, …
+//	linear,     m = 0:  e_s = 2^-s,        s = 0, 1, 2, 3, …
+//	hyperbolic, m = −1: e_s = atanh(2^-s), s = 1, 2, 3, 4, 4, 5, …
+//
+// Rotation through z ends at K·(x cos z − y sin z, y cos z + x sin z),
+// K·(x cosh z + y sinh z, y cosh z + x sinh z) or (x, y + x·z), and
+// vectoring at z + atan(y/x), z + atanh(y/x) or z + y/x, with x ending at
+// K·√(x² + y²) or K·√(x² − y²). Each step stretches the vector by
+// √(1 + m·2^-2s), and the gain K is their product. The hyperbolic angles
+// more than halve from one step to the next, so the steps after e_s cannot
+// always make up for it; taking the shifts 4, 13, 40, …, k' = 3k + 1 twice
+// makes sure they do.
+//
+// After the step of shift s at most about 2^-s of the angle is left, so a
+// result of w bits takes the shifts up to w. At w bits atan(2^-s) and
+// atanh(2^-s) round to 2^-s, and the factor of step s of the gain to 1,
+// once s > w/2, so the table of a precision needs only the angles below
+// that. Past them, a rotation through the angle z left can be done at
+// once, as cos z and cosh z are then 1, and sin z and sinh z are z, to w
+// bits, which halves the steps.
+
+// A CORDICMode is the geometry of the steps of CORDIC.
+type CORDICMode int
+
+const (
+	// CORDICCircular turns through atan(2^-s), for sin, cos and atan.
+	CORDICCircular CORDICMode = iota
+
+	// CORDICLinear steps by 2^-s, for multiplication and division.
+	CORDICLinear
+
+	// CORDICHyperbolic turns through atanh(2^-s), for exp, log, the
+	// hyperbolic functions and square roots.
+	CORDICHyperbolic
+)
+
+var cordicModeNames = [...]string{
+	CORDICCircular:   "Circular",
+	CORDICLinear:     "Linear",
+	CORDICHyperbolic: "Hyperbolic",
+}
+
+// String returns the name of m, such as "Hyperbolic".
+func (m CORDICMode) String() string {
+	if m >= 0 && int(m) < len(cordicModeNames) {
+		return cordicModeNames[m]
+	}
+
+	return fmt.Sprintf("CORDICMode(%d)", int(m))
+}
+
+// checkCORDICMode panics if m is not one of the modes.
+func checkCORDICMode(m CORDICMode) {
+	if m < 0 || int(m) >= len(cordicModeNames) {
+		panic(fmt.Sprintf("bigmath: unknown %v", m))
+	}
+}
+
+// CORDICSchedule returns the shifts s of the first n steps of mode: 0, 1,
+// 2, … for the circular and linear modes, and 1, 2, 3, 4, 4, 5, …, 13, 13,
+// 14, … for the hyperbolic mode.
+func CORDICSchedule(mode CORDICMode, n int) []int {
+	checkCORDICMode(mode)
+
+	shifts := make([]int, 0, max(n, 0))
+	s, repeat := 0, 4
+	if mode == CORDICHyperbolic {
+		s = 1
+	}
+	for ; len(shifts) < n; s++ {
+		shifts = append(shifts, s)
+		if mode == CORDICHyperbolic && s == repeat && len(shifts) < n {
+			shifts = append(shifts, s)
+			repeat = 3*repeat + 1
+		}
+	}
+
+	return shifts
+}
+
+// cordicSteps returns the number of steps of mode whose shifts are at most
+// w.
+func cordicSteps(mode CORDICMode, w uint) int {
+	if mode != CORDICHyperbolic {
+		return int(w) + 1
+	}
+
+	n := int(w)
+	for repeat := uint(4); repeat <= w; repeat = 3*repeat + 1 {
+		n++
+	}
+
+	return n
+}
+
+// CORDICGain returns the gain K of the first n steps of mode, the product
+// of √(1 + m·2^-2s) over their shifts, with precision prec. It is about
+// 1.6468 for the circular mode and 0.8282 for the hyperbolic mode, and 1
+// for the linear mode.
+func CORDICGain(mode CORDICMode, n int, prec uint) *big.Float {
+	return cordicGain(mode, CORDICSchedule(mode, n), prec)
+}
+
+// cordicGain returns the gain of the steps of shifts with precision prec.
+func cordicGain(mode CORDICMode, shifts []int, prec uint) *big.Float {
+	// The product has one rounding per factor.
+	work := prec + uint(bits.Len(uint(len(shifts)))) + 8
+	p := new(big.Float).SetPrec(work).SetInt64(1)
+	if mode == CORDICLinear {
+		return p.SetPrec(prec)
+	}
+
+	f := new(big.Float).SetPrec(work)
+	next := new(big.Float).SetPrec(work)
+	for _, s := range shifts {
+		f.SetMantExp(f.SetInt64(1), -2*s)
+		if mode == CORDICHyperbolic {
+			f.Neg(f)
+		}
+		f.Add(f, one)
+		next.Mul(p, f)
+		p, next = next, p
+	}
+
+	return p.Sqrt(p).SetPrec(prec)
+}
+
+// CORDICAngle returns the angle e_s of mode with precision prec:
+// atan(2^-s), 2^-s or atanh(2^-s). The hyperbolic angle of shift 0 is
+// +Inf. It panics if s is negative.
+func CORDICAngle(mode CORDICMode, s int, prec uint) *big.Float {
+	checkCORDICMode(mode)
+	if s < 0 {
+		panic(fmt.Sprintf("bigmath: CORDICAngle of negative shift %d", s))
+	}
+	if mode == CORDICHyperbolic && s == 0 {
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	}
+
+	z := new(big.Float).SetPrec(prec)
+
+	return z.Set(cordicTableFor(context.Background(), mode, prec).angle(z, s))
+}
+
+// cordicMaxCachedPrec is the largest precision whose tables are cached. A
+// table of w bits holds w/2 angles of w bits, so above it each call
+// computes its own.
+const cordicMaxCachedPrec = 8192
+
+// A cordicTable holds the angles e_s and the inverse 1/K of the gain of
+// CORDIC in one mode to one precision.
+type cordicTable struct {
+	mode   CORDICMode
+	angles []*big.Float // e_s for s <= prec/2 + 1, but none for linear
+	gain   *big.Float   // 1/K over every step
+}
+
+// A cordicKey is the mode and precision of a table.
+type cordicKey struct {
+	mode CORDICMode
+	prec uint
+}
+
+// cordicTables holds the tables computed so far.
+var cordicTables = struct {
+	sync.Mutex
+	m map[cordicKey]*cordicTable
+}{m: make(map[cordicKey]*cordicTable)}
+
+// cordicTableFor returns the table of mode with precision prec, from the
+// cache if it has been computed already. It must not be modified.
+func cordicTableFor(ctx context.Context, mode CORDICMode, prec uint) *cordicTable {
+	if prec > cordicMaxCachedPrec {
+		return newCordicTable(ctx, mode, prec)
+	}
+
+	key := cordicKey{mode, prec}
+	cordicTables.Lock()
+	t, ok := cordicTables.m[key]
+	cordicTables.Unlock()
+	if ok {
+		return t
+	}
+
+	t = newCordicTable(ctx, mode, prec)
+
+	cordicTables.Lock()
+	defer cordicTables.Unlock()
+	if old, ok := cordicTables.m[key]; ok {
+		// Another call computed it first.
+		return old
+	}
+	cordicTables.m[key] = t
+
+	return t
+}
+
+// newCordicTable computes the table of mode with precision prec.
+func newCordicTable(ctx context.Context, mode CORDICMode, prec uint) *cordicTable {
+	t := &cordicTable{mode: mode}
+	if mode == CORDICLinear {
+		t.gain = new(big.Float).SetPrec(prec).SetInt64(1)
+
+		return t
+	}
+
+	s := getScratch()
+	defer s.release()
+
+	n := int(prec/2) + 2
+	t.angles = make([]*big.Float, n)
+
+	// atan(2^-s) = 2^-s − 2^-3s/3 + 2^-5s/5 − … and atanh(2^-s) = 2^-s +
+	// 2^-3s/3 + 2^-5s/5 + …, except for atan(1) = π/4, whose series
+	// converges too slowly, and atanh(1), which is never used. SetMantExp
+	// takes the precision of its mantissa, so the powers of 2 are set from
+	// the buffers themselves.
+	if mode == CORDICCircular {
+		t.angles[0] = piCache.get(ctx, prec)
+		t.angles[0].SetMantExp(t.angles[0], -2)
+	}
+	work := seriesWork(prec)
+	first := s.float(work)
+	y := s.float(work)
+	sum := s.float(work)
+	for i := 1; i < n; i++ {
+		first.SetMantExp(first.SetInt64(1), -i)
+		y.SetMantExp(y.SetInt64(1), -2*i)
+		if mode == CORDICCircular {
+			y.Neg(y)
+		}
+		// Each series takes its own scratch, so that they are reused.
+		ss := getScratch()
+		sumSeries(ctx, ss, sum, first, y, atanRatio)
+		ss.release()
+		t.angles[i] = new(big.Float).SetPrec(prec).Set(sum)
+	}
+
+	k := cordicGain(mode, CORDICSchedule(mode, cordicSteps(mode, uint(n-1))), prec+8)
+	t.gain = new(big.Float).SetPrec(prec).Quo(one, k)
+
+	return t
+}
+
+// angle returns e_s, in z if it is not in the table.
+func (t *cordicTable) angle(z *big.Float, s int) *big.Float {
+	if s < len(t.angles) {
+		return t.angles[s]
+	}
+
+	return z.SetMantExp(z.SetInt64(1), -s)
+}
+
+// cordicRun takes the steps of shifts on x, y and z in place, in rotation
+// mode or, if vectoring, in vectoring mode. x, y and z must have the
+// precision of t, to which every addition rounds.
+func cordicRun(ctx context.Context, t *cordicTable, x, y, z *big.Float, shifts []int, vectoring bool) {
+	s := getScratch()
+	defer s.release()
+
+	prec := x.Prec()
+	dx := s.float(prec)
+	dy := s.float(prec)
+	a := s.float(prec)
+	for _, i := range shifts {
+		checkCtx(ctx)
+
+		// The shifts are exact, and both are taken before x and y change.
+		dx.SetMantExp(y, -i)
+		dy.SetMantExp(x, -i)
+		up := z.Sign() >= 0
+		if vectoring {
+			up = x.Sign()*y.Sign() < 0
+		}
+		if !up {
+			dx.Neg(dx)
+			dy.Neg(dy)
+		}
+
+		switch t.mode {
+		case CORDICCircular:
+			x.Sub(x, dx)
+		case CORDICHyperbolic:
+			x.Add(x, dx)
+		}
+		y.Add(y, dy)
+		if up {
+			z.Sub(z, t.angle(a, i))
+		} else {
+			z.Add(z, t.angle(a, i))
+		}
+	}
+}
+
+// cordicRotate returns cos r and sin r, or cosh r and sinh r, with work
+// bits, for r within the range of mode, to within a unit of 2^-work for
+// each step, as every step rounds. If finish is false it takes every step;
+// otherwise it takes half of them and rotates through the angle left at
+// once.
+func cordicRotate(ctx context.Context, mode CORDICMode, r *big.Float, work uint, finish bool) (c, s *big.Float) {
+	t := cordicTableFor(ctx, mode, work)
+
+	sc := getScratch()
+	defer sc.release()
+
+	c = new(big.Float).SetPrec(work).Set(t.gain)
+	s = new(big.Float).SetPrec(work)
+	z := sc.float(work).Set(r)
+
+	n := cordicSteps(mode, work)
+	if finish {
+		n = cordicSteps(mode, uint(len(t.angles)-1))
+	}
+	cordicRun(ctx, t, c, s, z, CORDICSchedule(mode, n), false)
+
+	if finish {
+		dc := sc.float(work).Mul(s, z)
+		ds := sc.float(work).Mul(c, z)
+		if mode == CORDICCircular {
+			dc.Neg(dc)
+		}
+		c.Add(c, dc)
+		s.Add(s, ds)
+	}
+
+	return c, s
+}
+
+// cordicVector returns the magnitude √(x² + y²), or √(x² − y²), and the
+// angle atan(y/x), or atanh(y/x), of (x, y) with work bits, by vectoring
+// in mode, for an angle within its range. In the linear mode they are x
+// and y/x.
+func cordicVector(ctx context.Context, mode CORDICMode, x, y *big.Float, work uint) (r, z *big.Float) {
+	t := cordicTableFor(ctx, mode, work)
+
+	s := getScratch()
+	defer s.release()
+
+	r = new(big.Float).SetPrec(work).Set(x)
+	z = new(big.Float).SetPrec(work)
+	cordicRun(ctx, t, r, s.float(work).Set(y), z, CORDICSchedule(mode, cordicSteps(mode, work)), true)
+
+	return r.Mul(r, t.gain), z
+}
+
+// cordicWork returns the precision for the steps of CORDIC giving a result
+// of prec bits of about the size of r, or of about 1 if r is nil.
+func cordicWork(prec uint, r *big.Float) uint {
+	// The extra bits of seriesWork cover the rounding of every step. That
+	// error is the same whatever the result is, so a small one needs as
+	// many more bits as it has leading zeros to keep its relative
+	// precision.
+	work := seriesWork(prec)
+	if r != nil && r.Sign() != 0 {
+		work += uint(max(0, -r.MantExp(nil)))
+	}
+
+	// Rounded up to whole words, the precisions share fewer tables.
+	return (work + 63) &^ 63
+}
+
+// cordicSinCos returns sin x and cos x with the precision of x, to within a
+// unit in the last place, by circular CORDIC. x must be finite.
+func cordicSinCos(ctx context.Context, x *big.Float, finish bool) (sin, cos *big.Float) {
+	prec := x.Prec()
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x), new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	r, quadrant := reduceHalfPi(ctx, x, seriesWork(prec))
+	c, s := cordicRotate(ctx, CORDICCircular, r, cordicWork(prec, r), finish)
+
+	return unreduceHalfPi(s, c, quadrant, prec)
+}
+
+// cordicSinhCosh returns sinh x and cosh x with the precision of x, to
+// within a unit in the last place, by hyperbolic CORDIC. x must be finite
+// and non-zero, and e^x must not overflow.
+func cordicSinhCosh(ctx context.Context, x *big.Float) (sinh, cosh *big.Float) {
+	prec := x.Prec()
+
+	// x = k·ln 2 + r with |r| <= ln(2)/2, within the range of the steps.
+	r, k := reduceLn2(ctx, x, seriesWork(prec))
+	if k == 0 {
+		cosh, sinh = cordicRotate(ctx, CORDICHyperbolic, r, cordicWork(prec, r), false)
+
+		return sinh.SetPrec(prec), cosh.SetPrec(prec)
+	}
+
+	// e^x = 2^k·(cosh r + sinh r) and e^-x = 2^-k·(cosh r − sinh r), and
+	// |x| >= ln(2)/2, so their difference loses at most two bits.
+	c, s := cordicRotate(ctx, CORDICHyperbolic, r, cordicWork(prec, nil), false)
+	ep := new(big.Float).SetPrec(c.Prec()).Add(c, s)
+	ep.SetMantExp(ep, k)
+	em := c.Sub(c, s)
+	em.SetMantExp(em, -k)
+
+	sinh = new(big.Float).SetPrec(ep.Prec()).Sub(ep, em)
+	cosh = ep.Add(ep, em)
+	sinh.SetMantExp(sinh, -1)
+	cosh.SetMantExp(cosh, -1)
+
+	return sinh.SetPrec(prec), cosh.SetPrec(prec)
+}
+
+// mulCORDIC returns x·y with the larger precision of x and y, by linear
+// CORDIC. x and y must be finite.
+// This is a package-private method for performance comparison.
+func mulCORDIC(x, y *big.Float) *big.Float {
+	prec := max(x.Prec(), y.Prec())
+	if x.Sign() == 0 || y.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Mul(x, y)
+	}
+
+	// x·y = 2^(ex+ey)·mx·my, and rotating (mx, 0) through my < 1 ends at
+	// (mx, mx·my).
+	ctx := context.Background()
+	mx, my := new(big.Float), new(big.Float)
+	e := x.MantExp(mx) + y.MantExp(my)
+	work := cordicWork(prec, nil)
+	t := cordicTableFor(ctx, CORDICLinear, work)
+	a := new(big.Float).SetPrec(work).Set(mx)
+	b := new(big.Float).SetPrec(work)
+	c := new(big.Float).SetPrec(work).Set(my)
+	cordicRun(ctx, t, a, b, c, CORDICSchedule(CORDICLinear, cordicSteps(CORDICLinear, work)), false)
+
+	return b.SetMantExp(b, e).SetPrec(prec)
+}
+
+// quoCORDIC returns x/y with the larger precision of x and y, by linear
+// CORDIC. x and y must be finite and not both zero.
+// This is a package-private method for performance comparison.
+func quoCORDIC(x, y *big.Float) *big.Float {
+	prec := max(x.Prec(), y.Prec())
+	if x.Sign() == 0 || y.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Quo(x, y)
+	}
+
+	// x/y = 2^(ex−ey)·mx/my, and vectoring (my, mx) leaves mx/my < 2 in z.
+	mx, my := new(big.Float), new(big.Float)
+	e := x.MantExp(mx) - y.MantExp(my)
+	_, z := cordicVector(context.Background(), CORDICLinear, my, mx, cordicWork(prec, nil))
+
+	return z.SetMantExp(z, e).SetPrec(prec)
+}
+
+// A CORDICConfig sets how CORDICRotate and CORDICVector run.
+type CORDICConfig struct {
+	// Mode is the geometry of the steps.
+	Mode CORDICMode
+
+	// Iterations is the number of steps, with the shifts of
+	// CORDICSchedule. If it is not positive, it is the number whose
+	// shifts reach the precision, FracBits or that of the arguments.
+	Iterations int
+
+	// FracBits, if it is not 0, runs the steps in fixed point with
+	// FracBits fraction bits, as a hardware unit does: x, y, z and the
+	// angles are rounded to the nearest multiple of 2^-FracBits, ties
+	// upwards, the shifts are arithmetic, rounding towards −Inf, and the
+	// additions are exact, as in a two's complement unit wide enough never
+	// to overflow. Otherwise the steps are in floating point, with the
+	// largest precision of the arguments, or 64 bits if that is 0, and
+	// each addition rounds to nearest even.
+	FracBits uint
+}
+
+// CORDICRotate runs CORDIC in rotation mode from (x, y, z), taking d = +1
+// if z >= 0 and −1 otherwise at each step, and returns where it ends. The
+// results are not divided by the gain, which is CORDICGain(cfg.Mode,
+// cfg.Iterations, prec), nor is z checked to be within the range of the
+// steps. In fixed point the results are exact. x, y and z must be finite.
+//
+// For example, in the circular mode with x = 1/K and y = 0, it returns
+// about cos z and sin z, and 0 for z.
+func CORDICRotate(cfg CORDICConfig, x, y, z *big.Float) (xn, yn, zn *big.Float) {
+	return cordic(cfg, x, y, z, false)
+}
+
+// CORDICVector runs CORDIC in vectoring mode from (x, y, z), taking d = +1
+// if x and y have opposite signs and −1 otherwise at each step, which
+// takes y towards 0, and returns where it ends, like CORDICRotate.
+//
+// For example, in the hyperbolic mode with x = 1, y = t and z = 0, it
+// returns about K·√(1 − t²), 0 and atanh(t).
+func CORDICVector(cfg CORDICConfig, x, y, z *big.Float) (xn, yn, zn *big.Float) {
+	return cordic(cfg, x, y, z, true)
+}
+
+// cordic implements CORDICRotate and CORDICVector.
+func cordic(cfg CORDICConfig, x, y, z *big.Float, vectoring bool) (xn, yn, zn *big.Float) {
+	checkCORDICMode(cfg.Mode)
+	ctx := context.Background()
+	if cfg.FracBits > 0 {
+		return cordicFixed(ctx, cfg, x, y, z, vectoring)
+	}
+
+	prec := max(x.Prec(), y.Prec(), z.Prec())
+	if prec == 0 {
+		prec = 64
+	}
+	n := cfg.Iterations
+	if n <= 0 {
+		n = cordicSteps(cfg.Mode, prec)
+	}
+
+	xn = new(big.Float).SetPrec(prec).Set(x)
+	yn = new(big.Float).SetPrec(prec).Set(y)
+	zn = new(big.Float).SetPrec(prec).Set(z)
+	cordicRun(ctx, cordicTableFor(ctx, cfg.Mode, prec), xn, yn, zn, CORDICSchedule(cfg.Mode, n), vectoring)
+
+	return xn, yn, zn
+}
+
+// cordicFixed runs CORDIC in fixed point, as CORDICConfig describes.
+func cordicFixed(ctx context.Context, cfg CORDICConfig, x, y, z *big.Float, vectoring bool) (xn, yn, zn *big.Float) {
+	f := cfg.FracBits
+	n := cfg.Iterations
+	if n <= 0 {
+		n = cordicSteps(cfg.Mode, f)
+	}
+	shifts := CORDICSchedule(cfg.Mode, n)
+
+	// The angles are rounded from 64 more bits, which gives the nearest
+	// multiple of 2^-f unless they are within 2^-(f+64) of a tie.
+	var angles []*big.Int
+	if len(shifts) > 0 {
+		angles = make([]*big.Int, shifts[len(shifts)-1]+1)
+	}
+	t := cordicTableFor(ctx, cfg.Mode, f+64)
+	a := new(big.Float).SetPrec(f + 64)
+	for _, s := range shifts {
+		if angles[s] == nil {
+			angles[s] = cordicToFixed(t.angle(a, s), f)
+		}
+	}
+
+	fx := cordicToFixed(x, f)
+	fy := cordicToFixed(y, f)
+	fz := cordicToFixed(z, f)
+	dx := new(big.Int)
+	dy := new(big.Int)
+	for _, s := range shifts {
+		checkCtx(ctx)
+
+		dx.Rsh(fy, uint(s))
+		dy.Rsh(fx, uint(s))
+		up := fz.Sign() >= 0
+		if vectoring {
+			up = fx.Sign()*fy.Sign() < 0
+		}
+		if !up {
+			dx.Neg(dx)
+			dy.Neg(dy)
+		}
+
+		switch cfg.Mode {
+		case CORDICCircular:
+			fx.Sub(fx, dx)
+		case CORDICHyperbolic:
+			fx.Add(fx, dx)
+		}
+		fy.Add(fy, dy)
+		if up {
+			fz.Sub(fz, angles[s])
+		} else {
+			fz.Add(fz, angles[s])
+		}
+	}
+
+	return cordicFromFixed(fx, f), cordicFromFixed(fy, f), cordicFromFixed(fz, f)
+}
+
+// cordicToFixed returns x·2^f rounded to the nearest integer, ties
+// upwards. x must be finite.
+func cordicToFixed(x *big.Float, f uint) *big.Int {
+	r, _ := x.Rat(nil)
+
+	// ⌊x·2^f + 1/2⌋ = ⌊(2·num·2^f + den) / 2·den⌋.
+	n := new(big.Int).Lsh(r.Num(), f+1)
+	n.Add(n, r.Denom())
+
+	return n.Div(n, new(big.Int).Lsh(r.Denom(), 1))
+}
+
+// cordicFromFixed returns n·2^-f exactly.
+func cordicFromFixed(n *big.Int, f uint) *big.Float {
+	z := new(big.Float).SetInt(n)
+
+	return z.SetMantExp(z, -int(f))
+}
@@ -0,0 +1,360 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var cordicKernels = []struct {
+	name   string
+	fn     func(*big.Float) *big.Float
+	wantFn func(*big.Float) *big.Float
+}{
+	{"sinCORDIC", sinCORDIC, Sin},
+	{"sinCORDICImproved", sinCORDICImproved, Sin},
+	{"cosCORDIC", cosCORDIC, Cos},
+	{"tanCORDIC", tanCORDIC, Tan},
+}
+
+var cordicHyperbolicKernels = []struct {
+	name   string
+	fn     func(*big.Float) *big.Float
+	wantFn func(*big.Float) *big.Float
+	args   []string
+}{
+	{"expCORDIC", expCORDIC, Exp, []string{"1/3", "-7/5", "1/1000000000000", "355/113", "100", "-1000"}},
+	{"logCORDIC", logCORDIC, Log, []string{"1/3", "7/5", "1000001/1000000", "999999/1000000", "355/113", "1/1000000000000"}},
+	{"sinhCORDIC", sinhCORDIC, Sinh, []string{"1/3", "-7/5", "1/1000000000000", "355/113", "-40"}},
+	{"coshCORDIC", coshCORDIC, Cosh, []string{"1/3", "-7/5", "1/1000000000000", "355/113", "-40"}},
+	{"tanhCORDIC", tanhCORDIC, Tanh, []string{"1/3", "-7/5", "1/1000000000000", "355/113", "-40"}},
+	{"atanhCORDIC", atanhCORDIC, Atanh, []string{"1/3", "-3/4", "1/1000000000000", "-9/10", "999999/1000000"}},
+	{"sqrtCORDIC", sqrtCORDIC, Sqrt, []string{"1/3", "2", "355/113", "1000000", "1/1000000000000"}},
+}
+
+func TestCordicKernels(t *testing.T) {
+	for _, prec := range []uint{53, 200, 1000, 3000} {
+		for _, s := range []string{"1/3", "-7/5", "1/1000000000000", "355/113", "1000000", "-11/7"} {
+			x := newRatFloat(s, prec)
+			for _, k := range cordicKernels {
+				got := k.fn(x)
+				want := k.wantFn(x)
+				if got.Prec() != prec || !withinUlp(got, want) {
+					t.Errorf("%s(%s) at %d bits = %s, want %s", k.name, s, prec, got.Text('g', 40), want.Text('g', 40))
+				}
+			}
+		}
+	}
+
+	for _, k := range cordicKernels[:3] {
+		if got := k.fn(new(big.Float).SetPrec(100)); got.Cmp(k.wantFn(new(big.Float))) != 0 {
+			t.Errorf("%s(0) = %v", k.name, got)
+		}
+	}
+}
+
+func TestCordicHyperbolicKernels(t *testing.T) {
+	for _, prec := range []uint{53, 200, 1000} {
+		for _, k := range cordicHyperbolicKernels {
+			for _, s := range k.args {
+				x := newRatFloat(s, prec)
+				got := k.fn(x)
+				want := k.wantFn(new(big.Float).SetPrec(prec + 64).Set(x))
+				want.SetPrec(prec)
+				if got.Prec() != prec || !withinUlp(got, want) {
+					t.Errorf("%s(%s) at %d bits = %s, want %s", k.name, s, prec, got.Text('g', 40), want.Text('g', 40))
+				}
+			}
+		}
+	}
+
+	if got := logCORDIC(big.NewFloat(1)); got.Sign() != 0 {
+		t.Errorf("logCORDIC(1) = %v, want 0", got)
+	}
+}
+
+func TestCordicLinear(t *testing.T) {
+	for _, prec := range []uint{53, 200, 1000} {
+		for _, xy := range [][2]string{{"1/3", "7/5"}, {"-355/113", "1000000"}, {"1/1000000000000", "-3"}, {"-2", "-1/2"}} {
+			x := newRatFloat(xy[0], prec)
+			y := newRatFloat(xy[1], prec)
+			if got, want := mulCORDIC(x, y), new(big.Float).SetPrec(prec).Mul(x, y); !withinUlp(got, want) {
+				t.Errorf("mulCORDIC(%s, %s) at %d bits = %s, want %s", xy[0], xy[1], prec, got.Text('g', 40), want.Text('g', 40))
+			}
+			if got, want := quoCORDIC(x, y), new(big.Float).SetPrec(prec).Quo(x, y); !withinUlp(got, want) {
+				t.Errorf("quoCORDIC(%s, %s) at %d bits = %s, want %s", xy[0], xy[1], prec, got.Text('g', 40), want.Text('g', 40))
+			}
+		}
+	}
+}
+
+func TestCORDICSchedule(t *testing.T) {
+	if got, want := CORDICSchedule(CORDICCircular, 4), []int{0, 1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("CORDICSchedule(Circular, 4) = %v, want %v", got, want)
+	}
+	if got, want := CORDICSchedule(CORDICHyperbolic, 7), []int{1, 2, 3, 4, 4, 5, 6}; !slices.Equal(got, want) {
+		t.Errorf("CORDICSchedule(Hyperbolic, 7) = %v, want %v", got, want)
+	}
+
+	// 4, 13 and 40 are taken twice; 121 is not reached.
+	shifts := CORDICSchedule(CORDICHyperbolic, 100)
+	for s, want := range map[int]int{3: 1, 4: 2, 12: 1, 13: 2, 40: 2, 41: 1, 97: 1} {
+		if got := countShift(shifts, s); got != want {
+			t.Errorf("the hyperbolic schedule takes shift %d %d times, want %d", s, got, want)
+		}
+	}
+
+	for _, mode := range []CORDICMode{CORDICCircular, CORDICLinear, CORDICHyperbolic} {
+		for _, w := range []uint{3, 4, 13, 100, 1000} {
+			shifts := CORDICSchedule(mode, cordicSteps(mode, w)+1)
+			if last, next := shifts[len(shifts)-2], shifts[len(shifts)-1]; last != int(w) || next <= int(w) {
+				t.Errorf("the %v steps of %d bits end with shift %d, followed by %d", mode, w, last, next)
+			}
+		}
+	}
+}
+
+// countShift returns the number of times shifts takes s.
+func countShift(shifts []int, s int) int {
+	n := 0
+	for _, i := range shifts {
+		if i == s {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestCORDICGain(t *testing.T) {
+	for _, mode := range []CORDICMode{CORDICCircular, CORDICLinear, CORDICHyperbolic} {
+		m := map[CORDICMode]float64{CORDICCircular: 1, CORDICLinear: 0, CORDICHyperbolic: -1}[mode]
+		want := 1.0
+		for _, s := range CORDICSchedule(mode, 40) {
+			want *= math.Sqrt(1 + m*math.Ldexp(1, -2*s))
+		}
+		got, _ := CORDICGain(mode, 40, 100).Float64()
+		if math.Abs(got-want) > 1e-15 {
+			t.Errorf("CORDICGain(%v, 40, 100) = %v, want %v", mode, got, want)
+		}
+
+		table := cordicTableFor(context.Background(), mode, 200)
+		k := CORDICGain(mode, cordicSteps(mode, 200), 200)
+		if p := k.Mul(k, table.gain); !withinUlp(p, big.NewFloat(1)) {
+			t.Errorf("the %v gain of the table is not the inverse of CORDICGain: product %v", mode, p)
+		}
+	}
+}
+
+func TestCORDICRotateVector(t *testing.T) {
+	const prec = 200
+	for _, tt := range []struct {
+		cfg      CORDICConfig
+		tol      int // the error allowed, as a power of 2
+		vectored bool
+	}{
+		{CORDICConfig{Mode: CORDICCircular}, -190, false},
+		{CORDICConfig{Mode: CORDICHyperbolic}, -190, false},
+		{CORDICConfig{Mode: CORDICCircular}, -190, true},
+		{CORDICConfig{Mode: CORDICHyperbolic}, -190, true},
+		{CORDICConfig{Mode: CORDICCircular, FracBits: 100}, -90, false},
+		{CORDICConfig{Mode: CORDICHyperbolic, FracBits: 100}, -90, true},
+		{CORDICConfig{Mode: CORDICHyperbolic, Iterations: 30}, -25, false},
+	} {
+		n := tt.cfg.Iterations
+		if n == 0 {
+			n = cordicSteps(tt.cfg.Mode, max(tt.cfg.FracBits, prec))
+		}
+		k := CORDICGain(tt.cfg.Mode, n, prec)
+		a := newRatFloat("3/5", prec)
+		hyperbolic := tt.cfg.Mode == CORDICHyperbolic
+
+		var got, want [3]*big.Float
+		if !tt.vectored {
+			// From (1/K, 0, a) to (cos a, sin a, 0) or (cosh a, sinh a, 0).
+			x := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), k)
+			got[0], got[1], got[2] = CORDICRotate(tt.cfg, x, new(big.Float).SetPrec(prec), a)
+			want[0], want[1] = Cos(a), Sin(a)
+			if hyperbolic {
+				want[0], want[1] = Cosh(a), Sinh(a)
+			}
+		} else {
+			// From (1, a, 0) to (K·√(1 ± a²), 0, atan a) or atanh a.
+			got[0], got[1], got[2] = CORDICVector(tt.cfg, big.NewFloat(1), a, new(big.Float).SetPrec(prec))
+			want[0] = new(big.Float).SetPrec(prec).Mul(a, a)
+			if hyperbolic {
+				want[0].Neg(want[0])
+			}
+			want[0].Add(want[0], big.NewFloat(1))
+			want[0].Sqrt(want[0]).Mul(want[0], k)
+			want[2] = Atan(a)
+			if hyperbolic {
+				want[2] = Atanh(a)
+			}
+		}
+
+		for i := range got {
+			if want[i] == nil {
+				want[i] = new(big.Float)
+			}
+			d := new(big.Float).Sub(got[i], want[i])
+			if d.Sign() != 0 && d.MantExp(nil) > tt.tol {
+				t.Errorf("%+v vectoring %t: result %d = %s, want %s", tt.cfg, tt.vectored, i, got[i].Text('g', 30), want[i].Text('g', 30))
+			}
+		}
+	}
+}
+
+func TestCORDICFixed(t *testing.T) {
+	// z = 12/16 takes y by +16, −8, +4 and +2 sixteenths, leaving
+	// z = −2/16.
+	cfg := CORDICConfig{Mode: CORDICLinear, Iterations: 4, FracBits: 4}
+	x, y, z := CORDICRotate(cfg, big.NewFloat(1), new(big.Float), big.NewFloat(0.75))
+	if x.Cmp(big.NewFloat(1)) != 0 || y.Cmp(big.NewFloat(0.875)) != 0 || z.Cmp(big.NewFloat(-0.125)) != 0 {
+		t.Errorf("CORDICRotate(%+v, 1, 0, 0.75) = %v, %v, %v, want 1, 0.875, -0.125", cfg, x, y, z)
+	}
+
+	// The shifts round towards −Inf, so −1/16 halved is −1/16: y takes −1/16
+	// and then +1/16, where floating point takes −1/16 and +1/32.
+	cfg = CORDICConfig{Mode: CORDICLinear, Iterations: 2, FracBits: 4}
+	x = big.NewFloat(-0.0625)
+	if _, y, _ := CORDICRotate(cfg, x, new(big.Float), big.NewFloat(0.5)); y.Sign() != 0 {
+		t.Errorf("CORDICRotate(%+v, -1/16, 0, 1/2) gives y = %v, want 0", cfg, y)
+	}
+	cfg.FracBits = 0
+	if _, y, _ := CORDICRotate(cfg, x, new(big.Float), big.NewFloat(0.5)); y.Cmp(big.NewFloat(-0.03125)) != 0 {
+		t.Errorf("CORDICRotate(%+v, -1/16, 0, 1/2) gives y = %v, want -1/32", cfg, y)
+	}
+
+	// Arguments are rounded to the grid, ties upwards, and the results are
+	// multiples of it.
+	cfg = CORDICConfig{Mode: CORDICHyperbolic, FracBits: 8}
+	for _, v := range []float64{1.0 / 3, -1.0 / 512, 0.7} {
+		x, y, z := CORDICVector(cfg, big.NewFloat(1), big.NewFloat(v), new(big.Float))
+		for _, r := range []*big.Float{x, y, z} {
+			if !new(big.Float).SetMantExp(r, 8).IsInt() {
+				t.Errorf("CORDICVector(%+v, 1, %v, 0) = %v, %v, %v, not multiples of 2^-8", cfg, v, x, y, z)
+			}
+		}
+	}
+	if got := cordicToFixed(big.NewFloat(-1.0/512), 8); got.Int64() != 0 {
+		t.Errorf("cordicToFixed(-1/512, 8) = %v, want 0", got)
+	}
+	if got := cordicToFixed(big.NewFloat(3.0/512), 8); got.Int64() != 2 {
+		t.Errorf("cordicToFixed(3/512, 8) = %v, want 2", got)
+	}
+}
+
+func TestCORDICMode(t *testing.T) {
+	if got := CORDICHyperbolic.String(); got != "Hyperbolic" {
+		t.Errorf("CORDICHyperbolic.String() = %q", got)
+	}
+	if got := CORDICMode(7).String(); got != "CORDICMode(7)" {
+		t.Errorf("CORDICMode(7).String() = %q", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("CORDICSchedule(CORDICMode(7), 1) did not panic")
+		}
+	}()
+	CORDICSchedule(CORDICMode(7), 1)
+}
+
+func TestCORDICAngleNegativeShift(t *testing.T) {
+	for _, mode := range []CORDICMode{CORDICCircular, CORDICLinear, CORDICHyperbolic} {
+		func() {
+			defer func() {
+				if r, _ := recover().(string); !strings.Contains(r, "negative shift") {
+					t.Errorf("CORDICAngle(%v, -1, 64) panicked with %q, want a negative shift", mode, r)
+				}
+			}()
+			CORDICAngle(mode, -1, 64)
+		}()
+	}
+}
+
+func TestCordicTable(t *testing.T) {
+	const prec = 300
+	table := cordicTableFor(context.Background(), CORDICCircular, prec)
+	if again := cordicTableFor(context.Background(), CORDICCircular, prec); again != table {
+		t.Errorf("the table of %d bits was computed again", prec)
+	}
+
+	// 1/K to 90 digits.
+	gain, _ := new(big.Float).SetPrec(prec).SetString("0.607252935008881256169446752504928263112390852150089772456976013110147881208424906906227426")
+	if d := new(big.Float).Sub(table.gain, gain); d.Sign() != 0 && d.MantExp(nil) > -295 {
+		t.Errorf("gain = %s, want %s", table.gain.Text('g', 90), gain.Text('g', 90))
+	}
+
+	a := new(big.Float).SetPrec(prec)
+	for _, i := range []int{0, 1, 5, 40, 150, 151, 200} {
+		x := new(big.Float).SetPrec(prec + 64).SetInt64(1)
+		x.SetMantExp(x, -i)
+		want := Atan(x)
+		want.SetPrec(prec)
+		if got := table.angle(a, i); !withinUlp(got, want) {
+			t.Errorf("atan(2^-%d) = %s, want %s", i, got.Text('g', 40), want.Text('g', 40))
+		}
+	}
+
+	for _, i := range []int{1, 4, 40, 150, 151, 200} {
+		x := new(big.Float).SetPrec(prec + 64).SetInt64(1)
+		x.SetMantExp(x, -i)
+		want := Atanh(x)
+		want.SetPrec(prec)
+		if got := CORDICAngle(CORDICHyperbolic, i, prec); !withinUlp(got, want) {
+			t.Errorf("atanh(2^-%d) = %s, want %s", i, got.Text('g', 40), want.Text('g', 40))
+		}
+	}
+	if got := CORDICAngle(CORDICHyperbolic, 0, prec); !got.IsInf() {
+		t.Errorf("CORDICAngle(Hyperbolic, 0) = %v, want +Inf", got)
+	}
+	if got := CORDICAngle(CORDICLinear, 3, prec); got.Cmp(big.NewFloat(0.125)) != 0 {
+		t.Errorf("CORDICAngle(Linear, 3) = %v, want 0.125", got)
+	}
+}
+
+func BenchmarkCordic(b *testing.B) {
+	for _, prec := range []uint{64, 256, 1024} {
+		x := newRatFloat("7/10", prec)
+
+		for _, k := range cordicKernels {
+			b.Run(fmt.Sprintf("%s/prec_%d", k.name, prec), func(b *testing.B) {
+				for b.Loop() {
+					k.fn(x)
+				}
+			})
+		}
+		for _, k := range cordicHyperbolicKernels {
+			b.Run(fmt.Sprintf("%s/prec_%d", k.name, prec), func(b *testing.B) {
+				for b.Loop() {
+					k.fn(x)
+				}
+			})
+		}
+		b.Run(fmt.Sprintf("Sin/prec_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				Sin(x)
+			}
+		})
+	}
+}
@@ -14,58 +14,98 @@
 
 package bigmath
 
-import "math/big"
+import (
+	"context"
+	"math/big"
+)
 
 // Cos returns the cosine of the radian argument x.
 //
+// The result has the precision of x and is correctly rounded in its
+// rounding mode. Cos uses the same algorithms as Sin.
+//
 // The special cases are:
 //
-//	Cos(±Inf) = NaN
-//	Cos(NaN) = NaN
+//	Cos(±0) = 1
+//	Cos(±Inf) = +Inf, and CosE returns ErrDomain
 func Cos(x *big.Float) *big.Float {
-	precision := x.Prec()
-	result := new(big.Float).SetPrec(precision)
-	term := new(big.Float).SetPrec(precision).SetInt64(1)
-	xSquared := new(big.Float).SetPrec(precision)
-	xSquared.Mul(x, x)
-
-	result.SetInt64(1)
-
-	// Taylor series for cos: (-1)^n * (x^2n / x!)
-	// cos(x) = 1 - x²/2! + x⁴/4! - x⁶/6! + ...
-	for i := int64(1); i < 100; i++ {
-		// Calculate next term: multiply by x² and divide by (2i-1)(2i)
-		term.Mul(term, xSquared)
-
-		// Divide by the next two factorial terms
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*i-1))
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*i))
-
-		// Alternate signs: subtract for odd i, add for even i
-		if i%2 == 1 {
-			result.Sub(result, term)
-		} else {
-			result.Add(result, term)
-		}
-
-		// Check convergence
-		threshold := new(big.Float).SetPrec(precision).Quo(
-			new(big.Float).SetInt64(1),
-			new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision/4)), nil)),
-		)
-		if term.Cmp(threshold) < 0 {
-			break
-		}
+	z, _ := CosE(x)
+
+	return z
+}
+
+// CosE is like Cos, and also returns an error wrapping ErrDomain for ±Inf,
+// or ErrNoConvergence if the rounding could not be proven correct.
+func CosE(x *big.Float) (*big.Float, error) {
+	return cosE(context.Background(), x)
+}
+
+// CosCtx is like CosE, but stops and returns ctx.Err() if ctx is done
+// before the result is ready.
+func CosCtx(ctx context.Context, x *big.Float) (z *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return cosE(ctx, x)
+}
+
+// CosTo sets z to the cosine of the radian argument x, correctly rounded to
+// the precision of z in its rounding mode, and returns z. If the precision
+// of z is 0, it is changed to the precision of x first, so that
+// CosTo(new(big.Float).SetMode(x.Mode()), x) gives the same result as
+// Cos(x). z may be x.
+//
+// Like the methods of big.Float, CosTo reuses the storage of z, and its
+// temporaries are reused from one call to the next, so it allocates far
+// less than Cos when called in a loop.
+func CosTo(z, x *big.Float) *big.Float {
+	if z.Prec() == 0 {
+		z.SetPrec(x.Prec())
 	}
+	z, _ = cosTo(context.Background(), nil, z, x)
 
-	return result
+	return z
+}
+
+// cosE implements CosE.
+func cosE(ctx context.Context, x *big.Float) (*big.Float, error) {
+	return cosTo(ctx, nil, new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode()), x)
+}
+
+// cosTo implements CosTo, and also returns the error of CosE. The error
+// describes x as it is after the call, so it is only meaningful if z is not
+// x. The Context c, which may be nil, sets the guard bits and iteration
+// limit of the rounding.
+func cosTo(ctx context.Context, c *Context, z, x *big.Float) (*big.Float, error) {
+	switch {
+	case x.Sign() == 0:
+		return z.SetInt64(1), nil
+	case x.IsInf():
+		err := funcError(ErrDomain, "Cos", x)
+
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return z.SetInf(false), err
+	case 2*x.MantExp(nil) <= -int(max(x.Prec(), z.Prec()))-3:
+		// cos x = 1 − x²/2 + …, below 1 by less than x².
+		return roundNudged(z, one, -1), nil
+	}
+
+	z, err := c.correctlyRounded(z, func(work uint) *big.Float {
+		_, cos := sinCosApprox(ctx, new(big.Float).SetPrec(work).Set(x))
+
+		return cos
+	}, nil)
+	if err != nil {
+		return z, funcError(err, "Cos", x)
+	}
+
+	return z, nil
 }
 
 // Acos returns the arccosine, in radians, of x.
 //
 // The special case is:
 //
-//	Acos(x) = NaN if x < -1 or x > 1
+//	Acos(x) = +Inf if x < -1 or x > 1, and AcosE returns ErrDomain
 func Acos(x *big.Float) *big.Float {
 	precision := x.Prec()
 
@@ -77,7 +117,7 @@ func Acos(x *big.Float) *big.Float {
 
 	if x.Cmp(one) > 0 || x.Cmp(negOne) < 0 {
 		result := new(big.Float).SetPrec(precision)
-		result.SetInf(false) // Return NaN for out of domain
+		result.SetInf(false) // Return +Inf for out of domain (big.Float has no NaN)
 
 		return result
 	}
@@ -92,22 +132,39 @@ func Acos(x *big.Float) *big.Float {
 	return result
 }
 
+// AcosE is like Acos, and also returns an error wrapping ErrDomain for
+// x < -1 or x > 1.
+func AcosE(x *big.Float) (*big.Float, error) {
+	if new(big.Float).Abs(x).Cmp(one) > 0 {
+		return Acos(x), funcError(ErrDomain, "Acos", x)
+	}
+
+	return Acos(x), nil
+}
+
 // Cosh returns the hyperbolic cosine of x.
 //
 // The special cases are:
 //
 //	Cosh(±0) = 1
 //	Cosh(±Inf) = +Inf
-//	Cosh(NaN) = NaN
 func Cosh(x *big.Float) *big.Float {
-	precision := x.Prec()
+	_, cosh := sinhCosh(x)
 
-	// TODO(rsned): Implement.
-	// For now, return a placeholder result
-	result := new(big.Float).SetPrec(precision)
-	result.SetInt64(0)
+	return cosh
+}
 
-	return result
+// coshCORDIC calculates cosh(x) by hyperbolic CORDIC, as sinhCORDIC does
+// sinh(x). x must be finite, with |x| < 2^31·ln 2 so that e^x is within
+// the exponent range; larger x give meaningless results.
+// This is a package-private method for performance comparison.
+func coshCORDIC(x *big.Float) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(x.Prec()).SetInt64(1)
+	}
+	_, cosh := cordicSinhCosh(context.Background(), x)
+
+	return cosh
 }
 
 // Acosh returns the inverse hyperbolic cosine of x.
@@ -115,8 +172,7 @@ func Cosh(x *big.Float) *big.Float {
 // The special cases are:
 //
 //	Acosh(+Inf) = +Inf
-//	Acosh(x) = NaN if x < 1
-//	Acosh(NaN) = NaN
+//	Acosh(x) = +Inf if x < 1
 func Acosh(x *big.Float) *big.Float {
 	precision := x.Prec()
 
@@ -128,100 +184,13 @@ func Acosh(x *big.Float) *big.Float {
 	return result
 }
 
-// cosCORDIC calculates cos(x) using CORDIC algorithm.
+// cosCORDIC calculates cos(x) using the CORDIC algorithm, as sinCORDIC
+// does sin(x). x must be finite.
 // This is a package-private method for performance comparison.
 func cosCORDIC(x *big.Float) *big.Float {
-	precision := x.Prec()
-
-	// CORDIC requires argument reduction to [-π/2, π/2]
-	reducedX := new(big.Float).SetPrec(precision).Set(x)
-	twoPi := new(big.Float).SetPrec(precision).Mul(bigPi, big.NewFloat(2))
-	pi := new(big.Float).SetPrec(precision).Set(bigPi)
-	halfPi := new(big.Float).SetPrec(precision).Quo(pi, big.NewFloat(2))
-
-	// Reduce to [0, 2π]
-	for reducedX.Cmp(twoPi) >= 0 {
-		reducedX.Sub(reducedX, twoPi)
-	}
-	for reducedX.Cmp(big.NewFloat(0)) < 0 {
-		reducedX.Add(reducedX, twoPi)
-	}
-
-	// Further reduce and track transformations
-	sign := 1
-	if reducedX.Cmp(pi) > 0 {
-		reducedX.Sub(reducedX, pi)
-		sign = -1
-	}
-
-	cosToSin := false
-	if reducedX.Cmp(halfPi) > 0 {
-		reducedX.Sub(pi, reducedX)
-		cosToSin = true
-	}
-
-	// CORDIC rotation mode
-	x0 := new(big.Float).SetPrec(precision).SetFloat64(0.6072529350088812561694) // 1/K ≈ 0.607252935
-	y0 := new(big.Float).SetPrec(precision).SetInt64(0)
-	z0 := new(big.Float).SetPrec(precision).Set(reducedX)
-
-	// CORDIC iterations
-	for i := 0; i < int(precision/2)+10; i++ {
-		// Calculate 2^(-i)
-		powerOf2 := new(big.Float).SetPrec(precision).SetInt64(1)
-		for j := 0; j < i; j++ {
-			powerOf2.Quo(powerOf2, big.NewFloat(2))
-		}
-
-		// Calculate arctan(2^(-i))
-		arctanTable := new(big.Float).SetPrec(precision)
-		if i < len(arctanLookupTable) {
-			arctanTable, _, _ = new(big.Float).SetPrec(precision).Parse(arctanLookupTable[i], 10)
-		} else {
-			arctanTable.Set(powerOf2)
-		}
-
-		if z0.Sign() >= 0 {
-			newX := new(big.Float).SetPrec(precision).Set(x0)
-			temp := new(big.Float).SetPrec(precision).Mul(y0, powerOf2)
-			newX.Sub(newX, temp)
-
-			newY := new(big.Float).SetPrec(precision).Set(y0)
-			temp = new(big.Float).SetPrec(precision).Mul(x0, powerOf2)
-			newY.Add(newY, temp)
-
-			newZ := new(big.Float).SetPrec(precision).Set(z0)
-			newZ.Sub(newZ, arctanTable)
-
-			x0, y0, z0 = newX, newY, newZ
-		} else {
-			newX := new(big.Float).SetPrec(precision).Set(x0)
-			temp := new(big.Float).SetPrec(precision).Mul(y0, powerOf2)
-			newX.Add(newX, temp)
-
-			newY := new(big.Float).SetPrec(precision).Set(y0)
-			temp = new(big.Float).SetPrec(precision).Mul(x0, powerOf2)
-			newY.Sub(newY, temp)
-
-			newZ := new(big.Float).SetPrec(precision).Set(z0)
-			newZ.Add(newZ, arctanTable)
-
-			x0, y0, z0 = newX, newY, newZ
-		}
-	}
-
-	var result *big.Float
-	if cosToSin {
-		result = new(big.Float).SetPrec(precision).Set(y0)
-	} else {
-		result = new(big.Float).SetPrec(precision).Set(x0)
-	}
-
-	if sign == -1 {
-		result.Neg(result)
-	}
+	_, cos := cordicSinCos(context.Background(), x, false)
 
-	return result
+	return cos
 }
 
 // cosArgReduction calculates cos(x) using argument reduction and polynomial approximation.
@@ -229,40 +198,24 @@ func cosCORDIC(x *big.Float) *big.Float {
 func cosArgReduction(x *big.Float) *big.Float {
 	precision := x.Prec()
 
-	// Reduce argument to [-π/2, π/2]
-	reducedX := new(big.Float).SetPrec(precision).Set(x)
-	twoPi := new(big.Float).SetPrec(precision).Mul(bigPi, big.NewFloat(2))
-	pi := new(big.Float).SetPrec(precision).Set(bigPi)
-	halfPi := new(big.Float).SetPrec(precision).Quo(pi, big.NewFloat(2))
-
 	// Reduce to [0, 2π]
-	for reducedX.Cmp(twoPi) >= 0 {
-		reducedX.Sub(reducedX, twoPi)
-	}
-	for reducedX.Cmp(big.NewFloat(0)) < 0 {
-		reducedX.Add(reducedX, twoPi)
-	}
+	reducedX, pi := reduceTwoPi(x)
+	halfPi := new(big.Float).SetPrec(precision).Quo(pi, big.NewFloat(2))
 
-	// Further reduction using symmetries
+	// Further reduction using the symmetries
+	// cos(x) = -cos(x-π) and cos(x) = -cos(π-x)
 	sign := 1
 	if reducedX.Cmp(pi) > 0 {
 		reducedX.Sub(reducedX, pi)
 		sign = -1
 	}
-
-	cosToSin := false
 	if reducedX.Cmp(halfPi) > 0 {
 		reducedX.Sub(pi, reducedX)
-		cosToSin = true
+		sign = -sign
 	}
 
 	// Use minimax polynomial for cos(x) on [0, π/2]
-	var result *big.Float
-	if cosToSin {
-		result = Sin(reducedX)
-	} else {
-		result = Cos(reducedX)
-	}
+	result := Cos(reducedX)
 
 	if sign == -1 {
 		result.Neg(result)
@@ -17,8 +17,18 @@ package bigmath
 import "math/big"
 
 // Csc calculates cosecant as 1/sin(x).
+//
+// The special cases are:
+//
+//	Csc(±0) = ±Inf, and CscE returns ErrPole
+//	Csc(±Inf) = +Inf, and CscE returns ErrDomain
 func Csc(x *big.Float) *big.Float {
 	precision := x.Prec()
+	if x.IsInf() {
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return new(big.Float).SetPrec(precision).SetInf(false)
+	}
+
 	sinX := Sin(x)
 
 	result := new(big.Float).SetPrec(precision)
@@ -28,7 +38,24 @@ func Csc(x *big.Float) *big.Float {
 	return result
 }
 
+// CscE is like Csc, and also returns an error wrapping ErrPole for ±0, or
+// ErrDomain for ±Inf.
+func CscE(x *big.Float) (*big.Float, error) {
+	switch {
+	case x.Sign() == 0:
+		return Csc(x), funcError(ErrPole, "Csc", x)
+	case x.IsInf():
+		return Csc(x), funcError(ErrDomain, "Csc", x)
+	}
+
+	return Csc(x), nil
+}
+
 // Acsc calculates inverse cosecant using the identity acsc(x) = asin(1/x).
+//
+// The special case is:
+//
+//	Acsc(x) = +Inf if -1 < x < 1, and AcscE returns ErrDomain
 func Acsc(x *big.Float) *big.Float {
 	precision := x.Prec()
 
@@ -38,7 +65,7 @@ func Acsc(x *big.Float) *big.Float {
 
 	if abs.Cmp(one) < 0 {
 		result := new(big.Float).SetPrec(precision)
-		result.SetInf(false) // Return NaN for out of domain
+		result.SetInf(false) // Return +Inf for out of domain (big.Float has no NaN)
 
 		return result
 	}
@@ -49,6 +76,16 @@ func Acsc(x *big.Float) *big.Float {
 	return Asin(reciprocal)
 }
 
+// AcscE is like Acsc, and also returns an error wrapping ErrDomain for
+// -1 < x < 1.
+func AcscE(x *big.Float) (*big.Float, error) {
+	if new(big.Float).Abs(x).Cmp(one) < 0 {
+		return Acsc(x), funcError(ErrDomain, "Acsc", x)
+	}
+
+	return Acsc(x), nil
+}
+
 // Csch calculates hyperbolic cosecant using the formula: csch(x) = 1/sinh(x)
 // This is a placeholder implementation.
 func Csch(x *big.Float) *big.Float {
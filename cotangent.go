@@ -17,8 +17,18 @@ package bigmath
 import "math/big"
 
 // Cot calculates cot(x) as cos(x)/sin(x).
+//
+// The special cases are:
+//
+//	Cot(±0) = ±Inf, and CotE returns ErrPole
+//	Cot(±Inf) = +Inf, and CotE returns ErrDomain
 func Cot(x *big.Float) *big.Float {
 	precision := x.Prec()
+	if x.IsInf() {
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return new(big.Float).SetPrec(precision).SetInf(false)
+	}
+
 	cosX := Cos(x)
 	sinX := Sin(x)
 
@@ -28,6 +38,19 @@ func Cot(x *big.Float) *big.Float {
 	return result
 }
 
+// CotE is like Cot, and also returns an error wrapping ErrPole for ±0, or
+// ErrDomain for ±Inf.
+func CotE(x *big.Float) (*big.Float, error) {
+	switch {
+	case x.Sign() == 0:
+		return Cot(x), funcError(ErrPole, "Cot", x)
+	case x.IsInf():
+		return Cot(x), funcError(ErrDomain, "Cot", x)
+	}
+
+	return Cot(x), nil
+}
+
 // Acot calculates inverse cotangent using the identity acot(x) = π/2 - atan(x).
 func Acot(x *big.Float) *big.Float {
 	precision := x.Prec()
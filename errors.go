@@ -0,0 +1,70 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Functions with restricted domains come in pairs. The plain form, such as
+// Log, follows package math: it never panics, and where math would return
+// NaN it returns +Inf instead, as big.Float has no NaN. The E form, such as
+// LogE, returns the same value together with an error wrapping one of the
+// sentinel errors below, so errors.Is can tell an invalid argument from a
+// genuinely infinite result.
+var (
+	// ErrDomain reports an argument outside the domain of a function,
+	// where package math would return NaN.
+	ErrDomain = errors.New("bigmath: argument out of domain")
+
+	// ErrPole reports an argument at a pole of a function, such as Log(0)
+	// or Gamma(-2). The result is an infinity.
+	ErrPole = errors.New("bigmath: argument at a pole")
+
+	// ErrOverflow reports a finite result beyond the exponent range of
+	// big.Float, which is returned as ±Inf, or as 0 when it is too small.
+	ErrOverflow = errors.New("bigmath: result out of range")
+
+	// ErrNoConvergence reports a computation that stopped short of its
+	// target accuracy. The best approximation found is still returned. For
+	// the correctly rounded functions, it means the exact result is so
+	// close to a rounding boundary that the rounding could not be proven.
+	ErrNoConvergence = errors.New("bigmath: no convergence")
+)
+
+// funcError returns err annotated with the call it came from, such as
+// "Log(-2): bigmath: argument out of domain".
+func funcError(err error, name string, args ...*big.Float) error {
+	s := make([]string, len(args))
+	for i, arg := range args {
+		s[i] = arg.Text('g', 10)
+	}
+
+	return fmt.Errorf("%s(%s): %w", name, strings.Join(s, ", "), err)
+}
+
+// overflowError returns an ErrOverflow error for the call name(args) if
+// its result z is infinite or zero, and nil otherwise. It is only meaningful
+// for finite arguments whose exact result is finite and non-zero.
+func overflowError(z *big.Float, name string, args ...*big.Float) error {
+	if z.IsInf() || z.Sign() == 0 {
+		return funcError(ErrOverflow, name, args...)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestErrorVariants(t *testing.T) {
+	posInf, negInf := big.NewFloat(math.Inf(1)), big.NewFloat(math.Inf(-1))
+	negZero := new(big.Float).Neg(big.NewFloat(0))
+
+	tests := []struct {
+		name string
+		fn   func() (*big.Float, error)
+		want error
+	}{
+		{"ExpE(1)", func() (*big.Float, error) { return ExpE(big.NewFloat(1)) }, nil},
+		{"ExpE(-Inf)", func() (*big.Float, error) { return ExpE(negInf) }, nil},
+		{"ExpE(1e10)", func() (*big.Float, error) { return ExpE(big.NewFloat(1e10)) }, ErrOverflow},
+		{"ExpE(-1e10)", func() (*big.Float, error) { return ExpE(big.NewFloat(-1e10)) }, ErrOverflow},
+		{"LogE(2)", func() (*big.Float, error) { return LogE(big.NewFloat(2)) }, nil},
+		{"LogE(0)", func() (*big.Float, error) { return LogE(big.NewFloat(0)) }, ErrPole},
+		{"LogE(-0)", func() (*big.Float, error) { return LogE(negZero) }, ErrPole},
+		{"LogE(-2)", func() (*big.Float, error) { return LogE(big.NewFloat(-2)) }, ErrDomain},
+		{"SqrtE(-2)", func() (*big.Float, error) { return SqrtE(big.NewFloat(-2)) }, ErrDomain},
+		{"SinE(+Inf)", func() (*big.Float, error) { return SinE(posInf) }, ErrDomain},
+		{"CosE(-Inf)", func() (*big.Float, error) { return CosE(negInf) }, ErrDomain},
+		{"TanE(+Inf)", func() (*big.Float, error) { return TanE(posInf) }, ErrDomain},
+		{"SecE(+Inf)", func() (*big.Float, error) { return SecE(posInf) }, ErrDomain},
+		{"CscE(0)", func() (*big.Float, error) { return CscE(big.NewFloat(0)) }, ErrPole},
+		{"CotE(-0)", func() (*big.Float, error) { return CotE(negZero) }, ErrPole},
+		{"AtanE(+Inf)", func() (*big.Float, error) { return AtanE(posInf) }, nil},
+		{"AsinE(1.5)", func() (*big.Float, error) { return AsinE(big.NewFloat(1.5)) }, ErrDomain},
+		{"AsinE(-1)", func() (*big.Float, error) { return AsinE(big.NewFloat(-1)) }, nil},
+		{"AcosE(-1.5)", func() (*big.Float, error) { return AcosE(big.NewFloat(-1.5)) }, ErrDomain},
+		{"AsecE(0.5)", func() (*big.Float, error) { return AsecE(big.NewFloat(0.5)) }, ErrDomain},
+		{"AcscE(-0.5)", func() (*big.Float, error) { return AcscE(big.NewFloat(-0.5)) }, ErrDomain},
+		{"AcscE(2)", func() (*big.Float, error) { return AcscE(big.NewFloat(2)) }, nil},
+		{"PowE(2, 0.5)", func() (*big.Float, error) { return PowE(big.NewFloat(2), big.NewFloat(0.5)) }, nil},
+		{"PowE(-2, 0.5)", func() (*big.Float, error) { return PowE(big.NewFloat(-2), big.NewFloat(0.5)) }, ErrDomain},
+		{"PowE(-2, 3)", func() (*big.Float, error) { return PowE(big.NewFloat(-2), big.NewFloat(3)) }, nil},
+		{"PowE(0, -1)", func() (*big.Float, error) { return PowE(big.NewFloat(0), big.NewFloat(-1)) }, ErrPole},
+		{"PowE(10, 1e12)", func() (*big.Float, error) { return PowE(big.NewFloat(10), big.NewFloat(1e12)) }, ErrOverflow},
+		{"PowE(2, +Inf)", func() (*big.Float, error) { return PowE(big.NewFloat(2), posInf) }, nil},
+		{"GammaE(0.5)", func() (*big.Float, error) { return GammaE(big.NewFloat(0.5)) }, nil},
+		{"GammaE(-3)", func() (*big.Float, error) { return GammaE(big.NewFloat(-3)) }, ErrPole},
+		{"GammaE(-0)", func() (*big.Float, error) { return GammaE(negZero) }, ErrPole},
+		{"GammaE(-Inf)", func() (*big.Float, error) { return GammaE(negInf) }, ErrDomain},
+		{"FactorialFloatE(-1)", func() (*big.Float, error) { return FactorialFloatE(big.NewFloat(-1)) }, ErrDomain},
+		{"FactorialFloatE(1e300)", func() (*big.Float, error) { return FactorialFloatE(big.NewFloat(1e300)) }, ErrOverflow},
+		{"FactorialFloatE(+Inf)", func() (*big.Float, error) { return FactorialFloatE(posInf) }, nil},
+	}
+	for _, test := range tests {
+		got, err := test.fn()
+		if got == nil {
+			t.Errorf("%s returned a nil result", test.name)
+		}
+		switch {
+		case test.want == nil && err != nil:
+			t.Errorf("%s returned error %v", test.name, err)
+		case test.want != nil && !errors.Is(err, test.want):
+			t.Errorf("%s returned error %v, want %v", test.name, err, test.want)
+		}
+	}
+
+	if _, err := FactorialE(-3); !errors.Is(err, ErrDomain) {
+		t.Errorf("FactorialE(-3) returned error %v, want %v", err, ErrDomain)
+	} else if got, want := err.Error(), "Factorial(-3): bigmath: argument out of domain"; got != want {
+		t.Errorf("FactorialE(-3) error = %q, want %q", got, want)
+	}
+	if got, err := FactorialE(5); err != nil || got.Int64() != 120 {
+		t.Errorf("FactorialE(5) = %v, %v", got, err)
+	}
+}
+
+func TestErrorVariantsMatch(t *testing.T) {
+	// The E forms return exactly what the plain forms do.
+	x := new(big.Float).SetPrec(200).SetFloat64(-0.75)
+	pairs := []struct {
+		name  string
+		plain *big.Float
+		e     func() (*big.Float, error)
+	}{
+		{"Exp", Exp(x), func() (*big.Float, error) { return ExpE(x) }},
+		{"Log", Log(x), func() (*big.Float, error) { return LogE(x) }},
+		{"Sin", Sin(x), func() (*big.Float, error) { return SinE(x) }},
+		{"Asin", Asin(x), func() (*big.Float, error) { return AsinE(x) }},
+		{"Gamma", Gamma(x), func() (*big.Float, error) { return GammaE(x) }},
+		{"Pow", Pow(x, big.NewFloat(3)), func() (*big.Float, error) { return PowE(x, big.NewFloat(3)) }},
+	}
+	for _, pair := range pairs {
+		got, _ := pair.e()
+		if got.Cmp(pair.plain) != 0 {
+			t.Errorf("%sE(%v) = %v, %s(%v) = %v", pair.name, x, got, pair.name, x, pair.plain)
+		}
+	}
+}
+
+func TestErrorText(t *testing.T) {
+	_, err := LogE(big.NewFloat(-2))
+	if got, want := err.Error(), "Log(-2): bigmath: argument out of domain"; got != want {
+		t.Errorf("LogE(-2) error = %q, want %q", got, want)
+	}
+}
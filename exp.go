@@ -15,78 +15,90 @@
 package bigmath
 
 import (
+	"context"
 	"math/big"
 )
 
 // Exp returns e**x, the base-e exponential of x.
 //
+// The result has the precision of x and is correctly rounded in its
+// rounding mode.
+//
 // The special cases are:
 //
 //	Exp(+Inf) = +Inf
-//	Exp(NaN) = NaN
-//
-// Very large values no longer overflow to 0 or +Inf.
-// Very small values no longer underflow to 1.
+//	Exp(-Inf) = 0
+//	Exp(±0) = 1
 //
-// For the time being, there is an explicit upper bound for x of ~700,000
-// beyond which we choose to call it Infinite instead of looping excessively.
+// Exp sums a power series at lower precisions and uses the bit-burst
+// algorithm with binary splitting, which scales to hundreds of thousands of
+// bits, at higher ones. Results overflow to +Inf, or underflow to
+// 0, only when they pass 2^±(2^31).
 func Exp(x *big.Float) *big.Float {
-	prec := x.Prec()
-	result := new(big.Float).SetPrec(prec).SetInt64(1) // Start with 1
-	term := new(big.Float).SetPrec(prec).SetInt64(1)   // Current term in series
+	z, _ := ExpE(x)
 
-	const largeX = 700000 // Our chosen limit for when to bail out.
+	return z
+}
 
-	// Handle special cases
-	if x.Sign() == 0 {
-		return big.NewFloat(1).SetPrec(prec)
-	}
+// ExpE is like Exp, and also returns an error wrapping ErrOverflow if a
+// finite x over or underflows, or ErrNoConvergence if the rounding could not
+// be proven correct.
+func ExpE(x *big.Float) (*big.Float, error) {
+	return expE(context.Background(), x)
+}
 
-	// For very large positive x, return +Inf
-	xFloat, _ := x.Float64()
-	if xFloat > largeX {
-		result := big.NewFloat(0).SetPrec(prec)
-		result.SetInf(false) // +Inf
+// ExpCtx is like ExpE, but stops and returns ctx.Err() if ctx is done
+// before the result is ready.
+func ExpCtx(ctx context.Context, x *big.Float) (z *big.Float, err error) {
+	defer recoverCtx(&err)
 
-		return result
+	return expE(ctx, x)
+}
+
+// ExpTo sets z to e**x, correctly rounded to the precision of z in its
+// rounding mode, and returns z. If the precision of z is 0, it is changed
+// to the precision of x first, so that
+// ExpTo(new(big.Float).SetMode(x.Mode()), x) gives the same result as
+// Exp(x). z may be x.
+//
+// Like the methods of big.Float, ExpTo reuses the storage of z, and its
+// temporaries are reused from one call to the next, so it allocates far
+// less than Exp when called in a loop.
+func ExpTo(z, x *big.Float) *big.Float {
+	if z.Prec() == 0 {
+		z.SetPrec(x.Prec())
 	}
+	z, _ = expTo(context.Background(), nil, z, x)
+
+	return z
+}
+
+// expE implements ExpE.
+func expE(ctx context.Context, x *big.Float) (*big.Float, error) {
+	return expTo(ctx, nil, new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode()), x)
+}
 
-	// For very large negative x, return 0
-	if xFloat < -largeX {
-		return big.NewFloat(0).SetPrec(prec)
+// expTo implements ExpTo, and also returns the error of ExpE. The error
+// describes x as it is after the call, so it is only meaningful if z is not
+// x. The Context c, which may be nil, sets the guard bits and iteration
+// limit of the rounding.
+func expTo(ctx context.Context, c *Context, z, x *big.Float) (*big.Float, error) {
+	if x.IsInf() || x.Sign() == 0 {
+		return z.Set(expApprox(ctx, x)), nil
+	}
+	if x.MantExp(nil) <= -int(max(x.Prec(), z.Prec()))-4 {
+		// e^x = 1 + x + …, off 1 by less than 2|x|, with the sign of x.
+		return roundNudged(z, one, x.Sign()), nil
 	}
 
-	// compute using Taylor series
-	// e^x = 1 + x + x^2/2! + x^3/3! + ...
-	for i := 1; i < 200; i++ {
-		// term = term * x / i
-		term.Mul(term, x)
-		term.Quo(term, new(big.Float).SetPrec(prec).SetFloat64(float64(i)))
-		// Add term to result
-		result.Add(result, term)
-
-		// Check for convergence
-		termAbs := new(big.Float).SetPrec(prec).Abs(term)
-
-		// Check convergence
-		if termAbs.Cmp(new(big.Float).SetPrec(prec).Quo(
-			new(big.Float).SetInt64(1),
-			new(big.Float).SetPrec(prec).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec/4)), nil)),
-		)) < 0 {
-			break
-		}
-
-		// Prevent infinite loops with very large terms
-		absLimit := new(big.Float)
-		absLimit, _ = absLimit.SetString("1e1000")
-		if termAbs.Cmp(absLimit) > 0 {
-			result.SetInf(false) // Return +Inf if terms get too large
-
-			break
-		}
+	z, err := c.correctlyRounded(z, func(work uint) *big.Float {
+		return expApprox(ctx, new(big.Float).SetPrec(work).Set(x))
+	}, nil)
+	if err != nil {
+		return z, funcError(err, "Exp", x)
 	}
 
-	return result
+	return z, overflowError(z, "Exp", x)
 }
 
 // Exp2 returns 2**x, the base-2 exponential of x.
@@ -94,7 +106,6 @@ func Exp(x *big.Float) *big.Float {
 // The special cases are:
 //
 //	Exp2(+Inf) = +Inf
-//	Exp2(NaN) = NaN
 func Exp2(x *big.Float) *big.Float {
 	prec := x.Prec()
 
@@ -104,3 +115,35 @@ func Exp2(x *big.Float) *big.Float {
 
 	return result
 }
+
+// expCORDIC calculates e^x by hyperbolic CORDIC, as 2^k·(cosh r + sinh r)
+// for x = k·ln 2 + r, taking one rotation per bit of the precision of x.
+// x must be finite and nonzero, with |x| < 2^31·ln 2 so that e^x is within
+// the exponent range; outside that the result is meaningless.
+// This is a package-private method for performance comparison.
+func expCORDIC(x *big.Float) *big.Float {
+	ctx := context.Background()
+	prec := x.Prec()
+
+	r, k := reduceLn2(ctx, x, seriesWork(prec))
+	cosh, sinh := cordicRotate(ctx, CORDICHyperbolic, r, cordicWork(prec, nil), false)
+	cosh.Add(cosh, sinh)
+
+	return cosh.SetMantExp(cosh, k).SetPrec(prec)
+}
+
+// expTaylorRemainder returns an upper bound, rounded up, on the tail
+// Σ_{k≥n} r^k/k! of the Taylor series of e^r for every |r| <= bound, where
+// bound < n+1. Each term of the tail is at most bound/(n+1) times the one
+// before it, so the tail is at most bound^n/n! · (n+1)/(n+1-bound).
+func expTaylorRemainder(bound *big.Float, n int) *big.Float {
+	prec := max(bound.Prec(), 64)
+	result := powUp(bound, n, prec)
+	result.Quo(result, factorialDown(n, prec))
+	result.Mul(result, new(big.Float).SetInt64(int64(n+1)))
+
+	d := newDown(prec).SetInt64(int64(n + 1))
+	d.Sub(d, bound)
+
+	return result.Quo(result, d)
+}
@@ -47,17 +47,22 @@ func TestExp(t *testing.T) {
 }
 
 func TestExpEdgeCases(t *testing.T) {
-	// Test very large positive values
+	// Large values no longer overflow at low precision.
 	result := Exp(big.NewFloat(1000000))
+	if got := result.Text('g', 5); got != "3.0332e+434294" {
+		t.Errorf("Exp(1000000) = %s, expected 3.0332e+434294", got)
+	}
+
+	// Past the exponent range of big.Float they overflow to +Inf, or
+	// underflow to 0.
+	result = Exp(big.NewFloat(1e10))
 	if !result.IsInf() {
-		t.Errorf("Exp(1000000) should be +Inf, got %v", result)
+		t.Errorf("Exp(1e10) should be +Inf, got %v", result)
 	}
 
-	// Test very large negative values
-	result = Exp(big.NewFloat(-1000000))
-	resultFloat, _ := result.Float64()
-	if resultFloat != 0 {
-		t.Errorf("Exp(-1000000) should be 0, got %v", resultFloat)
+	result = Exp(big.NewFloat(-1e10))
+	if result.Sign() != 0 {
+		t.Errorf("Exp(-1e10) should be 0, got %v", result)
 	}
 }
 
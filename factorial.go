@@ -15,19 +15,79 @@
 package bigmath
 
 import (
+	"context"
 	"math/big"
+	"math/bits"
 )
 
+// factorialSwingThreshold is the value of n at and above which Factorial
+// uses the prime-swing algorithm. Below it, the set up cost of the prime
+// sieve outweighs the savings, and the simple loop is faster.
+const factorialSwingThreshold = 40
+
 // Factorial calculates n! using big.Int math to ensure no overflow.
 //
-// big.Int does not have a concept of Inf or NaN, so the best we can
-// do for negatives is return 0.
+// Large factorials are computed with Luschny's prime-swing algorithm,
+// which multiplies balanced products of prime powers rather than the
+// numbers 2·3·…·n one at a time.
+//
+// big.Int does not have a concept of Inf or NaN, so for n < 0 Factorial
+// returns 0, and FactorialE returns ErrDomain. FactorialFloat, whose result
+// can be infinite, returns +Inf for negatives instead.
 func Factorial(n int64) *big.Int {
 	if n < 0 {
 		// Factorial is undefined for negative numbers
 		return big.NewInt(0)
 	}
 
+	if n < factorialSwingThreshold {
+		return factorialIterative(n)
+	}
+
+	return factorialPrimeSwing(context.Background(), uint64(n))
+}
+
+// FactorialE is like Factorial, and also returns an error wrapping
+// ErrDomain for n < 0.
+func FactorialE(n int64) (*big.Int, error) {
+	if n < 0 {
+		return Factorial(n), funcError(ErrDomain, "Factorial", new(big.Float).SetInt64(n))
+	}
+
+	return Factorial(n), nil
+}
+
+// FactorialCtx is like FactorialE, but stops and returns ctx.Err() if ctx
+// is done before the result is ready.
+func FactorialCtx(ctx context.Context, n int64) (f *big.Int, err error) {
+	defer recoverCtx(&err)
+
+	if n < factorialSwingThreshold {
+		return FactorialE(n)
+	}
+
+	return factorialPrimeSwing(ctx, uint64(n)), nil
+}
+
+// FactorialBig calculates n! for an arbitrarily sized integer n.
+//
+// As with Factorial, negative values of n return 0. The result has more
+// than n bits for any n > 4, so values of n that do not fit in an int64
+// can not be represented and nil is returned for them.
+func FactorialBig(n *big.Int) *big.Int {
+	if n.Sign() < 0 {
+		return big.NewInt(0)
+	}
+
+	if !n.IsInt64() {
+		return nil
+	}
+
+	return Factorial(n.Int64())
+}
+
+// factorialIterative calculates n! by multiplying 2·3·…·n sequentially.
+func factorialIterative(n int64) *big.Int {
 	result := big.NewInt(1)
 	for i := int64(2); i <= n; i++ {
 		result.Mul(result, big.NewInt(i))
@@ -36,14 +96,174 @@ func Factorial(n int64) *big.Int {
 	return result
 }
 
+// factorialProductTree calculates n! by binary splitting the product
+// 2·3·…·n so that the multiplications are of similarly sized operands.
+// This is kept for performance comparison.
+func factorialProductTree(n int64) *big.Int {
+	if n < 2 {
+		return big.NewInt(1)
+	}
+
+	// MulRange recursively splits the range in half.
+	return new(big.Int).MulRange(2, n)
+}
+
+// factorialPrimeSwing calculates n! using the prime-swing algorithm.
+//
+// The swing number n≀ = n! / ⌊n/2⌋!² has a prime factorization that can be
+// written down directly, which gives the recursion
+//
+//	n! = ⌊n/2⌋!² · n≀
+//
+// Every power of two is stripped out of the recursion and applied as a
+// single shift at the end, since the exponent of 2 in n! is n − popcount(n).
+func factorialPrimeSwing(ctx context.Context, n uint64) *big.Int {
+	if n < 2 {
+		return big.NewInt(1)
+	}
+
+	primes := oddPrimesUpTo(n)
+	result := oddFactorial(ctx, n, primes)
+
+	return result.Lsh(result, uint(n)-uint(bits.OnesCount64(n)))
+}
+
+// oddFactorial returns the odd part of n!, given all of the odd primes up
+// to at least n.
+func oddFactorial(ctx context.Context, n uint64, primes []uint64) *big.Int {
+	if n < 3 {
+		return big.NewInt(1)
+	}
+
+	// The odd factorial of n/2 and the swing number of n are independent.
+	var result, swing *big.Int
+	if n >= parallelMinFactors && parallel() {
+		fork(func() { result = oddFactorial(ctx, n/2, primes) }, func() { swing = oddSwing(ctx, n, primes) })
+	} else {
+		result = oddFactorial(ctx, n/2, primes)
+		swing = oddSwing(ctx, n, primes)
+	}
+	result.Mul(result, result)
+
+	return result.Mul(result, swing)
+}
+
+// oddSwing returns the odd part of the swing number n≀.
+//
+// The exponent of the prime p in n≀ is the number of odd values in the
+// sequence ⌊n/p⌋, ⌊n/p²⌋, …, and p raised to that exponent never exceeds n,
+// so each prime contributes a single machine word factor.
+func oddSwing(ctx context.Context, n uint64, primes []uint64) *big.Int {
+	factors := make([]uint64, 0, 64)
+	for _, p := range primes {
+		if p > n {
+			break
+		}
+
+		// Primes between n/3 and n/2 do not divide the swing number,
+		// and those above n/2 divide it exactly once.
+		if p > n/2 {
+			factors = append(factors, p)
+
+			continue
+		}
+		if p > n/3 {
+			continue
+		}
+
+		pe := uint64(1)
+		for q := n / p; q > 0; q /= p {
+			if q&1 == 1 {
+				pe *= p
+			}
+		}
+		if pe > 1 {
+			factors = append(factors, pe)
+		}
+	}
+
+	return productTree(ctx, factors)
+}
+
+// productTree returns the product of the given factors, splitting the
+// list in half recursively so the operands stay balanced.
+func productTree(ctx context.Context, factors []uint64) *big.Int {
+	const leafSize = 16
+
+	checkCtx(ctx)
+
+	if len(factors) <= leafSize {
+		result := big.NewInt(1)
+		word := uint64(1)
+		tmp := new(big.Int)
+		for _, f := range factors {
+			// Accumulate into a machine word for as long as it fits.
+			hi, lo := bits.Mul64(word, f)
+			if hi == 0 {
+				word = lo
+
+				continue
+			}
+			result.Mul(result, tmp.SetUint64(word))
+			word = f
+		}
+
+		return result.Mul(result, tmp.SetUint64(word))
+	}
+
+	mid := len(factors) / 2
+	if len(factors) >= parallelMinFactors && parallel() {
+		var left, right *big.Int
+		fork(func() { left = productTree(ctx, factors[:mid]) }, func() { right = productTree(ctx, factors[mid:]) })
+
+		return left.Mul(left, right)
+	}
+	left := productTree(ctx, factors[:mid])
+
+	return left.Mul(left, productTree(ctx, factors[mid:]))
+}
+
+// oddPrimesUpTo returns the odd primes less than or equal to n in
+// ascending order using a sieve of Eratosthenes over the odd numbers.
+func oddPrimesUpTo(n uint64) []uint64 {
+	if n < 3 {
+		return nil
+	}
+
+	// composite[i] records whether 2i+1 is composite.
+	composite := make([]bool, (n-1)/2+1)
+	for i := uint64(1); ; i++ {
+		p := 2*i + 1
+		if p*p > n {
+			break
+		}
+		if composite[i] {
+			continue
+		}
+		for j := p * p / 2; j < uint64(len(composite)); j += p {
+			composite[j] = true
+		}
+	}
+
+	var primes []uint64
+	for i := uint64(1); i < uint64(len(composite)); i++ {
+		if !composite[i] {
+			primes = append(primes, 2*i+1)
+		}
+	}
+
+	return primes
+}
+
 // FactorialFloat is a function that returns the factorial of a given big.Float.
 // For integer values, computes n! = n * (n-1) * ... * 2 * 1
 // For non-integer values, uses the Gamma function property: n! = Gamma(n+1)
 //
-// Negative values will return +Inf.
+// Negative values will return +Inf, as do values so large the result is
+// beyond the exponent range of big.Float.
 func FactorialFloat(x *big.Float) *big.Float {
 	// Handle special cases
-	if x.Sign() < 0 {
+	if x.Sign() < 0 || x.Cmp(factorialFloatMax) > 0 {
 		// Factorial is undefined for negative numbers
 		result := big.NewFloat(0)
 		result.SetInf(false) // +Inf to indicate undefined
@@ -61,5 +281,24 @@ func FactorialFloat(x *big.Float) *big.Float {
 	// For non-integer values, use the relation n! = Gamma(n+1)
 	// TODO(rsned): Need to block circular infinite loop cycle because Gamma calls
 	// Factorial in some cases.
-	return Gamma(x.Add(x, big.NewFloat(1)))
+	return Gamma(new(big.Float).Add(x, big.NewFloat(1)))
+}
+
+// factorialFloatMax is the largest n for which n! is inside the exponent
+// range of big.Float, that is n! < 2^MaxExp.
+var factorialFloatMax = big.NewFloat(86181405)
+
+// FactorialFloatE is like FactorialFloat, and also returns an error
+// wrapping ErrDomain for x < 0, or ErrOverflow if the result is too large.
+func FactorialFloatE(x *big.Float) (*big.Float, error) {
+	switch {
+	case x.Sign() < 0:
+		return FactorialFloat(x), funcError(ErrDomain, "FactorialFloat", x)
+	case x.IsInf():
+		return FactorialFloat(x), nil
+	}
+
+	z := FactorialFloat(x)
+
+	return z, overflowError(z, "FactorialFloat", x)
 }
@@ -15,6 +15,7 @@
 package bigmath
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/big"
@@ -108,6 +109,107 @@ func TestFactorialBigConsistency(t *testing.T) {
 	}
 }
 
+func TestFactorialMethods(t *testing.T) {
+	methods := []struct {
+		name string
+		fn   func(n int64) *big.Int
+	}{
+		{"Factorial", Factorial},
+		{"factorialProductTree", factorialProductTree},
+		{"factorialPrimeSwing", func(n int64) *big.Int { return factorialPrimeSwing(context.Background(), uint64(n)) }},
+	}
+
+	for _, method := range methods {
+		// Every value through the small cases and the swing threshold.
+		for n := int64(0); n <= 1200; n++ {
+			if got, want := method.fn(n), factorialIterative(n); got.Cmp(want) != 0 {
+				t.Errorf("%s(%d) = %v, want %v", method.name, n, got, want)
+			}
+		}
+
+		for _, n := range []int64{4095, 4096, 4097, 65535, 65536, 100003} {
+			if got, want := method.fn(n), factorialProductTree(n); got.Cmp(want) != 0 {
+				t.Errorf("%s(%d) did not match the product tree", method.name, n)
+			}
+		}
+	}
+}
+
+func TestFactorialKnownValues(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{-1, "0"},
+		{0, "1"},
+		{1, "1"},
+		{20, "2432902008176640000"},
+		{25, "15511210043330985984000000"},
+		{50, "30414093201713378043612608166064768844377641568960512000000000000"},
+	}
+
+	for _, test := range tests {
+		if got := Factorial(test.n); got.String() != test.want {
+			t.Errorf("Factorial(%d) = %v, want %s", test.n, got, test.want)
+		}
+	}
+
+	// 1000! has 2568 digits, ends in 249 zeros and begins 402387260077.
+	got := Factorial(1000).String()
+	if len(got) != 2568 || !strings.HasPrefix(got, "402387260077") ||
+		!strings.HasSuffix(got, strings.Repeat("0", 249)) || got[len(got)-250] == '0' {
+		t.Errorf("Factorial(1000) = %s..., %d digits", got[:12], len(got))
+	}
+}
+
+func TestFactorialBigInt(t *testing.T) {
+	tests := []struct {
+		n    *big.Int
+		want *big.Int
+	}{
+		{big.NewInt(-5), big.NewInt(0)},
+		{big.NewInt(0), big.NewInt(1)},
+		{big.NewInt(10), big.NewInt(3628800)},
+		{big.NewInt(300), factorialIterative(300)},
+		{new(big.Int).Lsh(big.NewInt(1), 64), nil},
+		{new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 64)), big.NewInt(0)},
+	}
+
+	for _, test := range tests {
+		got := FactorialBig(test.n)
+		switch {
+		case test.want == nil && got != nil:
+			t.Errorf("FactorialBig(%v) = %v, want nil", test.n, got)
+		case test.want != nil && (got == nil || got.Cmp(test.want) != 0):
+			t.Errorf("FactorialBig(%v) = %v, want %v", test.n, got, test.want)
+		}
+	}
+}
+
+func TestOddPrimesUpTo(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want []uint64
+	}{
+		{0, nil},
+		{2, nil},
+		{3, []uint64{3}},
+		{30, []uint64{3, 5, 7, 11, 13, 17, 19, 23, 29}},
+		{49, []uint64{3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47}},
+	}
+
+	for _, test := range tests {
+		if got := oddPrimesUpTo(test.n); fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("oddPrimesUpTo(%d) = %v, want %v", test.n, got, test.want)
+		}
+	}
+
+	// There are 78498 primes below one million, one of which is 2.
+	if got := len(oddPrimesUpTo(1000000)); got != 78497 {
+		t.Errorf("len(oddPrimesUpTo(1000000)) = %d, want 78497", got)
+	}
+}
+
 // compareNumberStrings compares two integer number strings and returns
 // the number of digits from the start where they first differ.
 // This is useful for comparing high-precision calculations.
@@ -346,3 +448,24 @@ func BenchmarkScalingFactorial(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkFactorialMethods(b *testing.B) {
+	methods := []struct {
+		name string
+		fn   func(n int64) *big.Int
+	}{
+		{"Iterative", factorialIterative},
+		{"ProductTree", factorialProductTree},
+		{"PrimeSwing", func(n int64) *big.Int { return factorialPrimeSwing(context.Background(), uint64(n)) }},
+	}
+
+	for _, n := range []int64{20, 100, 1000, 10000, 100000} {
+		for _, method := range methods {
+			b.Run(fmt.Sprintf("%s_%d", method.name, n), func(b *testing.B) {
+				for b.Loop() {
+					_ = method.fn(n)
+				}
+			})
+		}
+	}
+}
@@ -15,6 +15,7 @@
 package bigmath
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/big"
@@ -175,7 +176,7 @@ func TestGammaLanczos(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := gammaLanczos(big.NewFloat(test.input))
+		result := gammaLanczos(context.Background(), big.NewFloat(test.input))
 		resultFloat, _ := result.Float64()
 
 		diff := math.Abs(resultFloat - test.expected)
@@ -196,7 +197,7 @@ func TestGammaMethodsComparison(t *testing.T) {
 		// Test different implementations
 		resultStandard := Gamma(x)
 		resultSpouge := gammaSpouge(x)
-		resultLanczos := gammaLanczos(x)
+		resultLanczos := gammaLanczos(context.Background(), x)
 
 		standardFloat, _ := resultStandard.Float64()
 		spougeFloat, _ := resultSpouge.Float64()
@@ -248,7 +249,7 @@ func TestGammaPrecisionComparison(t *testing.T) {
 		x128 := new(big.Float).SetPrec(128).SetFloat64(tc.input)
 
 		resultSpouge := gammaSpouge(x128)
-		resultLanczos := gammaLanczos(x128)
+		resultLanczos := gammaLanczos(context.Background(), x128)
 
 		spougeFloat, _ := resultSpouge.Float64()
 		lanczosFloat, _ := resultLanczos.Float64()
@@ -286,7 +287,7 @@ func TestGammaEdgeCases(t *testing.T) {
 		t.Errorf("GammaSpouge(-1) should be +Inf, got %v", negInt)
 	}
 
-	negIntLanczos := gammaLanczos(big.NewFloat(-2))
+	negIntLanczos := gammaLanczos(context.Background(), big.NewFloat(-2))
 	if !negIntLanczos.IsInf() {
 		t.Errorf("GammaLanczos(-2) should be +Inf, got %v", negIntLanczos)
 	}
@@ -305,6 +306,22 @@ func TestGammaEdgeCases(t *testing.T) {
 	}
 }
 
+func TestGammaTiny(t *testing.T) {
+	// Γ(x) = 1/x − γ + O(x) near zero, so to float64 precision it is 1/x.
+	for _, x := range []float64{1e-30, 1e-16, 1e-12, -1e-20, -1e-12} {
+		got, err := GammaE(big.NewFloat(x))
+		if err != nil {
+			t.Errorf("GammaE(%g) returned error %v", x, err)
+
+			continue
+		}
+		want := 1/x - 0.5772156649015329
+		if g, _ := got.Float64(); math.Abs(g-want) > 1e-14*math.Abs(want) {
+			t.Errorf("GammaE(%g) = %g, want %g", x, g, want)
+		}
+	}
+}
+
 func TestGammaNegativeValues(t *testing.T) {
 	// Test negative non-integer values using reflection formula
 	// Γ(z)Γ(1-z) = π/sin(πz)
@@ -330,7 +347,7 @@ func TestGammaNegativeValues(t *testing.T) {
 		spougeFloat, _ := spouge.Float64()
 
 		// Test GammaLanczos - this should also match
-		lanczos := gammaLanczos(big.NewFloat(test.input))
+		lanczos := gammaLanczos(context.Background(), big.NewFloat(test.input))
 		lanczosFloat, _ := lanczos.Float64()
 
 		// Check that all methods agree with each other
@@ -384,7 +401,7 @@ func TestGammaHighPrecisionCoefficients(t *testing.T) {
 			x := new(big.Float).SetPrec(prec).SetFloat64(tc.input)
 
 			// Test both GammaLanczos and GammaSpouge
-			resultLanczos := gammaLanczos(x)
+			resultLanczos := gammaLanczos(context.Background(), x)
 			resultSpouge := gammaSpouge(x)
 
 			lanczosFloat, _ := resultLanczos.Float64()
@@ -434,7 +451,7 @@ func BenchmarkGammaLanczos(b *testing.B) {
 	x := big.NewFloat(3.5)
 	b.ResetTimer()
 	for b.Loop() {
-		_ = gammaLanczos(x)
+		_ = gammaLanczos(context.Background(), x)
 	}
 }
 
@@ -461,7 +478,7 @@ func BenchmarkGammaComparative(b *testing.B) {
 		b.Run(fmt.Sprintf("Lanczos_%.1f", val), func(b *testing.B) {
 			b.ResetTimer()
 			for b.Loop() {
-				gammaLanczos(x)
+				gammaLanczos(context.Background(), x)
 			}
 		})
 	}
@@ -0,0 +1,1186 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// intervalGuardBits is the number of extra bits of working precision used by
+// the Interval functions, which keeps their enclosures close to the width
+// that the precision of the result allows.
+const intervalGuardBits = 64
+
+// Interval is a closed interval [lo, hi] of real numbers with big.Float
+// endpoints, for verified computation.
+//
+// Every operation rounds the lower endpoint of its result toward -Inf and the
+// upper endpoint toward +Inf, so the result is guaranteed to contain the exact
+// result of the operation at every point of its operands. The functions
+// evaluate their series in interval arithmetic and widen the sum by a
+// rigorous bound on the truncated tail, and the constants π and ln 2 they
+// need are computed the same way, so the guarantee holds for them too.
+//
+// Endpoints may be infinite. An interval with lo > hi is empty, which is the
+// result of a function applied entirely outside its domain, such as the Log
+// of a negative interval. Functions of the empty interval are empty.
+//
+// The zero value is [0, 0] with precision 0, and as with big.Float the
+// precision of the receiver of an operation is set from its operands when it
+// is 0. Like big.Float, an Interval must not be copied by value; use Set.
+type Interval struct {
+	lo, hi big.Float
+}
+
+// NewInterval returns a new Interval [lo, hi] with the larger of the
+// precisions of lo and hi.
+func NewInterval(lo, hi *big.Float) *Interval {
+	return new(Interval).setBounds(lo, hi, max(lo.Prec(), hi.Prec()))
+}
+
+// Lower returns the lower endpoint of x. The result is a reference to x's
+// endpoint; it may change if a new value is assigned to x, and vice versa.
+func (x *Interval) Lower() *big.Float {
+	return &x.lo
+}
+
+// Upper returns the upper endpoint of x. The result is a reference to x's
+// endpoint; it may change if a new value is assigned to x, and vice versa.
+func (x *Interval) Upper() *big.Float {
+	return &x.hi
+}
+
+// Prec returns the precision of x in bits.
+func (x *Interval) Prec() uint {
+	return max(x.lo.Prec(), x.hi.Prec())
+}
+
+// SetPrec sets the precision of both endpoints of z to prec, rounding them
+// outward if needed, and returns z.
+func (z *Interval) SetPrec(prec uint) *Interval {
+	z.lo.SetMode(big.ToNegativeInf).SetPrec(prec)
+	z.hi.SetMode(big.ToPositiveInf).SetPrec(prec)
+
+	return z
+}
+
+// Set sets z to x, rounded outward to the precision of z, and returns z. If
+// z's precision is 0, it is changed to the precision of x first.
+func (z *Interval) Set(x *Interval) *Interval {
+	return z.setBounds(&x.lo, &x.hi, z.resultPrec(x))
+}
+
+// SetFloat sets z to the interval [x, x], rounded outward to the precision of
+// z, and returns z. If z's precision is 0, it is changed to the precision of
+// x first.
+func (z *Interval) SetFloat(x *big.Float) *Interval {
+	prec := z.Prec()
+	if prec == 0 {
+		prec = x.Prec()
+	}
+
+	return z.setBounds(x, x, prec)
+}
+
+// SetString sets z to the smallest interval at the precision of z that
+// contains the number s, and returns z and a boolean indicating success. s
+// may be any decimal or fraction accepted by big.Rat.SetString, such as
+// "0.1", "1e-300" or "22/7", so it is not rounded before the endpoints are.
+// If z's precision is 0, it is changed to 64. On failure z is unchanged and
+// the result is (nil, false).
+func (z *Interval) SetString(s string) (*Interval, bool) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, false
+	}
+
+	prec := z.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+
+	return z.setRat(r, prec), true
+}
+
+// IsEmpty reports whether x is the empty interval.
+func (x *Interval) IsEmpty() bool {
+	return x.lo.Cmp(&x.hi) > 0
+}
+
+// Contains reports whether v lies in x.
+func (x *Interval) Contains(v *big.Float) bool {
+	return x.lo.Cmp(v) <= 0 && v.Cmp(&x.hi) <= 0
+}
+
+// Mid returns the midpoint of x with one bit more than the precision of x,
+// which makes it exact for finite endpoints. If one endpoint is infinite the
+// result is that endpoint, and the midpoint of [-Inf, +Inf] is 0.
+func (x *Interval) Mid() *big.Float {
+	result := new(big.Float).SetPrec(x.Prec() + 1)
+	switch {
+	case x.lo.IsInf() && x.hi.IsInf() && x.lo.Signbit() != x.hi.Signbit():
+		return result
+	case x.lo.IsInf():
+		return result.Set(&x.lo)
+	case x.hi.IsInf():
+		return result.Set(&x.hi)
+	}
+
+	result.Add(&x.lo, &x.hi)
+
+	return result.SetMantExp(result, -1)
+}
+
+// Width returns hi - lo rounded up, which is +Inf for an unbounded interval
+// and 0 for a point or the empty interval.
+func (x *Interval) Width() *big.Float {
+	result := newUp(x.Prec())
+	switch {
+	case x.IsEmpty() || x.lo.Cmp(&x.hi) == 0:
+		return result
+	case x.lo.IsInf() || x.hi.IsInf():
+		return result.SetInf(false)
+	}
+
+	return result.Sub(&x.hi, &x.lo)
+}
+
+// Text converts x to a string of the form [lo, hi], formatting each endpoint
+// with big.Float.Text using the given format and number of digits. The
+// decimal endpoints are rounded to nearest, so they are for display and
+// need not enclose x.
+func (x *Interval) Text(format byte, digits int) string {
+	return "[" + x.lo.Text(format, digits) + ", " + x.hi.Text(format, digits) + "]"
+}
+
+// String formats x like x.Text('g', 10).
+func (x *Interval) String() string {
+	return x.Text('g', 10)
+}
+
+// Neg sets z to -x and returns z.
+func (z *Interval) Neg(x *Interval) *Interval {
+	lo := new(big.Float).Neg(&x.hi)
+	hi := new(big.Float).Neg(&x.lo)
+
+	return z.setBounds(lo, hi, z.resultPrec(x))
+}
+
+// Add sets z to the interval sum x+y and returns z.
+func (z *Interval) Add(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	if x.IsEmpty() || y.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	lo := addRounded(&x.lo, &y.lo, prec, false)
+	hi := addRounded(&x.hi, &y.hi, prec, true)
+
+	return z.setBounds(lo, hi, prec)
+}
+
+// Sub sets z to the interval difference x-y and returns z.
+func (z *Interval) Sub(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	if x.IsEmpty() || y.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	lo := addRounded(&x.lo, new(big.Float).Neg(&y.hi), prec, false)
+	hi := addRounded(&x.hi, new(big.Float).Neg(&y.lo), prec, true)
+
+	return z.setBounds(lo, hi, prec)
+}
+
+// Mul sets z to the interval product x·y and returns z. As is usual in
+// interval arithmetic, 0·Inf is taken to be 0 for the endpoints.
+func (z *Interval) Mul(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	if x.IsEmpty() || y.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	lo, hi := corners(x, y, prec, mulRounded)
+
+	return z.setBounds(lo, hi, prec)
+}
+
+// Quo sets z to the interval quotient x/y and returns z. If y contains 0 the
+// result is [-Inf, +Inf].
+func (z *Interval) Quo(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	switch {
+	case x.IsEmpty() || y.IsEmpty():
+		return z.setEmpty(prec)
+	case y.lo.Sign() <= 0 && y.hi.Sign() >= 0:
+		return z.setEntire(prec)
+	}
+
+	lo, hi := corners(x, y, prec, quoRounded)
+
+	return z.setBounds(lo, hi, prec)
+}
+
+// Sqrt sets z to the square root of x and returns z. The negative part of x
+// is outside the domain and ignored, so Sqrt of a negative interval is
+// empty.
+func (z *Interval) Sqrt(x *Interval) *Interval {
+	prec := z.resultPrec(x)
+	if x.IsEmpty() || x.hi.Sign() < 0 {
+		return z.setEmpty(prec)
+	}
+
+	lo := newDown(prec)
+	if x.lo.Sign() > 0 {
+		lo = sqrtRounded(&x.lo, prec, false)
+	}
+
+	return z.setBounds(lo, sqrtRounded(&x.hi, prec, true), prec)
+}
+
+// Exp sets z to e^x and returns z.
+func (z *Interval) Exp(x *Interval) *Interval {
+	prec := z.resultPrec(x)
+	if x.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	work := prec + intervalGuardBits
+
+	return z.setBounds(&expEnclosure(&x.lo, work).lo, &expEnclosure(&x.hi, work).hi, prec)
+}
+
+// Log sets z to the natural logarithm of x and returns z. The negative part
+// of x is outside the domain and ignored, so Log of a negative interval is
+// empty, and the lower endpoint is -Inf if x contains 0.
+func (z *Interval) Log(x *Interval) *Interval {
+	prec := z.resultPrec(x)
+	if x.IsEmpty() || x.hi.Sign() < 0 {
+		return z.setEmpty(prec)
+	}
+
+	work := prec + intervalGuardBits
+	lo := newDown(prec).SetInf(true)
+	if x.lo.Sign() > 0 {
+		lo = &logEnclosure(&x.lo, work).lo
+	}
+
+	return z.setBounds(lo, &logEnclosure(&x.hi, work).hi, prec)
+}
+
+// Sin sets z to the sine of the radian interval x and returns z.
+func (z *Interval) Sin(x *Interval) *Interval {
+	// sin has its maximum at π/2 + 2kπ and its minimum at 3π/2 + 2kπ.
+	return z.trig(x, 1, 3, func(a *big.Float, work uint) *Interval {
+		sin, _ := sinCosEnclosure(a, work)
+
+		return sin
+	})
+}
+
+// Cos sets z to the cosine of the radian interval x and returns z.
+func (z *Interval) Cos(x *Interval) *Interval {
+	// cos has its maximum at 2kπ and its minimum at π + 2kπ.
+	return z.trig(x, 0, 2, func(a *big.Float, work uint) *Interval {
+		_, cos := sinCosEnclosure(a, work)
+
+		return cos
+	})
+}
+
+// trig sets z to f(x) for f = sin or cos, which has its maxima at the points
+// (maxQuarter + 4k)·π/2 and its minima at (minQuarter + 4k)·π/2.
+// Away from these f is monotone, so its range over x is spanned by its
+// values at the endpoints and ±1 for each extremum x might contain.
+func (z *Interval) trig(x *Interval, maxQuarter, minQuarter int64, f func(*big.Float, uint) *Interval) *Interval {
+	prec := z.resultPrec(x)
+	if x.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	lo := newDown(prec).SetInt64(-1)
+	hi := newUp(prec).SetInt64(1)
+	if x.lo.IsInf() || x.hi.IsInf() || x.Width().Cmp(big.NewFloat(2*math.Pi)) >= 0 {
+		return z.setBounds(lo, hi, prec)
+	}
+
+	work := prec + intervalGuardBits
+	a, b := f(&x.lo, work), f(&x.hi, work)
+	if !x.mayContainQuarter(minQuarter, work) {
+		lo = minFloat(&a.lo, &b.lo)
+	}
+	if !x.mayContainQuarter(maxQuarter, work) {
+		hi = maxFloat(&a.hi, &b.hi)
+	}
+
+	return z.setBounds(lo, hi, prec)
+}
+
+// Atan sets z to the arctangent, in radians, of x and returns z.
+func (z *Interval) Atan(x *Interval) *Interval {
+	prec := z.resultPrec(x)
+	if x.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	work := prec + intervalGuardBits
+
+	return z.setBounds(&atanEnclosure(&x.lo, work).lo, &atanEnclosure(&x.hi, work).hi, prec)
+}
+
+// Pow sets z to x**y and returns z.
+//
+// If y is a single integer n, the result is the range of xⁿ over x, for
+// negative x too. Otherwise the power is e^(y·log x), defined for x >= 0,
+// and the negative part of x is ignored. The range of 0**y is taken to be
+// [0, +Inf] when y contains 0 or negative values.
+func (z *Interval) Pow(x, y *Interval) *Interval {
+	prec := z.resultPrec(x, y)
+	if x.IsEmpty() || y.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	if y.lo.Cmp(&y.hi) == 0 && y.lo.IsInt() {
+		if n, acc := y.lo.Int64(); acc == big.Exact {
+			return z.powInt(x, n, prec)
+		}
+	}
+
+	switch {
+	case x.hi.Sign() < 0:
+		return z.setEmpty(prec)
+	case x.lo.Sign() > 0:
+		return z.powPositive(x, y, prec)
+	case y.lo.Sign() <= 0:
+		return z.setBounds(newDown(prec), newUp(prec).SetInf(false), prec)
+	case x.hi.Sign() == 0:
+		return z.setBounds(newDown(prec), newUp(prec), prec)
+	}
+
+	// x = [0, hi] and y > 0, where the power is largest at hi.
+	top := new(Interval).setBounds(&x.hi, &x.hi, x.Prec())
+	top.powPositive(top, y, prec)
+
+	return z.setBounds(newDown(prec), &top.hi, prec)
+}
+
+// powPositive sets z to e^(y·log x) for x > 0 and returns z. The exponent
+// is needed to prec bits after the binary point, so the working precision
+// is widened by its magnitude.
+func (z *Interval) powPositive(x, y *Interval, prec uint) *Interval {
+	work := prec + intervalGuardBits
+	for {
+		t := new(Interval).SetPrec(work).Log(x)
+		t.Mul(t, y)
+
+		extra := 0
+		if m := t.mag(); !m.IsInf() && m.Sign() != 0 {
+			extra = max(m.MantExp(nil), 0)
+		}
+		if extra <= intervalGuardBits/2 || work >= prec+intervalGuardBits+uint(extra) {
+			return z.Set(t.Exp(t).roundTo(prec))
+		}
+		work = prec + intervalGuardBits + uint(extra)
+	}
+}
+
+// powInt sets z to the range of xⁿ over x and returns z.
+func (z *Interval) powInt(x *Interval, n int64, prec uint) *Interval {
+	switch {
+	case n == 0:
+		return z.setBounds(newDown(prec).SetInt64(1), newUp(prec).SetInt64(1), prec)
+	case n < 0:
+		p := new(Interval).SetPrec(prec+intervalGuardBits).powInt(x, -n, prec+intervalGuardBits)
+		unit := new(Interval).setBounds(one, one, prec)
+
+		return z.SetPrec(prec).Quo(unit, p)
+	}
+
+	lo, hi := absRange(x)
+	if n%2 == 1 {
+		// xⁿ is increasing, and the sign of each endpoint carries through.
+		lo = powRounded(&x.lo, n, prec, false)
+		hi = powRounded(&x.hi, n, prec, true)
+
+		return z.setBounds(lo, hi, prec)
+	}
+
+	return z.setBounds(powRounded(lo, n, prec, false), powRounded(hi, n, prec, true), prec)
+}
+
+// Gamma sets z to the Gamma function of x and returns z.
+//
+// The result is [-Inf, +Inf] if x contains one of the poles 0, -1, -2, ….
+// Positive intervals use the monotonicity of Γ on either side of its minimum
+// near 1.4616 and the Stirling series, whose remainder for real arguments is
+// bounded by its first omitted term. Negative intervals use the reflection
+// formula Γ(x) = π / (sin(πx)·Γ(1-x)).
+func (z *Interval) Gamma(x *Interval) *Interval {
+	prec := z.resultPrec(x)
+	if x.IsEmpty() {
+		return z.setEmpty(prec)
+	}
+
+	work := prec + intervalGuardBits
+	if x.lo.Sign() <= 0 {
+		// The first pole at or above lo is ⌈lo⌉, and it lies in x unless x
+		// is strictly between two poles.
+		if x.lo.IsInf() || new(big.Float).Neg(&x.hi).Cmp(new(big.Float).SetInt(floorInt(new(big.Float).Neg(&x.lo)))) <= 0 {
+			return z.setEntire(prec)
+		}
+
+		pi := piEnclosure(work)
+		s := new(Interval).SetPrec(work).Mul(pi, x)
+		s.Sin(s)
+
+		y := new(Interval).setBounds(one, one, work)
+		y.Sub(y, x)
+		s.Mul(s, y.Gamma(y))
+
+		return z.Set(s.Quo(pi, s).roundTo(prec))
+	}
+
+	x0 := gammaMinimumPoint(work)
+	lo, hi := &x.lo, &x.hi
+	switch {
+	case hi.Cmp(&x0.lo) <= 0:
+		// Γ is decreasing on (0, x0].
+		lo, hi = hi, lo
+	case lo.Cmp(&x0.hi) >= 0:
+		// Γ is increasing on [x0, +Inf).
+	default:
+		upper := maxFloat(&gammaEnclosure(lo, work).hi, &gammaEnclosure(hi, work).hi)
+
+		return z.setBounds(gammaMinimum(work), upper, prec)
+	}
+
+	return z.setBounds(&gammaEnclosure(lo, work).lo, &gammaEnclosure(hi, work).hi, prec)
+}
+
+// gammaMinimumX is within 3·10⁻²¹ of the point x0 where Γ has its minimum
+// on (0, +Inf).
+const gammaMinimumX = "1.46163214496836234126"
+
+// gammaMinimumPoint returns an interval containing x0.
+func gammaMinimumPoint(work uint) *Interval {
+	x0, _ := new(Interval).SetPrec(work).SetString(gammaMinimumX)
+
+	return x0.widen(big.NewFloat(1e-20))
+}
+
+// gammaMinimum returns a lower bound on Γ(x0), the minimum of Γ on
+// (0, +Inf). It is the lower end of the enclosure of Γ at gammaMinimumX
+// less 2⁻¹³². That covers Γ(gammaMinimumX) - Γ(x0), which is about
+// (gammaMinimumX-x0)²/2 times the second derivative of Γ, and that is below 1
+// near x0.
+func gammaMinimum(work uint) *big.Float {
+	c, _ := new(big.Float).SetPrec(work).SetString(gammaMinimumX)
+	minimum := &gammaEnclosure(c, work).lo
+
+	return minimum.Sub(minimum, new(big.Float).SetMantExp(one, -132))
+}
+
+// gammaEnclosure returns an interval containing Γ(a) for a > 0, with
+// the given working precision.
+func gammaEnclosure(a *big.Float, work uint) *Interval {
+	if a.IsInf() {
+		return new(Interval).setBounds(a, a, work)
+	}
+	if a.MantExp(nil) > 30 {
+		// Γ(a) is beyond the exponent range of big.Float.
+		return new(Interval).setBounds(one, new(big.Float).SetInf(false), work)
+	}
+
+	// Shift a out to the radius of the Stirling series using
+	// Γ(a) = Γ(a+n) / (a(a+1)···(a+n-1)).
+	radius := float64(work/4 + 8)
+	af, _ := a.Float64()
+
+	// log Γ is about a·ln a, which is needed to work bits after the binary
+	// point.
+	size := max(af, radius)
+	work += uint(bits.Len64(uint64(size * math.Log(size))))
+
+	y := new(Interval).setBounds(a, a, work)
+	p := new(Interval).setBounds(one, one, work)
+	step := new(Interval).setBounds(one, one, work)
+	if af < radius {
+		for range int(math.Ceil(radius - af)) {
+			p.Mul(p, y)
+			y.Add(y, step)
+		}
+	}
+
+	g := logGammaStirlingEnclosure(y, work)
+	g.Exp(g)
+
+	return g.Quo(g, p)
+}
+
+// logGammaStirlingEnclosure returns an interval containing log Γ(x) for
+// x >= work/4 + 8, using the Stirling series
+//
+//	log Γ(x) = (x - 1/2)·log x - x + ln(2π)/2 + Σ B(2k) / (2k(2k-1)·x^(2k-1))
+//
+// For real x > 0 the error of the series stopped after any number of terms
+// is at most the first term omitted.
+func logGammaStirlingEnclosure(x *Interval, work uint) *Interval {
+	halfLog2Pi := new(Interval).SetPrec(work).scale(piEnclosure(work), 1)
+	halfLog2Pi.Log(halfLog2Pi)
+	halfLog2Pi.scale(halfLog2Pi, -1)
+
+	h := new(Interval).SetPrec(work)
+	h.SetString("1/2")
+	h.Sub(x, h)
+
+	result := new(Interval).SetPrec(work).Log(x)
+	result.Mul(result, h)
+	result.Sub(result, x)
+	result.Add(result, halfLog2Pi)
+
+	inv := new(Interval).setBounds(one, one, work)
+	inv.Quo(inv, x)
+	inv2 := new(Interval).SetPrec(work).sqr(inv)
+
+	bernoulli := bernoulliEven(int(work/4) + 16)
+	c := new(Interval).SetPrec(work)
+	term := new(Interval).SetPrec(work)
+	for k := 1; k < len(bernoulli); k++ {
+		c.setRat(new(big.Rat).Quo(bernoulli[k], big.NewRat(int64(2*k*(2*k-1)), 1)), work)
+		term.Mul(c, inv)
+		if k == len(bernoulli)-1 || term.negligible(work) {
+			// term is the first omitted.
+			return result.widen(term.mag())
+		}
+		result.Add(result, term)
+		inv.Mul(inv, inv2)
+	}
+
+	panic("unreachable")
+}
+
+// expEnclosure returns an interval containing e^a, with the given working
+// precision.
+func expEnclosure(a *big.Float, work uint) *Interval {
+	switch {
+	case a.Sign() == 0:
+		return new(Interval).setBounds(one, one, work)
+	case a.IsInf() && a.Sign() > 0:
+		return new(Interval).setBounds(a, a, work)
+	case a.IsInf():
+		return new(Interval).setBounds(zero, zero, work)
+	case a.MantExp(nil) > 30 && a.Sign() > 0:
+		// e^a is beyond the exponent range of big.Float.
+		return new(Interval).setBounds(one, new(big.Float).SetInf(false), work)
+	case a.MantExp(nil) > 30:
+		return new(Interval).setBounds(zero, one, work)
+	}
+
+	// e^a = 2^k · e^r with r = a - k·ln 2, and e^r = (e^(r/2^s))^(2^s).
+	// Each squaring doubles the relative width, so it costs a bit.
+	af, _ := a.Float64()
+	k := int64(math.Round(af / math.Ln2))
+	s := int(math.Sqrt(float64(work))) / 2
+	w := work + uint(s) + uint(bits.Len64(uint64(abs(int(k)))))
+
+	r := new(Interval).setBounds(a, a, w)
+	kLn2 := new(Interval).SetPrec(w).SetFloat(new(big.Float).SetInt64(k))
+	kLn2.Mul(kLn2, ln2Enclosure(w))
+	r.Sub(r, kLn2)
+	r.scale(r, -s)
+
+	result := expTaylorEnclosure(r, w)
+	for range s {
+		result.sqr(result)
+	}
+
+	return result.scale(result, int(k))
+}
+
+// expTaylorEnclosure returns an interval containing e^r for every r in the
+// interval r, which must lie within [-1, 1].
+func expTaylorEnclosure(r *Interval, work uint) *Interval {
+	bound := r.mag()
+	sum := new(Interval).setBounds(one, one, work)
+	term := new(Interval).setBounds(one, one, work)
+	for n := 1; ; n++ {
+		term.Mul(term, r)
+		term.quoInt(term, int64(n))
+		sum.Add(sum, term)
+
+		if term.negligible(work) {
+			return sum.widen(expTaylorRemainder(bound, n+1))
+		}
+	}
+}
+
+// logEnclosure returns an interval containing log a for a >= 0, with the
+// given working precision.
+func logEnclosure(a *big.Float, work uint) *Interval {
+	switch {
+	case a.Sign() == 0:
+		inf := new(big.Float).SetInf(true)
+
+		return new(Interval).setBounds(inf, inf, work)
+	case a.IsInf():
+		return new(Interval).setBounds(a, a, work)
+	}
+
+	// log a = log m + e·ln 2 with m in [1/√2, √2), and
+	// log m = 2·artanh((m-1)/(m+1)) where |(m-1)/(m+1)| < 0.18.
+	m := new(big.Float)
+	e := a.MantExp(m)
+	if m.Cmp(big.NewFloat(math.Sqrt2/2)) < 0 {
+		m.SetMantExp(m, 1)
+		e--
+	}
+
+	w := work + uint(bits.Len(uint(abs(e))))
+	t := new(Interval).setBounds(m, m, w)
+	d := new(Interval).setBounds(m, m, w)
+	step := new(Interval).setBounds(one, one, w)
+	t.Sub(t, step)
+	d.Add(d, step)
+	t.Quo(t, d)
+
+	result := atanhTaylorEnclosure(t, w)
+	result.scale(result, 1)
+
+	eLn2 := new(Interval).SetPrec(w).SetFloat(new(big.Float).SetInt64(int64(e)))
+	eLn2.Mul(eLn2, ln2Enclosure(w))
+
+	return result.Add(result, eLn2)
+}
+
+// atanhTaylorEnclosure returns an interval containing artanh t for every t
+// in the interval t, which must lie within (-1, 1).
+func atanhTaylorEnclosure(t *Interval, work uint) *Interval {
+	bound := t.mag()
+	t2 := new(Interval).SetPrec(work).sqr(t)
+	power := new(Interval).SetPrec(work).Set(t)
+	sum := new(Interval).SetPrec(work).Set(t)
+	term := new(Interval).SetPrec(work)
+	for n := int64(1); ; n++ {
+		power.Mul(power, t2)
+		term.quoInt(power, 2*n+1)
+		sum.Add(sum, term)
+
+		if term.negligible(work) {
+			return sum.widen(atanhTaylorRemainder(bound, int(n+1)))
+		}
+	}
+}
+
+// atanEnclosure returns an interval containing arctan a, with the given
+// working precision.
+func atanEnclosure(a *big.Float, work uint) *Interval {
+	switch {
+	case a.Sign() == 0:
+		return new(Interval).setBounds(zero, zero, work)
+	case a.IsInf():
+		result := new(Interval).SetPrec(work).scale(piEnclosure(work), -1)
+		if a.Sign() < 0 {
+			result.Neg(result)
+		}
+
+		return result
+	}
+
+	t := new(Interval).SetPrec(work).SetFloat(new(big.Float).Abs(a))
+	step := new(Interval).setBounds(one, one, work)
+
+	// For |a| > 1, use the identity arctan(a) = π/2 - arctan(1/a).
+	invert := t.lo.Cmp(one) > 0
+	if invert {
+		t.Quo(step, t)
+	}
+
+	// Halve the argument with arctan(t) = 2·arctan(t / (1 + √(1 + t²))),
+	// as Atan does.
+	halvings := 0
+	s := new(Interval).SetPrec(work)
+	for t.hi.MantExp(nil) > atanReduceExp {
+		s.sqr(t)
+		s.Add(s, step)
+		s.Sqrt(s)
+		s.Add(s, step)
+		t.Quo(t, s)
+		halvings++
+	}
+
+	result := atanTaylorEnclosure(t, work)
+	result.scale(result, halvings)
+
+	if invert {
+		halfPi := new(Interval).SetPrec(work).scale(piEnclosure(work), -1)
+		result.Sub(halfPi, result)
+	}
+
+	if a.Sign() < 0 {
+		result.Neg(result)
+	}
+
+	return result
+}
+
+// atanTaylorEnclosure returns an interval containing arctan t for every t in
+// the interval t, which must lie within [-1, 1].
+func atanTaylorEnclosure(t *Interval, work uint) *Interval {
+	bound := t.mag()
+	t2 := new(Interval).SetPrec(work).sqr(t)
+	power := new(Interval).SetPrec(work).Set(t)
+	sum := new(Interval).SetPrec(work).Set(t)
+	term := new(Interval).SetPrec(work)
+	for n := int64(1); ; n++ {
+		power.Mul(power, t2)
+		term.quoInt(power, 2*n+1)
+		if n%2 == 1 {
+			sum.Sub(sum, term)
+		} else {
+			sum.Add(sum, term)
+		}
+
+		if term.negligible(work) {
+			return sum.widen(atanTaylorRemainder(bound, int(n+1)))
+		}
+	}
+}
+
+// sinCosEnclosure returns intervals containing sin a and cos a, with the
+// given working precision.
+func sinCosEnclosure(a *big.Float, work uint) (sin, cos *Interval) {
+	if a.Sign() == 0 {
+		return new(Interval).setBounds(zero, zero, work), new(Interval).setBounds(one, one, work)
+	}
+
+	// a = k·π/2 + r with |r| <= π/4, computed with as many extra bits as
+	// k has.
+	w := work + uint(max(a.MantExp(nil), 0))
+	halfPi := new(Interval).SetPrec(w).scale(piEnclosure(w), -1)
+	q := new(big.Float).SetPrec(w).Quo(a, halfPi.Mid())
+	k := floorInt(q.Add(q, big.NewFloat(0.5)))
+
+	r := new(Interval).setBounds(a, a, w)
+	kHalfPi := new(Interval).SetPrec(w).SetFloat(new(big.Float).SetInt(k))
+	r.Sub(r, kHalfPi.Mul(kHalfPi, halfPi))
+
+	sin, cos = sinCosTaylorEnclosure(r, w)
+	switch new(big.Int).Mod(k, big.NewInt(4)).Int64() {
+	case 1:
+		sin, cos = cos, sin.Neg(sin)
+	case 2:
+		sin, cos = sin.Neg(sin), cos.Neg(cos)
+	case 3:
+		sin, cos = cos.Neg(cos), sin
+	}
+
+	return sin.clampUnit(), cos.clampUnit()
+}
+
+// sinCosTaylorEnclosure returns intervals containing sin r and cos r for
+// every r in the interval r, whose Taylor terms rⁿ/n! are added in turn to
+// the series they belong to.
+func sinCosTaylorEnclosure(r *Interval, work uint) (sin, cos *Interval) {
+	bound := r.mag()
+	sin = new(Interval).SetPrec(work).Set(r)
+	cos = new(Interval).setBounds(one, one, work)
+	term := new(Interval).SetPrec(work).Set(r)
+	for n := int64(2); ; n++ {
+		term.Mul(term, r)
+		term.quoInt(term, n)
+		switch n % 4 {
+		case 0:
+			cos.Add(cos, term)
+		case 1:
+			sin.Add(sin, term)
+		case 2:
+			cos.Sub(cos, term)
+		case 3:
+			sin.Sub(sin, term)
+		}
+
+		// The series that ended with this term omits terms from degree n+2
+		// and the other from n+1, and the bound for n+1 covers both.
+		if term.negligible(work) && bound.Cmp(new(big.Float).SetInt64(n)) < 0 {
+			rem := sinTaylorRemainder(bound, int(n+1))
+
+			return sin.widen(rem), cos.widen(rem)
+		}
+	}
+}
+
+// mayContainQuarter reports whether x might contain a point (q + 4k)·π/2 for
+// an integer k. It can only err by reporting true.
+func (x *Interval) mayContainQuarter(q int64, work uint) bool {
+	w := work + uint(max(x.lo.MantExp(nil), x.hi.MantExp(nil), 0))
+	halfPi := new(Interval).SetPrec(w).scale(piEnclosure(w), -1)
+
+	// The quarter turns spanned by x lie within [u, v].
+	u := new(Interval).setBounds(&x.lo, &x.lo, w)
+	u.Quo(u, halfPi)
+	v := new(Interval).setBounds(&x.hi, &x.hi, w)
+	v.Quo(v, halfPi)
+
+	// Some q + 4k lies in [u, v] if ⌈(u-q)/4⌉ <= ⌊(v-q)/4⌋.
+	qf := new(big.Float).SetInt64(q)
+	first := newDown(w).Sub(&u.lo, qf)
+	first.Neg(first.Quo(first, four))
+	last := newUp(w).Sub(&v.hi, qf)
+	last.Quo(last, four)
+
+	return new(big.Int).Neg(floorInt(first)).Cmp(floorInt(last)) <= 0
+}
+
+// intervalConstants caches the enclosures of π and ln 2 at the largest
+// precision computed so far. Lower precisions are rounded outward from them.
+var intervalConstants struct {
+	sync.Mutex
+	pi, ln2 *Interval
+}
+
+// piEnclosure returns an interval of the given precision containing π.
+func piEnclosure(prec uint) *Interval {
+	return cachedEnclosure(&intervalConstants.pi, prec, func(work uint) *Interval {
+		// Machin's formula π = 16·arctan(1/5) - 4·arctan(1/239).
+		t := new(Interval).SetPrec(work)
+		t.SetString("1/5")
+		a := atanTaylorEnclosure(t, work)
+		a.scale(a, 4)
+
+		t.SetString("1/239")
+		b := atanTaylorEnclosure(t, work)
+		b.scale(b, 2)
+
+		return a.Sub(a, b)
+	})
+}
+
+// ln2Enclosure returns an interval of the given precision containing ln 2.
+func ln2Enclosure(prec uint) *Interval {
+	return cachedEnclosure(&intervalConstants.ln2, prec, func(work uint) *Interval {
+		// ln 2 = 2·artanh(1/3)
+		t := new(Interval).SetPrec(work)
+		t.SetString("1/3")
+		result := atanhTaylorEnclosure(t, work)
+
+		return result.scale(result, 1)
+	})
+}
+
+// cachedEnclosure returns *c rounded outward to prec, first replacing it by
+// compute(prec) if it has fewer bits. The multiples in the formulas above
+// lose a few bits, so they are computed with some to spare.
+func cachedEnclosure(c **Interval, prec uint, compute func(work uint) *Interval) *Interval {
+	intervalConstants.Lock()
+	defer intervalConstants.Unlock()
+
+	if *c == nil || (*c).Prec() < prec {
+		*c = compute(prec + 16).roundTo(prec)
+	}
+
+	return new(Interval).SetPrec(prec).Set(*c)
+}
+
+// resultPrec returns the precision of z, or if it is 0 the largest of the
+// precisions of the operands.
+func (z *Interval) resultPrec(operands ...*Interval) uint {
+	if prec := z.Prec(); prec != 0 {
+		return prec
+	}
+
+	var prec uint
+	for _, x := range operands {
+		prec = max(prec, x.Prec())
+	}
+
+	return prec
+}
+
+// setBounds sets z to [lo, hi] rounded outward to prec and returns z. hi
+// must not be the lower endpoint of z.
+func (z *Interval) setBounds(lo, hi *big.Float, prec uint) *Interval {
+	z.lo.SetMode(big.ToNegativeInf).SetPrec(prec).Set(lo)
+	z.hi.SetMode(big.ToPositiveInf).SetPrec(prec).Set(hi)
+
+	return z
+}
+
+// setRat sets z to the smallest interval of precision prec containing r and
+// returns z.
+func (z *Interval) setRat(r *big.Rat, prec uint) *Interval {
+	z.SetPrec(prec)
+	z.lo.SetRat(r)
+	z.hi.SetRat(r)
+
+	return z
+}
+
+// setEmpty sets z to the empty interval [+Inf, -Inf] and returns z.
+func (z *Interval) setEmpty(prec uint) *Interval {
+	z.SetPrec(prec)
+	z.lo.SetInf(false)
+	z.hi.SetInf(true)
+
+	return z
+}
+
+// setEntire sets z to [-Inf, +Inf] and returns z.
+func (z *Interval) setEntire(prec uint) *Interval {
+	z.SetPrec(prec)
+	z.lo.SetInf(true)
+	z.hi.SetInf(false)
+
+	return z
+}
+
+// roundTo rounds x outward to prec and returns x.
+func (x *Interval) roundTo(prec uint) *Interval {
+	return x.SetPrec(prec)
+}
+
+// scale sets z to x·2ⁿ, which is exact unless it leaves the exponent range,
+// and returns z.
+func (z *Interval) scale(x *Interval, n int) *Interval {
+	lo := new(big.Float).SetMantExp(&x.lo, n)
+	hi := new(big.Float).SetMantExp(&x.hi, n)
+
+	return z.setBounds(lo, hi, z.resultPrec(x))
+}
+
+// quoInt sets z to x/n for n > 0 and returns z.
+func (z *Interval) quoInt(x *Interval, n int64) *Interval {
+	prec := z.resultPrec(x)
+	d := new(big.Float).SetInt64(n)
+	lo := newDown(prec).Quo(&x.lo, d)
+	hi := newUp(prec).Quo(&x.hi, d)
+
+	return z.setBounds(lo, hi, prec)
+}
+
+// sqr sets z to the range of x² over x, which unlike x·x is never negative,
+// and returns z.
+func (z *Interval) sqr(x *Interval) *Interval {
+	prec := z.resultPrec(x)
+	lo, hi := absRange(x)
+
+	return z.setBounds(mulRounded(lo, lo, prec, false), mulRounded(hi, hi, prec, true), prec)
+}
+
+// widen sets x to [lo - d, hi + d] for d >= 0 and returns x.
+func (x *Interval) widen(d *big.Float) *Interval {
+	x.lo.Sub(&x.lo, d)
+	x.hi.Add(&x.hi, d)
+
+	return x
+}
+
+// clampUnit intersects x with [-1, 1] and returns x.
+func (x *Interval) clampUnit() *Interval {
+	if x.lo.Cmp(big.NewFloat(-1)) < 0 {
+		x.lo.SetInt64(-1)
+	}
+	if x.hi.Cmp(one) > 0 {
+		x.hi.SetInt64(1)
+	}
+
+	return x
+}
+
+// mag returns the largest magnitude of the points of x.
+func (x *Interval) mag() *big.Float {
+	_, hi := absRange(x)
+
+	return hi
+}
+
+// negligible reports whether every point of x is less than 2^-work in
+// magnitude.
+func (x *Interval) negligible(work uint) bool {
+	m := x.mag()
+
+	return m.Sign() == 0 || m.MantExp(nil) < -int(work)
+}
+
+// absRange returns the smallest and largest magnitudes of the points of x.
+func absRange(x *Interval) (lo, hi *big.Float) {
+	a := new(big.Float).Abs(&x.lo)
+	b := new(big.Float).Abs(&x.hi)
+	switch {
+	case x.lo.Sign() >= 0:
+		return a, b
+	case x.hi.Sign() <= 0:
+		return b, a
+	}
+
+	return new(big.Float), maxFloat(a, b)
+}
+
+// corners returns the smallest and largest of op applied to the endpoints of
+// x and y, rounded down and up respectively, which bound op over x and y
+// for op = · or /.
+func corners(x, y *Interval, prec uint, op func(x, y *big.Float, prec uint, up bool) *big.Float) (lo, hi *big.Float) {
+	for _, a := range []*big.Float{&x.lo, &x.hi} {
+		for _, b := range []*big.Float{&y.lo, &y.hi} {
+			if d := op(a, b, prec, false); lo == nil || d.Cmp(lo) < 0 {
+				lo = d
+			}
+			if u := op(a, b, prec, true); hi == nil || u.Cmp(hi) > 0 {
+				hi = u
+			}
+		}
+	}
+
+	return lo, hi
+}
+
+// newDown returns a new zero of the given precision that rounds toward -Inf.
+func newDown(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).SetMode(big.ToNegativeInf)
+}
+
+// newUp returns a new zero of the given precision that rounds toward +Inf.
+func newUp(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).SetMode(big.ToPositiveInf)
+}
+
+// newRounded returns newUp(prec) if up is true and newDown(prec) otherwise.
+func newRounded(prec uint, up bool) *big.Float {
+	if up {
+		return newUp(prec)
+	}
+
+	return newDown(prec)
+}
+
+// addRounded returns x+y rounded up or down. The sum of opposite infinities
+// is taken to be the infinity in the direction of rounding.
+func addRounded(x, y *big.Float, prec uint, up bool) *big.Float {
+	z := newRounded(prec, up)
+	if x.IsInf() && y.IsInf() && x.Signbit() != y.Signbit() {
+		return z.SetInf(!up)
+	}
+
+	return z.Add(x, y)
+}
+
+// mulRounded returns x·y rounded up or down, taking 0·Inf to be 0.
+func mulRounded(x, y *big.Float, prec uint, up bool) *big.Float {
+	z := newRounded(prec, up)
+	if x.Sign() == 0 || y.Sign() == 0 {
+		return z
+	}
+
+	return z.Mul(x, y)
+}
+
+// quoRounded returns x/y for y != 0 rounded up or down. The quotient of two
+// infinities is taken to be the infinity in the direction of rounding.
+func quoRounded(x, y *big.Float, prec uint, up bool) *big.Float {
+	z := newRounded(prec, up)
+	if x.IsInf() && y.IsInf() {
+		return z.SetInf(!up)
+	}
+
+	return z.Quo(x, y)
+}
+
+// sqrtRounded returns √x for x >= 0 rounded up or down. big.Float.Sqrt does
+// not promise a correctly rounded result, so it is checked against the exact
+// square and moved by an ulp until it is on the correct side.
+func sqrtRounded(x *big.Float, prec uint, up bool) *big.Float {
+	z := newRounded(prec, up)
+	if x.Sign() == 0 || x.IsInf() {
+		return z.Set(x)
+	}
+
+	z.Sqrt(x)
+	square := new(big.Float)
+	for {
+		square.SetPrec(2*z.Prec()).Mul(z, z)
+		c := square.Cmp(x)
+		if c == 0 || (c > 0) == up {
+			return z
+		}
+
+		ulp := new(big.Float).SetMantExp(one, z.MantExp(nil)-int(z.Prec()))
+		if up {
+			z.Add(z, ulp)
+		} else {
+			z.Sub(z, ulp)
+		}
+	}
+}
+
+// powRounded returns aⁿ for n > 0 rounded up or down, using repeated
+// squaring of |a| in the direction that makes the result rounded the right
+// way after its sign is applied.
+func powRounded(a *big.Float, n int64, prec uint, up bool) *big.Float {
+	negative := a.Sign() < 0 && n%2 == 1
+	result := powDirected(new(big.Float).Abs(a), n, prec, up != negative)
+	if negative {
+		result.Neg(result)
+	}
+
+	return result
+}
+
+// powDirected returns aⁿ for a >= 0 and n >= 0, rounded up or down. Every
+// intermediate value is non-negative, so rounding each product the same way
+// rounds the result that way.
+func powDirected(a *big.Float, n int64, prec uint, up bool) *big.Float {
+	result := newRounded(prec, up).SetInt64(1)
+	base := newRounded(prec, up).Set(a)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = mulRounded(result, base, prec, up)
+		}
+		if n > 1 {
+			base = mulRounded(base, base, prec, up)
+		}
+	}
+
+	return result
+}
+
+// powUp returns aⁿ for a >= 0, rounded up.
+func powUp(a *big.Float, n int, prec uint) *big.Float {
+	return powDirected(a, int64(n), prec, true)
+}
+
+// factorialDown returns n! rounded down.
+func factorialDown(n int, prec uint) *big.Float {
+	return newDown(prec).SetInt(new(big.Int).MulRange(1, int64(n)))
+}
+
+// minFloat returns the smaller of x and y.
+func minFloat(x, y *big.Float) *big.Float {
+	if x.Cmp(y) <= 0 {
+		return x
+	}
+
+	return y
+}
+
+// maxFloat returns the larger of x and y.
+func maxFloat(x, y *big.Float) *big.Float {
+	if x.Cmp(y) >= 0 {
+		return x
+	}
+
+	return y
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+var intervalFunctions = []struct {
+	name     string
+	interval func(z, x *Interval) *Interval
+	complex  func(z, x *Complex) *Complex
+}{
+	{"Sqrt", (*Interval).Sqrt, (*Complex).Sqrt},
+	{"Exp", (*Interval).Exp, (*Complex).Exp},
+	{"Log", (*Interval).Log, (*Complex).Log},
+	{"Sin", (*Interval).Sin, (*Complex).Sin},
+	{"Cos", (*Interval).Cos, (*Complex).Cos},
+	{"Atan", (*Interval).Atan, (*Complex).Atan},
+	{"Gamma", (*Interval).Gamma, (*Complex).Gamma},
+}
+
+// intervalTestValues are in the domain of every function in
+// intervalFunctions, and are not exactly representable in binary.
+var intervalTestValues = []string{
+	"0.1", "1/3", "1.4616321449683623", "2.5", "7.3", "31.7", "1e-30", "1000.1", "-2.7", "-0.3",
+}
+
+// checkEnclosure reports an error unless got contains want and is no wider
+// than 2^-bits relative to |want|.
+func checkEnclosure(t *testing.T, name string, got *Interval, want *big.Float, bits uint) {
+	t.Helper()
+
+	if !got.Contains(want) {
+		t.Errorf("%s = %v does not contain %s", name, got, want.Text('g', 20))
+		return
+	}
+
+	width := got.Width()
+	if width.Sign() == 0 {
+		return
+	}
+	if w := width.MantExp(nil) - want.MantExp(nil); w > -int(bits) {
+		t.Errorf("%s = %v has relative width 2^%d, want at most 2^-%d", name, got, w, bits)
+	}
+}
+
+func TestIntervalFunctions(t *testing.T) {
+	for _, fn := range intervalFunctions {
+		for _, prec := range []uint{53, 256, 1000} {
+			for _, s := range intervalTestValues {
+				if s[0] == '-' && (fn.name == "Sqrt" || fn.name == "Log") {
+					continue
+				}
+
+				t.Run(fmt.Sprintf("%s(%s)/precision_%d", fn.name, s, prec), func(t *testing.T) {
+					x, _ := new(Interval).SetPrec(prec).SetString(s)
+					got := fn.interval(new(Interval), x)
+					if got.Prec() != prec {
+						t.Errorf("%s(%s) has precision %d, want %d", fn.name, s, got.Prec(), prec)
+					}
+
+					// Every point of x maps inside the result, and the
+					// width is close to that of x.
+					for _, v := range []*big.Float{x.Lower(), x.Upper()} {
+						c := NewComplex(v, new(big.Float).SetPrec(prec))
+						want := fn.complex(new(Complex).SetPrec(prec+128), c).Real()
+						checkEnclosure(t, fmt.Sprintf("%s(%s)", fn.name, s), got, want, prec-16)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestIntervalConstants(t *testing.T) {
+	for _, prec := range []uint{64, 300, 2000} {
+		pi := ComputePi(prec + 64)
+		checkEnclosure(t, "π", piEnclosure(prec), pi, prec-2)
+
+		quarter := new(Interval).SetPrec(prec)
+		quarter.SetString("1")
+		quarter.Atan(quarter)
+		checkEnclosure(t, "atan(1)", quarter, new(big.Float).Quo(pi, four), prec-2)
+
+		two := new(big.Float).SetPrec(prec + 64).SetInt64(2)
+		checkEnclosure(t, "ln 2", ln2Enclosure(prec), Log(two), prec-2)
+
+		e := new(Interval).SetPrec(prec)
+		e.SetString("1")
+		e.Exp(e)
+		checkEnclosure(t, "e", e, Exp(new(big.Float).SetPrec(prec+64).SetInt64(1)), prec-2)
+	}
+}
+
+func TestIntervalArithmetic(t *testing.T) {
+	const prec = 100
+	x, _ := new(Interval).SetPrec(prec).SetString("0.1")
+	y, _ := new(Interval).SetPrec(prec).SetString("-7/3")
+
+	exact := func(s string) *big.Float {
+		r, _ := new(big.Rat).SetString(s)
+		return new(big.Float).SetPrec(1000).SetRat(r)
+	}
+	tests := []struct {
+		op   string
+		got  *Interval
+		want string
+	}{
+		{"+", new(Interval).Add(x, y), "-67/30"},
+		{"-", new(Interval).Sub(x, y), "73/30"},
+		{"·", new(Interval).Mul(x, y), "-7/30"},
+		{"/", new(Interval).Quo(x, y), "-3/70"},
+		{"**", new(Interval).Pow(y, NewInterval(big.NewFloat(3), big.NewFloat(3))), "-343/27"},
+		{"**", new(Interval).Pow(y, NewInterval(big.NewFloat(-2), big.NewFloat(-2))), "9/49"},
+	}
+	for _, test := range tests {
+		checkEnclosure(t, "0.1 "+test.op+" -7/3", test.got, exact(test.want), prec-4)
+	}
+
+	if got := new(Interval).Mul(x, x); !got.Contains(exact("1/100")) {
+		t.Errorf("0.1·0.1 = %v does not contain 0.01", got)
+	}
+}
+
+func TestIntervalRanges(t *testing.T) {
+	negInf, posInf := math.Inf(-1), math.Inf(1)
+	interval := func(lo, hi float64) *Interval {
+		return NewInterval(big.NewFloat(lo), big.NewFloat(hi))
+	}
+	isPoint := func(x *big.Float, want float64) bool {
+		return x.Cmp(big.NewFloat(want)) == 0
+	}
+
+	// The extrema inside the interval are reached exactly.
+	if got := new(Interval).Sin(interval(1, 2)); !isPoint(got.Upper(), 1) || got.Lower().Cmp(big.NewFloat(0.84)) < 0 {
+		t.Errorf("Sin([1, 2]) = %v", got)
+	}
+	if got := new(Interval).Cos(interval(3, 3.3)); !isPoint(got.Lower(), -1) {
+		t.Errorf("Cos([3, 3.3]) = %v", got)
+	}
+	if got := new(Interval).Sin(interval(-100, 100)); !isPoint(got.Lower(), -1) || !isPoint(got.Upper(), 1) {
+		t.Errorf("Sin([-100, 100]) = %v", got)
+	}
+	if got := new(Interval).Cos(interval(0.1, 3)); isPoint(got.Upper(), 1) || isPoint(got.Lower(), -1) {
+		t.Errorf("Cos([0.1, 3]) = %v contains an extremum", got)
+	}
+
+	// Γ has its minimum 0.8856031944… inside [1, 2].
+	if got := new(Interval).Gamma(interval(1, 2)); !got.Contains(big.NewFloat(0.8856031944108887)) || got.Upper().Cmp(big.NewFloat(1.0000001)) > 0 {
+		t.Errorf("Gamma([1, 2]) = %v", got)
+	}
+	if got := new(Interval).Gamma(interval(0.5, 1)); !got.Contains(big.NewFloat(1)) || !got.Contains(big.NewFloat(1.7724538509055159)) {
+		t.Errorf("Gamma([0.5, 1]) = %v", got)
+	}
+	if got := new(Interval).Gamma(interval(-1.5, -1.25)); !got.Contains(big.NewFloat(2.3632718012073548)) || got.Lower().Sign() <= 0 {
+		t.Errorf("Gamma([-1.5, -1.25]) = %v", got)
+	}
+
+	tests := []struct {
+		name   string
+		got    *Interval
+		lo, hi float64
+	}{
+		{"Sqrt([-1, 4])", new(Interval).Sqrt(interval(-1, 4)), 0, 2},
+		{"Pow([-2, 3], 2)", new(Interval).Pow(interval(-2, 3), interval(2, 2)), 0, 9},
+		{"Pow([-2, -1], 3)", new(Interval).Pow(interval(-2, -1), interval(3, 3)), -8, -1},
+		{"Pow([0, 4], 0.5)", new(Interval).Pow(interval(0, 4), interval(0.5, 0.5)), 0, 2},
+		{"Exp([-Inf, 0])", new(Interval).Exp(interval(negInf, 0)), 0, 1},
+		{"Atan([-Inf, +Inf])", new(Interval).Atan(interval(negInf, posInf)), -1.5707963267948968, 1.5707963267948968},
+		{"Gamma([-0.5, 0.5])", new(Interval).Gamma(interval(-0.5, 0.5)), negInf, posInf},
+		{"Gamma([-3.5, -2.5])", new(Interval).Gamma(interval(-3.5, -2.5)), negInf, posInf},
+		{"Quo([1, 2], [-1, 1])", new(Interval).Quo(interval(1, 2), interval(-1, 1)), negInf, posInf},
+		{"Log([0, 1])", new(Interval).Log(interval(0, 1)), negInf, 0},
+	}
+	for _, test := range tests {
+		lo, _ := test.got.Lower().Float64()
+		hi, _ := test.got.Upper().Float64()
+		if lo > test.lo || hi < test.hi || lo < test.lo-1e-15 || hi > test.hi+1e-15 {
+			t.Errorf("%s = %v, want [%g, %g]", test.name, test.got, test.lo, test.hi)
+		}
+	}
+
+	// Functions outside their domain, and of the empty set, are empty.
+	empty := new(Interval).Log(interval(-2, -1))
+	for name, got := range map[string]*Interval{
+		"Log([-2, -1])":        empty,
+		"Sqrt([-2, -1])":       new(Interval).Sqrt(interval(-2, -1)),
+		"Pow([-2, -1], 0.5)":   new(Interval).Pow(interval(-2, -1), interval(0.5, 0.5)),
+		"Exp(empty)":           new(Interval).Exp(empty),
+		"Add(empty, [1, 2])":   new(Interval).Add(empty, interval(1, 2)),
+		"NewInterval(2, 1)":    interval(2, 1),
+		"Gamma(Log([-2, -1]))": new(Interval).Gamma(empty),
+	} {
+		if !got.IsEmpty() {
+			t.Errorf("%s = %v, want empty", name, got)
+		}
+	}
+}
+
+func TestIntervalSetString(t *testing.T) {
+	x, ok := new(Interval).SetString("0.1")
+	if !ok || x.Prec() != 64 {
+		t.Fatalf("SetString(0.1) = %v, %v", x, ok)
+	}
+	if x.Lower().Cmp(x.Upper()) >= 0 || x.Lower().Sign() <= 0 {
+		t.Errorf("SetString(0.1) = %v is not a tight enclosure", x)
+	}
+	if x, _ := new(Interval).SetString("0.5"); x.Width().Sign() != 0 {
+		t.Errorf("SetString(0.5) = %v, want a point", x)
+	}
+	if _, ok := new(Interval).SetString("x"); ok {
+		t.Error("SetString(x) succeeded")
+	}
+	if got, want := x.String(), "[0.1, 0.1]"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func BenchmarkInterval(b *testing.B) {
+	for _, fn := range intervalFunctions {
+		for _, prec := range []uint{53, 256, 1000} {
+			b.Run(fmt.Sprintf("%s/precision_%d", fn.name, prec), func(b *testing.B) {
+				x, _ := new(Interval).SetPrec(prec).SetString("2.5")
+				z := new(Interval)
+				for b.Loop() {
+					fn.interval(z.SetPrec(0), x)
+				}
+			})
+		}
+	}
+}
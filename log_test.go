@@ -15,6 +15,7 @@
 package bigmath
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"math/big"
@@ -25,9 +26,15 @@ import (
 // over the different logarithm methods.
 var logMethods = []benchAndCompare{
 	{"Log", Log, math.Log},
-	{"LogHalley", logHalley, math.Log}, // Halley's method
-	{"LogNewton", logNewton, math.Log}, // Newton's method
-	{"LogTaylor", logTaylor, math.Log}, // Taylor series
+	{"LogHalley", logHalley, math.Log},     // Halley's method
+	{"LogNewton", logNewton, math.Log},     // Newton's method
+	{"LogTaylor", logTaylor, math.Log},     // Taylor series
+	{"LogAGM", logAGMBackground, math.Log}, // Arithmetic-geometric mean
+}
+
+// logAGMBackground is logAGM with a context that is never done.
+func logAGMBackground(x *big.Float) *big.Float {
+	return logAGM(context.Background(), x)
 }
 
 // This is a limited set of test cases since the better cases are tested in
@@ -58,21 +65,15 @@ func TestLog(t *testing.T) {
 }
 
 func TestLogEdgeCases(t *testing.T) {
-	// Test zero - should panic
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Log(0) should panic")
-		}
-	}()
-	Log(big.NewFloat(0))
+	// Log(0) = -Inf, like math.Log.
+	if result := Log(big.NewFloat(0)); !result.IsInf() || !result.Signbit() {
+		t.Errorf("Log(0) = %v, expected -Inf", result)
+	}
 
-	// Test negative number - should panic
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("Log(-1) should panic")
-		}
-	}()
-	Log(big.NewFloat(-1))
+	// Log(-1) = NaN, returned as +Inf.
+	if result := Log(big.NewFloat(-1)); !result.IsInf() || result.Signbit() {
+		t.Errorf("Log(-1) = %v, expected +Inf", result)
+	}
 }
 
 // Helper function to test a logarithm method with standard test cases
@@ -125,6 +126,53 @@ func TestLogHalley(t *testing.T) {
 	testLogMethod(t, "logHalley", logHalley)
 }
 
+func TestLogAGM(t *testing.T) {
+	testLogMethod(t, "logAGM", logAGMBackground)
+}
+
+func TestLogAGMHighPrecision(t *testing.T) {
+	for _, prec := range []uint{64, 200, 1000, 5000, 20000} {
+		t.Run(fmt.Sprintf("prec_%d", prec), func(t *testing.T) {
+			x := new(big.Float).SetPrec(prec).SetInt64(2)
+			got := logAGM(context.Background(), x)
+
+			// ln(2) by binary splitting, to more bits than are tested.
+			expected := ComputeLn2(prec + 64)
+			diff := new(big.Float).Sub(got, expected)
+			if diff.Sign() != 0 && diff.MantExp(nil) > -int(prec)+2 {
+				t.Errorf("logAGM(2) at %d bits = %s, want %s", prec, got.Text('g', 60), expected.Text('g', 60))
+			}
+
+			// log(x·y) = log(x) + log(y) to the full precision.
+			y := new(big.Float).SetPrec(prec).SetFloat64(0.1)
+			xy := new(big.Float).SetPrec(prec).Mul(x, y)
+			sum := new(big.Float).SetPrec(prec).Add(got, logAGM(context.Background(), y))
+			diff.Sub(logAGM(context.Background(), xy), sum)
+			if diff.Sign() != 0 && diff.MantExp(nil) > -int(prec)+4 {
+				t.Errorf("logAGM(0.2) - (logAGM(2) + logAGM(0.1)) = %s at %d bits", diff.Text('g', 5), prec)
+			}
+		})
+	}
+}
+
+func TestLogAGMNearOne(t *testing.T) {
+	// log(1+ε) ≈ ε - ε²/2 must keep full relative precision.
+	const prec = 500
+	eps := new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(1), -300)
+	x := new(big.Float).SetPrec(prec).Add(big.NewFloat(1), eps)
+
+	expected := new(big.Float).SetPrec(prec).Mul(eps, eps)
+	expected.Quo(expected, big.NewFloat(2))
+	expected.Sub(eps, expected)
+
+	got := logAGM(context.Background(), x)
+	relErr := new(big.Float).Sub(got, expected)
+	relErr.Quo(relErr, expected)
+	if relErr.Sign() != 0 && relErr.MantExp(nil) > -prec+4 {
+		t.Errorf("logAGM(1+2^-300) relative error %s", relErr.Text('g', 5))
+	}
+}
+
 func TestLogTaylorEdgeCases(t *testing.T) {
 	// Test zero - should panic
 	defer func() {
@@ -376,6 +424,18 @@ func BenchmarkLogHalley(b *testing.B) {
 	benchmarkLogMethod(b, "LogHalley", logHalley)
 }
 
+// Benchmarks for logAGM function
+func BenchmarkLogAGM(b *testing.B) {
+	benchmarkLogMethod(b, "LogAGM", logAGMBackground)
+}
+
+// Benchmarks for logAGM against the Newton and Halley methods across precisions.
+func BenchmarkLogPrecision(b *testing.B) {
+	for _, lm := range logMethods {
+		benchmarkBigmathFunctionVsPrecision(b, lm, big.NewFloat(10))
+	}
+}
+
 // Comparative benchmarks between all three logarithm methods
 func BenchmarkLogMethodsComparative(b *testing.B) {
 	testValues := []struct {
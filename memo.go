@@ -0,0 +1,150 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"container/list"
+	"math/big"
+	"sync"
+)
+
+// A Memo remembers the results of a function for the arguments it was
+// called with most recently, so that calling it again with one of them
+// costs a copy rather than an evaluation. It is safe for concurrent use.
+//
+// Arguments are the same when they have the same precision, rounding mode
+// and value, bit for bit, as the results of the functions of the package
+// depend on nothing else; -0 and +0 are different arguments. Each result
+// returned is a new copy, as with Pi and E, so callers may change it
+// without affecting the Memo.
+type Memo struct {
+	fn   func(x *big.Float) *big.Float
+	size int
+
+	mu      sync.Mutex
+	entries map[memoKey]*list.Element
+	lru     list.List // of *memoEntry, the most recently used first
+	stats   MemoStats
+}
+
+// A memoKey identifies an argument exactly.
+type memoKey struct {
+	prec  uint
+	mode  big.RoundingMode
+	value string // as formatted by x.Text('p', 0)
+}
+
+// A memoEntry is a remembered result.
+type memoEntry struct {
+	key    memoKey
+	result *big.Float
+}
+
+// MemoStats counts the calls of a Memo.
+type MemoStats struct {
+	Hits      uint64 // calls answered from the Memo
+	Misses    uint64 // calls that evaluated the function
+	Evictions uint64 // results dropped to make room for others
+	Len       int    // results held
+	Size      int    // results that can be held
+}
+
+// NewMemo returns a Memo of fn that holds the results of up to size
+// arguments, dropping the least recently used result when it needs room
+// for another. If size is less than 1, nothing is held, though the
+// statistics are still counted. fn must be safe for concurrent use, as the
+// functions of the package are.
+//
+// For example
+//
+//	exp := bigmath.NewMemo(bigmath.Exp, 1000)
+//	y := exp.Eval(x)
+//
+// evaluates e**x only the first time it is needed for each x.
+func NewMemo(fn func(x *big.Float) *big.Float, size int) *Memo {
+	return &Memo{
+		fn:      fn,
+		size:    max(size, 0),
+		entries: make(map[memoKey]*list.Element),
+	}
+}
+
+// Eval returns fn(x), as a copy from the Memo if it holds it. Concurrent
+// calls for an argument that is not held may each evaluate fn.
+func (m *Memo) Eval(x *big.Float) *big.Float {
+	key := memoKey{prec: x.Prec(), mode: x.Mode(), value: x.Text('p', 0)}
+
+	m.mu.Lock()
+	if e, ok := m.entries[key]; ok {
+		m.lru.MoveToFront(e)
+		m.stats.Hits++
+		result := e.Value.(*memoEntry).result
+		m.mu.Unlock()
+
+		return new(big.Float).Copy(result)
+	}
+	m.stats.Misses++
+	m.mu.Unlock()
+
+	// fn runs without the lock, so that other arguments are not held up.
+	// Its result may be x itself, which belongs to the caller, so the Memo
+	// holds a copy.
+	result := m.fn(x)
+	if m.size > 0 {
+		m.add(key, new(big.Float).Copy(result))
+	}
+
+	return result
+}
+
+// add holds result for key, dropping the least recently used result if the
+// Memo is full.
+func (m *Memo) add(key memoKey, result *big.Float) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.entries[key]; ok {
+		// Another call evaluated it first.
+		return
+	}
+	if m.lru.Len() >= m.size {
+		oldest := m.lru.Back()
+		delete(m.entries, oldest.Value.(*memoEntry).key)
+		m.lru.Remove(oldest)
+		m.stats.Evictions++
+	}
+	m.entries[key] = m.lru.PushFront(&memoEntry{key: key, result: result})
+}
+
+// Stats returns the statistics of m.
+func (m *Memo) Stats() MemoStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := m.stats
+	s.Len = m.lru.Len()
+	s.Size = m.size
+
+	return s
+}
+
+// Clear drops every result held by m, keeping its statistics.
+func (m *Memo) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clear(m.entries)
+	m.lru.Init()
+}
@@ -0,0 +1,171 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingFunc returns fn and a count of its calls.
+func countingFunc(fn func(*big.Float) *big.Float) (func(*big.Float) *big.Float, *atomic.Int64) {
+	var calls atomic.Int64
+
+	return func(x *big.Float) *big.Float {
+		calls.Add(1)
+
+		return fn(x)
+	}, &calls
+}
+
+func TestMemo(t *testing.T) {
+	exp, calls := countingFunc(Exp)
+	m := NewMemo(exp, 2)
+
+	a := newRatFloat("1/3", 100)
+	b := newRatFloat("2/3", 100)
+	c := newRatFloat("4/3", 100)
+	for _, x := range []*big.Float{a, b, a, c, a, b} {
+		if got, want := m.Eval(x), Exp(x); !sameValue(got, want) {
+			t.Errorf("Eval(%s) = %s, want %s", x.Text('g', 10), got.Text('g', 20), want.Text('g', 20))
+		}
+	}
+
+	// a, b and c miss; a hits; c evicts b, the least recently used; a
+	// hits; b misses and evicts c.
+	want := MemoStats{Hits: 2, Misses: 4, Evictions: 2, Len: 2, Size: 2}
+	if got := m.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if calls.Load() != 4 {
+		t.Errorf("Exp was called %d times, want 4", calls.Load())
+	}
+
+	m.Clear()
+	m.Eval(a)
+	want = MemoStats{Hits: 2, Misses: 5, Evictions: 2, Len: 1, Size: 2}
+	if got := m.Stats(); got != want {
+		t.Errorf("after Clear, Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMemoKey(t *testing.T) {
+	m := NewMemo(Sin, 10)
+
+	// Arguments equal in value but not in precision, rounding mode or sign
+	// are different.
+	x := newRatFloat("1/3", 100)
+	for _, arg := range []*big.Float{
+		x,
+		new(big.Float).SetPrec(100).Set(x),
+		new(big.Float).SetPrec(200).Set(x),
+		new(big.Float).SetPrec(100).SetMode(big.ToZero).Set(x),
+		new(big.Float).SetPrec(100),
+		new(big.Float).SetPrec(100).Neg(new(big.Float)),
+	} {
+		if got, want := m.Eval(arg), Sin(arg); !sameValue(got, want) {
+			t.Errorf("Eval(%v) = %v, want %v", arg, got, want)
+		}
+	}
+	if got := m.Stats(); got.Hits != 1 || got.Misses != 5 {
+		t.Errorf("Stats() = %+v, want 1 hit and 5 misses", got)
+	}
+}
+
+func TestMemoCopies(t *testing.T) {
+	m := NewMemo(Log, 4)
+	x := big.NewFloat(2)
+	want := Log(x)
+
+	// Neither the results nor the argument can change what is held.
+	m.Eval(x).SetInt64(7)
+	m.Eval(x).SetInt64(8)
+	if got := m.Eval(x); !sameValue(got, want) {
+		t.Errorf("after changing results, Eval(2) = %v, want %v", got, want)
+	}
+
+	// A function returning its argument.
+	id := NewMemo(func(x *big.Float) *big.Float { return x }, 4)
+	y := big.NewFloat(3)
+	id.Eval(y)
+	y.SetInt64(5)
+	if got := id.Eval(big.NewFloat(3)); got.Cmp(big.NewFloat(3)) != 0 {
+		t.Errorf("after changing the argument, Eval(3) = %v, want 3", got)
+	}
+}
+
+func TestMemoDisabled(t *testing.T) {
+	gamma, calls := countingFunc(Gamma)
+	m := NewMemo(gamma, 0)
+	x := big.NewFloat(4.5)
+	for range 3 {
+		m.Eval(x)
+	}
+	want := MemoStats{Misses: 3}
+	if got := m.Stats(); got != want || calls.Load() != 3 {
+		t.Errorf("Stats() = %+v after %d calls, want %+v", got, calls.Load(), want)
+	}
+}
+
+func TestMemoConcurrent(t *testing.T) {
+	m := NewMemo(Exp, 8)
+	xs := batchInputs(16, 80)
+	want := make([]*big.Float, len(xs))
+	for i, x := range xs {
+		want[i] = Exp(x)
+	}
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				k := (i*7 + g) % len(xs)
+				if got := m.Eval(xs[k]); !sameValue(got, want[k]) {
+					t.Errorf("Eval(xs[%d]) = %v, want %v", k, got, want[k])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	s := m.Stats()
+	if s.Hits+s.Misses != 8*200 || s.Len > 8 {
+		t.Errorf("Stats() = %+v, want %d calls and at most 8 held", s, 8*200)
+	}
+}
+
+func BenchmarkMemo(b *testing.B) {
+	for _, prec := range []uint{64, 1024} {
+		x := newRatFloat("7/3", prec)
+
+		b.Run(fmt.Sprintf("Exp_prec_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				Exp(x)
+			}
+		})
+		b.Run(fmt.Sprintf("Memo_hit_prec_%d", prec), func(b *testing.B) {
+			m := NewMemo(Exp, 16)
+			m.Eval(x)
+			for b.Loop() {
+				m.Eval(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// At very high precision the independent halves of a binary splitting
+// tree or a product tree, and the chunks of the bit-burst algorithm, can
+// run on other goroutines. Each part is computed exactly as it would be on
+// one goroutine and the parts are combined in the same order, so results
+// never depend on the number of workers.
+
+const (
+	// parallelMinTerms is the number of terms below which a binary
+	// splitting range is not split across goroutines.
+	parallelMinTerms = 256
+
+	// parallelMinFactors is the number of factors below which a product
+	// tree is not split across goroutines.
+	parallelMinFactors = 1024
+)
+
+// A workerPool hands out the goroutines beyond the calling one.
+type workerPool struct {
+	n      int
+	tokens chan struct{}
+}
+
+// workers is the pool set by SetMaxWorkers, or nil if computations run on
+// the calling goroutine only.
+var workers atomic.Pointer[workerPool]
+
+// SetMaxWorkers sets the number of goroutines that the computations of the
+// package may use at once to n, and returns the previous setting. The
+// default, and any n < 1, is 1: everything runs on the calling goroutine.
+//
+// With more workers, binary splitting (ComputePi, ComputeE, ComputeLn2 and
+// the constants and functions built on them), Factorial and the bit-burst
+// evaluation of Exp, Sin and Cos at very high precision share their work
+// among up to n goroutines in all, across all concurrent calls. The
+// results are identical to those computed with one worker.
+func SetMaxWorkers(n int) int {
+	var p *workerPool
+	if n > 1 {
+		p = &workerPool{n: n, tokens: make(chan struct{}, n-1)}
+	}
+
+	if old := workers.Swap(p); old != nil {
+		return old.n
+	}
+
+	return 1
+}
+
+// MaxWorkers returns the number of goroutines set by SetMaxWorkers.
+func MaxWorkers() int {
+	if p := workers.Load(); p != nil {
+		return p.n
+	}
+
+	return 1
+}
+
+// parallel reports whether work may be shared with other goroutines.
+func parallel() bool {
+	return workers.Load() != nil
+}
+
+// fork calls a and b, a on another goroutine if a worker is free, and
+// returns when both have returned. A panic in a, such as the one of a
+// cancelled context, is raised again on the calling goroutine.
+func fork(a, b func()) {
+	p := workers.Load()
+	if p == nil {
+		a()
+		b()
+
+		return
+	}
+
+	select {
+	case p.tokens <- struct{}{}:
+	default:
+		a()
+		b()
+
+		return
+	}
+
+	var wg sync.WaitGroup
+	var panicked any
+	wg.Add(1)
+	go func() {
+		defer func() {
+			panicked = recover()
+			<-p.tokens
+			wg.Done()
+		}()
+		a()
+	}()
+
+	// Wait for a even if b panics, so that no work outlives the call.
+	defer wg.Wait()
+	b()
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// forEach calls fn(i) for each i in [0, n), sharing the calls among the
+// free workers, and returns when all of them have returned.
+func forEach(n int, fn func(i int)) {
+	var each func(lo, hi int)
+	each = func(lo, hi int) {
+		if hi-lo == 1 {
+			fn(lo)
+
+			return
+		}
+		mid := lo + (hi-lo)/2
+		fork(func() { each(lo, mid) }, func() { each(mid, hi) })
+	}
+
+	if n > 0 {
+		each(0, n)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// parallelFunctions are the computations that share their work among the
+// workers, at sizes large enough to do so.
+var parallelFunctions = []struct {
+	name string
+	fn   func() any
+}{
+	{"ComputePi", func() any { return ComputePi(40000) }},
+	{"ComputeE", func() any { return ComputeE(40000) }},
+	{"ComputeLn2", func() any { return ComputeLn2(20000) }},
+	{"Factorial", func() any { return Factorial(100000) }},
+	{"Exp", func() any { return expBitBurst(context.Background(), newRatFloat("7/3", 20000)) }},
+	{"SinCos", func() any {
+		s, c := sinCosBitBurst(context.Background(), newRatFloat("7/3", 20000))
+
+		return []*big.Float{s, c}
+	}},
+}
+
+// withWorkers sets the number of workers for the rest of the test.
+func withWorkers(t testing.TB, n int) {
+	t.Helper()
+	prev := SetMaxWorkers(n)
+	t.Cleanup(func() { SetMaxWorkers(prev) })
+}
+
+func TestSetMaxWorkers(t *testing.T) {
+	withWorkers(t, 1)
+
+	tests := []struct {
+		n, want int
+	}{
+		{4, 4},
+		{0, 1},
+		{-3, 1},
+		{1, 1},
+		{2, 2},
+	}
+	prev := 1
+	for _, test := range tests {
+		if got := SetMaxWorkers(test.n); got != prev {
+			t.Errorf("SetMaxWorkers(%d) = %d, want %d", test.n, got, prev)
+		}
+		if got := MaxWorkers(); got != test.want {
+			t.Errorf("MaxWorkers() after SetMaxWorkers(%d) = %d, want %d", test.n, got, test.want)
+		}
+		prev = test.want
+	}
+}
+
+func TestParallelIdentical(t *testing.T) {
+	withWorkers(t, 1)
+
+	for _, f := range parallelFunctions {
+		want := f.fn()
+		for _, n := range []int{2, 4, 16} {
+			t.Run(fmt.Sprintf("%s/workers_%d", f.name, n), func(t *testing.T) {
+				withWorkers(t, n)
+				if got := f.fn(); !sameValue(got, want) {
+					t.Errorf("%s with %d workers differs from 1 worker", f.name, n)
+				}
+			})
+		}
+	}
+}
+
+func TestParallelCanceled(t *testing.T) {
+	withWorkers(t, 4)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ComputePiCtx(ctx, 100000); !errors.Is(err, context.Canceled) {
+		t.Errorf("ComputePiCtx(canceled) error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := FactorialCtx(ctx, 1000000); !errors.Is(err, context.Canceled) {
+		t.Errorf("FactorialCtx(canceled) error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestForkPanic(t *testing.T) {
+	withWorkers(t, 4)
+
+	defer func() {
+		if r := recover(); r != "a" {
+			t.Errorf("fork panicked with %v, want a", r)
+		}
+	}()
+
+	ran := false
+	fork(func() { panic("a") }, func() { ran = true })
+	t.Errorf("fork returned after a panicked; b ran: %v", ran)
+}
+
+func TestForEach(t *testing.T) {
+	withWorkers(t, 3)
+
+	for _, n := range []int{0, 1, 2, 7, 100} {
+		got := make([]int, n)
+		forEach(n, func(i int) { got[i] = i + 1 })
+		for i, v := range got {
+			if v != i+1 {
+				t.Errorf("forEach(%d) did not call fn(%d)", n, i)
+			}
+		}
+	}
+}
+
+// BenchmarkParallelScaling shows how the very high precision computations
+// scale with the number of workers.
+func BenchmarkParallelScaling(b *testing.B) {
+	x := newRatFloat("7/3", 100000)
+	computations := []struct {
+		name string
+		fn   func()
+	}{
+		{"ComputePi_200000", func() { ComputePi(200000) }},
+		{"ComputeE_200000", func() { ComputeE(200000) }},
+		{"Factorial_1000000", func() { Factorial(1000000) }},
+		{"Exp_100000", func() { expBitBurst(context.Background(), x) }},
+		{"SinCos_100000", func() { sinCosBitBurst(context.Background(), x) }},
+	}
+
+	for _, c := range computations {
+		for _, n := range []int{1, 2, 4, 8} {
+			b.Run(fmt.Sprintf("%s/workers_%d", c.name, n), func(b *testing.B) {
+				withWorkers(b, n)
+				for b.Loop() {
+					c.fn()
+				}
+			})
+		}
+	}
+}
@@ -15,6 +15,7 @@
 package bigmath
 
 import (
+	"context"
 	"math"
 	"math/big"
 )
@@ -40,8 +41,6 @@ func isOddInteger(f *big.Float) bool {
 // Pow(x, ±0) = 1 for any x
 // Pow(1, y) = 1 for any y
 // Pow(x, 1) = x for any x
-// Pow(NaN, y) = NaN
-// Pow(x, NaN) = NaN
 // Pow(±0, y) = ±Inf for y an odd integer < 0
 // Pow(±0, -Inf) = +Inf
 // Pow(±0, +Inf) = +0
@@ -56,8 +55,83 @@ func isOddInteger(f *big.Float) bool {
 // Pow(+Inf, y) = +Inf for y > 0
 // Pow(+Inf, y) = +0 for y < 0
 // Pow(-Inf, y) = Pow(-0, -y)
-// Pow(x, y) = NaN for finite x < 0 and finite non-integer y
+// Pow(x, y) = +Inf for finite x < 0 and finite non-integer y, and PowE returns ErrDomain
+//
+// The result has the larger of the precisions of x and y, and is correctly
+// rounded in the rounding mode of x.
 func Pow(x, y *big.Float) *big.Float {
+	z, _ := PowE(x, y)
+
+	return z
+}
+
+// PowE is like Pow, and also returns an error wrapping ErrDomain for finite
+// x < 0 and finite non-integer y, ErrPole for x = ±0 and y < 0, ErrOverflow
+// if finite x and y over or underflow, or ErrNoConvergence if the rounding
+// could not be proven correct.
+func PowE(x, y *big.Float) (*big.Float, error) {
+	return powE(context.Background(), x, y)
+}
+
+// PowCtx is like PowE, but stops and returns ctx.Err() if ctx is done
+// before the result is ready.
+func PowCtx(ctx context.Context, x, y *big.Float) (z *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return powE(ctx, x, y)
+}
+
+// PowTo sets z to x**y, correctly rounded to the precision of z in its
+// rounding mode, and returns z. If the precision of z is 0, it is changed
+// to the larger of the precisions of x and y first, so that
+// PowTo(new(big.Float).SetMode(x.Mode()), x, y) gives the same result as
+// Pow(x, y). z may be x or y.
+//
+// Like the methods of big.Float, PowTo reuses the storage of z, and its
+// temporaries are reused from one call to the next, so it allocates far
+// less than Pow when called in a loop.
+func PowTo(z, x, y *big.Float) *big.Float {
+	if z.Prec() == 0 {
+		z.SetPrec(max(x.Prec(), y.Prec()))
+	}
+	z, _ = powTo(context.Background(), nil, z, x, y)
+
+	return z
+}
+
+// powE implements PowE.
+func powE(ctx context.Context, x, y *big.Float) (*big.Float, error) {
+	return powTo(ctx, nil, new(big.Float).SetPrec(max(x.Prec(), y.Prec())).SetMode(x.Mode()), x, y)
+}
+
+// powTo implements PowTo, and also returns the error of PowE. The error
+// describes x and y as they are after the call, so it is only meaningful if
+// z is neither of them. The Context c, which may be nil, sets the guard bits
+// and iteration limit of the rounding.
+func powTo(ctx context.Context, c *Context, z, x, y *big.Float) (*big.Float, error) {
+	if special := powSpecial(x, y); special != nil {
+		var err error
+		switch {
+		case x.Sign() < 0 && !x.IsInf() && !y.IsInf() && !y.IsInt():
+			err = funcError(ErrDomain, "Pow", x, y)
+		case x.Sign() == 0 && y.Sign() < 0:
+			err = funcError(ErrPole, "Pow", x, y)
+		}
+
+		return z.Set(special), err
+	}
+
+	z, err := pow(ctx, c, z, x, y)
+	if err != nil {
+		return z, funcError(err, "Pow", x, y)
+	}
+
+	return z, overflowError(z, "Pow", x, y)
+}
+
+// powSpecial returns the exact result of one of the special cases of Pow,
+// or nil if x and y are not one of them.
+func powSpecial(x, y *big.Float) *big.Float {
 	zero := big.NewFloat(0)
 	one := big.NewFloat(1)
 	negOne := big.NewFloat(-1)
@@ -214,7 +288,7 @@ func Pow(x, y *big.Float) *big.Float {
 		return big.NewFloat(0)
 	}
 
-	// Pow(x, y) = NaN for finite x < 0 and finite non-integer y
+	// Pow(x, y) = +Inf for finite x < 0 and finite non-integer y
 	if x.Sign() < 0 && !y.IsInt() {
 		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
 		result := big.NewFloat(0)
@@ -223,29 +297,203 @@ func Pow(x, y *big.Float) *big.Float {
 		return result
 	}
 
-	// For integer exponents, use PowInt
-	if y.IsInt() && y.Abs(y).Cmp(big.NewFloat(1000000)) < 0 {
-		yInt, _ := y.Int64()
+	return nil
+}
+
+// pow sets z to x**y correctly rounded to the precision of z in its
+// rounding mode, for finite non-zero x ≠ 1 and finite y that are not
+// special cases, and returns z.
+func pow(ctx context.Context, c *Context, z, x, y *big.Float) (*big.Float, error) {
+	// Integer powers are computed exactly when that is affordable, so
+	// they round only once.
+	if n, acc := y.Int64(); y.IsInt() && acc == big.Exact && n != math.MinInt64 {
+		if result := powIntExact(x, uint64(max(n, -n))); result != nil {
+			if n < 0 {
+				return z.Quo(one, result), nil
+			}
 
-		return PowInt(x, yInt)
+			return z.Set(result), nil
+		}
 	}
 
-	// For non-integer exponents, use exp(y * ln(x))
-	// x^y = e^(y * ln(x))
-	logX := Log(x)
-	if logX == nil {
-		// If log fails, return +Inf to indicate undefined behavior
-		result := big.NewFloat(0)
-		result.SetInf(false)
+	return c.correctlyRounded(z, func(work uint) *big.Float {
+		return powApprox(ctx, x, y, work)
+	}, func(c *big.Float) bool {
+		return powIsExact(c, x, y)
+	})
+}
+
+// powExactMaxBits is the largest number of bits powIntExact will spend on
+// an exact power.
+const powExactMaxBits = 1 << 20
+
+// powIntExact returns x**n exactly, or nil if that might need more than
+// powExactMaxBits bits.
+func powIntExact(x *big.Float, n uint64) *big.Float {
+	bits := uint64(x.MinPrec())
+	if n > powExactMaxBits/max(bits, 1) {
+		return nil
+	}
+	prec := uint(max(bits*n, 1))
+
+	// Binary exponentiation; every intermediate fits in prec bits.
+	result := new(big.Float).SetPrec(prec).SetInt64(1)
+	base := new(big.Float).SetPrec(prec).Set(x)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result.Mul(result, base)
+		}
+		if n > 1 {
+			base.Mul(base, base)
+		}
+	}
+
+	return result
+}
+
+// powApprox returns x**y for finite non-zero x ≠ 1 and finite y to within
+// a unit in the last place of work bits, as e^(y·ln|x|).
+func powApprox(ctx context.Context, x, y *big.Float, work uint) *big.Float {
+	// The integer bits of y·ln|x| end up in the exponent of the result, so
+	// it needs that many extra bits for the fraction to have work of them.
+	extra := uint(8)
+	for {
+		t := logAGM(ctx, new(big.Float).SetPrec(work+extra).Abs(x))
+		t.Mul(t, y)
+
+		var result *big.Float
+		switch e := t.MantExp(nil); {
+		case e > 32:
+			// Far beyond the exponent range of big.Float; this over or
+			// underflows.
+			result = expApprox(ctx, t)
+		case e > 0 && uint(e)+8 > extra:
+			extra = uint(e) + 8
+
+			continue
+		default:
+			result = expApprox(ctx, t).SetPrec(work)
+		}
+
+		if x.Sign() < 0 && isOddInteger(y) {
+			result.Neg(result)
+		}
 
 		return result
 	}
+}
+
+// powIsExact reports whether c is exactly x**y, for y = m/2^k a dyadic
+// rational with modest m and k. Other non-integer powers of a rational x
+// are irrational, and integer powers are handled exactly by Pow itself.
+func powIsExact(c, x, y *big.Float) bool {
+	k := int(y.MinPrec()) - y.MantExp(nil)
+	if k <= 0 || k > 16 {
+		return false
+	}
+	m, acc := new(big.Float).SetMantExp(y, k).Int64()
+	if acc != big.Exact || m == math.MinInt64 {
+		return false
+	}
+
+	// c^(2^k) = x^m, or c^(2^k)·x^|m| = 1 for negative m.
+	lhs := powIntExact(c, 1<<k)
+	rhs := powIntExact(x, uint64(max(m, -m)))
+	if lhs == nil || rhs == nil {
+		return false
+	}
+	if m < 0 {
+		return new(big.Float).SetPrec(lhs.Prec()+rhs.Prec()).Mul(lhs, rhs).Cmp(one) == 0
+	}
+
+	return lhs.Cmp(rhs) == 0
+}
+
+// Sqrt returns the square root of x.
+//
+// The result has the precision of x and is correctly rounded in its
+// rounding mode.
+//
+// The special cases are:
+//
+//	Sqrt(+Inf) = +Inf
+//	Sqrt(±0) = ±0
+//	Sqrt(x < 0) = +Inf, and SqrtE returns ErrDomain
+func Sqrt(x *big.Float) *big.Float {
+	z, _ := SqrtE(x)
+
+	return z
+}
+
+// SqrtE is like Sqrt, and also returns an error wrapping ErrDomain for
+// x < 0.
+func SqrtE(x *big.Float) (*big.Float, error) {
+	return sqrtTo(nil, new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode()), x)
+}
+
+// SqrtTo sets z to the square root of x, correctly rounded to the precision
+// of z in its rounding mode, and returns z. If the precision of z is 0, it
+// is changed to the precision of x first, so that
+// SqrtTo(new(big.Float).SetMode(x.Mode()), x) gives the same result as
+// Sqrt(x). z may be x.
+//
+// Unlike big.Float.Sqrt, SqrtTo rounds correctly in every rounding mode.
+func SqrtTo(z, x *big.Float) *big.Float {
+	if z.Prec() == 0 {
+		z.SetPrec(x.Prec())
+	}
+	z, _ = sqrtTo(nil, z, x)
+
+	return z
+}
+
+// sqrtTo implements SqrtTo, and also returns the error of SqrtE. The error
+// describes x as it is after the call, so it is only meaningful if z is not
+// x. The Context c, which may be nil, sets the guard bits and iteration
+// limit of the rounding.
+func sqrtTo(c *Context, z, x *big.Float) (*big.Float, error) {
+	switch {
+	case x.Sign() < 0:
+		err := funcError(ErrDomain, "Sqrt", x)
+
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return z.SetInf(false), err
+	case x.Sign() == 0, x.IsInf():
+		return z.Set(x), nil
+	}
+
+	// Every square root of a finite x is distinguished by the exactness
+	// test, so this always converges.
+	return c.correctlyRounded(z, func(work uint) *big.Float {
+		return new(big.Float).SetPrec(work).Sqrt(x)
+	}, func(c *big.Float) bool {
+		return new(big.Float).SetPrec(2*c.Prec()).Mul(c, c).Cmp(x) == 0
+	})
+}
+
+// sqrtCORDIC calculates √x by hyperbolic CORDIC, as
+// 2^e·√((m + ¼)² − (m − ¼)²) for x = m·4^e with ¼ <= m < 1, vectoring
+// (m + ¼, m − ¼), whose angle is then within the range of the steps. x
+// must be finite and positive; 0 and negative x give
+// meaningless results.
+// This is a package-private method for performance comparison.
+func sqrtCORDIC(x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	m := new(big.Float)
+	e := x.MantExp(m)
+	if e%2 != 0 {
+		m.SetMantExp(m, -1)
+		e++
+	}
 
-	// Compute y * log(x)
-	yLogX := new(big.Float).Mul(y, logX)
+	work := cordicWork(prec, nil)
+	quarter := big.NewFloat(0.25)
+	u := new(big.Float).SetPrec(work).Sub(m, quarter)
+	v := new(big.Float).SetPrec(work).Add(m, quarter)
+	r, _ := cordicVector(context.Background(), CORDICHyperbolic, v, u, work)
 
-	// Compute e^(y * log(x))
-	return Exp(yLogX)
+	return r.SetMantExp(r, e/2).SetPrec(prec)
 }
 
 // PowFloat64 returns x**y, the base-x exponential of y from float64 inputs
@@ -256,8 +504,8 @@ func Pow(x, y *big.Float) *big.Float {
 // PowFloat64(x, ±0) = 1 for any x
 // PowFloat64(1, y) = 1 for any y
 // PowFloat64(x, 1) = x for any x
-// PowFloat64(NaN, y) = ErrNaN
-// PowFloat64(x, NaN) = ErrNaN
+// PowFloat64(NaN, y) = +Inf
+// PowFloat64(x, NaN) = +Inf
 // Pow(±0, -Inf) = +Inf
 // Pow(±0, +Inf) = +0
 // Pow(±0, y) = +Inf for finite y < 0 and not an odd integer
@@ -272,7 +520,7 @@ func Pow(x, y *big.Float) *big.Float {
 // Pow(+Inf, y) = +Inf for y > 0
 // Pow(+Inf, y) = +0 for y < 0
 // Pow(-Inf, y) = Pow(-0, -y)
-// Pow(x, y) = ErrNan for finite x < 0 and finite non-integer y
+// Pow(x, y) = +Inf for finite x < 0 and finite non-integer y
 func PowFloat64(x, y float64) *big.Float {
 	// Handle NaN inputs - return +Inf to indicate undefined (big.Float has no NaN)
 	if math.IsNaN(x) || math.IsNaN(y) {
@@ -121,6 +121,27 @@ func TestPowLargeValues(t *testing.T) {
 	}
 }
 
+func TestPowOddPowerOutOfRange(t *testing.T) {
+	// y = 2^40 + 1 is odd, so the over and underflowing powers of -3 and
+	// -1/3 keep the sign of x.
+	y := new(big.Float).SetPrec(53).SetMantExp(big.NewFloat(1), 40)
+	y.Add(y, big.NewFloat(1))
+
+	if got := Pow(big.NewFloat(-3), y); !got.IsInf() || !got.Signbit() {
+		t.Errorf("Pow(-3, 2^40+1) = %v, want -Inf", got)
+	}
+	third := new(big.Float).SetPrec(53).Quo(big.NewFloat(-1), big.NewFloat(3))
+	if got := Pow(third, y); got.Sign() != 0 || !got.Signbit() {
+		t.Errorf("Pow(-1/3, 2^40+1) = %v, want -0", got)
+	}
+
+	// An even power is positive.
+	y.Add(y, big.NewFloat(1))
+	if got := Pow(big.NewFloat(-3), y); !got.IsInf() || got.Signbit() {
+		t.Errorf("Pow(-3, 2^40+2) = %v, want +Inf", got)
+	}
+}
+
 func TestPowSpecialCases(t *testing.T) {
 	zero := big.NewFloat(0)
 	one := big.NewFloat(1)
@@ -0,0 +1,251 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+)
+
+// A Context controls the precision and rounding of results, independently
+// of the precision of the arguments, so that one configured Context can
+// drive a whole computation. For example
+//
+//	c := bigmath.Context{Prec: bigmath.DigitsToBits(150)}
+//	y := c.Exp(x)
+//
+// gives e**x to 150 digits whatever the precision of x. A Context is not
+// changed by its methods and is safe for concurrent use.
+//
+// The correctly rounded functions, Exp, Log, Sqrt, Pow, Sin, Cos and Atan,
+// give the correctly rounded result of the exact argument, and Pi, E and
+// Ln2 the correctly rounded constant. Tan, Asin, Acos, Atan2, Sinh, Cosh,
+// Tanh, Sec, Csc, Cot and Gamma evaluate the package-level function with
+// the argument carried to the guard bits beyond the precision of the
+// result, and round that. The other functions of the package have no
+// Context method, and take their precision from their arguments.
+type Context struct {
+	// Prec is the precision of results in bits. If it is 0, results have
+	// the precision and rounding mode of the argument, the larger of the
+	// two precisions for Pow and Atan2, or 53 bits rounded to nearest for
+	// the constants, and Mode is ignored.
+	Prec uint
+
+	// Mode is the rounding mode of results.
+	Mode big.RoundingMode
+
+	// GuardBits is the number of bits beyond the precision of the result
+	// carried by the first approximation. For the correctly rounded
+	// functions each further approximation doubles it; the others are
+	// evaluated with the argument carried to that many extra bits. If it
+	// is 0, 32 guard bits are used.
+	GuardBits uint
+
+	// MaxIterations limits the number of approximations the correctly
+	// rounded functions make before they give up and round the last one
+	// to nearest. If it is 0, they continue up to max(4·Prec, 1024) guard
+	// bits.
+	MaxIterations int
+}
+
+// DefaultContext is the zero Context. Its correctly rounded methods give
+// the same results as the package-level functions, and the others are at
+// least as accurate. The package-level functions do not read it, so
+// changing it changes only the results of its own methods.
+var DefaultContext Context
+
+// guardBits returns the number of guard bits of the first approximation.
+// c may be nil.
+func (c *Context) guardBits() uint {
+	if c == nil || c.GuardBits == 0 {
+		return zivGuardBits
+	}
+
+	return c.GuardBits
+}
+
+// result returns a zero with the precision and rounding mode of results
+// for an argument with the precision prec and rounding mode of x.
+func (c *Context) result(x *big.Float, prec uint) *big.Float {
+	if c.Prec == 0 {
+		return new(big.Float).SetPrec(prec).SetMode(x.Mode())
+	}
+
+	return new(big.Float).SetPrec(c.Prec).SetMode(c.Mode)
+}
+
+// approx evaluates one of the functions that are not correctly rounded,
+// with x carried to the guard bits beyond the precision of the result.
+func (c *Context) approx(fn func(x *big.Float) *big.Float, x *big.Float) *big.Float {
+	z := c.result(x, x.Prec())
+	arg := new(big.Float).SetPrec(max(x.Prec(), z.Prec()+c.guardBits())).Set(x)
+
+	return z.Set(fn(arg))
+}
+
+// Exp returns e**x, correctly rounded.
+func (c *Context) Exp(x *big.Float) *big.Float {
+	z, _ := expTo(context.Background(), c, c.result(x, x.Prec()), x)
+
+	return z
+}
+
+// Log returns the natural logarithm of x, correctly rounded.
+func (c *Context) Log(x *big.Float) *big.Float {
+	z, _ := logTo(context.Background(), c, c.result(x, x.Prec()), x)
+
+	return z
+}
+
+// Sqrt returns the square root of x, correctly rounded.
+func (c *Context) Sqrt(x *big.Float) *big.Float {
+	z, _ := sqrtTo(c, c.result(x, x.Prec()), x)
+
+	return z
+}
+
+// Pow returns x**y, correctly rounded.
+func (c *Context) Pow(x, y *big.Float) *big.Float {
+	z, _ := powTo(context.Background(), c, c.result(x, max(x.Prec(), y.Prec())), x, y)
+
+	return z
+}
+
+// Sin returns the sine of the radian argument x, correctly rounded.
+func (c *Context) Sin(x *big.Float) *big.Float {
+	z, _ := sinTo(context.Background(), c, c.result(x, x.Prec()), x)
+
+	return z
+}
+
+// Cos returns the cosine of the radian argument x, correctly rounded.
+func (c *Context) Cos(x *big.Float) *big.Float {
+	z, _ := cosTo(context.Background(), c, c.result(x, x.Prec()), x)
+
+	return z
+}
+
+// Atan returns the arctangent, in radians, of x, correctly rounded.
+func (c *Context) Atan(x *big.Float) *big.Float {
+	z, _ := atanTo(context.Background(), c, c.result(x, x.Prec()), x)
+
+	return z
+}
+
+// Pi returns π, correctly rounded.
+func (c *Context) Pi() *big.Float {
+	return c.constant(func(work uint) *big.Float {
+		return piCache.get(context.Background(), work)
+	})
+}
+
+// E returns e, the base of natural logarithms, correctly rounded.
+func (c *Context) E() *big.Float {
+	return c.constant(func(work uint) *big.Float {
+		return computeEBinarySplit(context.Background(), work)
+	})
+}
+
+// Ln2 returns the natural logarithm of 2, correctly rounded.
+func (c *Context) Ln2() *big.Float {
+	return c.constant(func(work uint) *big.Float {
+		return ln2Cache.get(context.Background(), work)
+	})
+}
+
+// constant returns the constant that compute(work) gives to work bits,
+// correctly rounded. No constant here is exactly representable, so the
+// rounding always converges.
+func (c *Context) constant(compute func(work uint) *big.Float) *big.Float {
+	z := new(big.Float).SetPrec(53)
+	if c.Prec != 0 {
+		z.SetPrec(c.Prec).SetMode(c.Mode)
+	}
+	z, _ = c.correctlyRounded(z, compute, nil)
+
+	return z
+}
+
+// Tan returns the tangent of the radian argument x.
+func (c *Context) Tan(x *big.Float) *big.Float {
+	return c.approx(Tan, x)
+}
+
+// Asin returns the arcsine, in radians, of x.
+func (c *Context) Asin(x *big.Float) *big.Float {
+	return c.approx(Asin, x)
+}
+
+// Acos returns the arccosine, in radians, of x.
+func (c *Context) Acos(x *big.Float) *big.Float {
+	return c.approx(Acos, x)
+}
+
+// Atan2 returns the arc tangent of y/x, using the signs of the two to
+// determine the quadrant of the return value.
+func (c *Context) Atan2(y, x *big.Float) *big.Float {
+	z := c.result(y, max(y.Prec(), x.Prec()))
+	work := max(y.Prec(), x.Prec(), z.Prec()+c.guardBits())
+
+	return z.Set(Atan2(new(big.Float).SetPrec(work).Set(y), new(big.Float).SetPrec(work).Set(x)))
+}
+
+// Sinh returns the hyperbolic sine of x.
+func (c *Context) Sinh(x *big.Float) *big.Float {
+	return c.approx(Sinh, x)
+}
+
+// Cosh returns the hyperbolic cosine of x.
+func (c *Context) Cosh(x *big.Float) *big.Float {
+	return c.approx(Cosh, x)
+}
+
+// Tanh returns the hyperbolic tangent of x.
+func (c *Context) Tanh(x *big.Float) *big.Float {
+	return c.approx(Tanh, x)
+}
+
+// Sec returns the secant of the radian argument x.
+func (c *Context) Sec(x *big.Float) *big.Float {
+	return c.approx(Sec, x)
+}
+
+// Csc returns the cosecant of the radian argument x.
+func (c *Context) Csc(x *big.Float) *big.Float {
+	return c.approx(Csc, x)
+}
+
+// Cot returns the cotangent of the radian argument x.
+func (c *Context) Cot(x *big.Float) *big.Float {
+	return c.approx(Cot, x)
+}
+
+// Gamma returns the Gamma function of x.
+func (c *Context) Gamma(x *big.Float) *big.Float {
+	return c.approx(Gamma, x)
+}
+
+// DigitsToBits returns the number of bits of precision needed to hold the
+// given number of significant decimal digits, ⌈digits·log₂10⌉.
+func DigitsToBits(digits uint) uint {
+	return uint(math.Ceil(float64(digits) * math.Log2(10)))
+}
+
+// BitsToDigits returns the number of significant decimal digits that the
+// given number of bits of precision always holds, ⌊bits·log₁₀2⌋.
+func BitsToDigits(bits uint) uint {
+	return uint(float64(bits) * math.Log10(2))
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// contextFunctions pairs each correctly rounded Context method with the
+// package-level function it matches.
+func contextFunctions(c *Context) []struct {
+	name  string
+	fn    func(x *big.Float) *big.Float
+	plain func(x *big.Float) *big.Float
+} {
+	third := newRatFloat("1/3", 24)
+
+	return []struct {
+		name  string
+		fn    func(x *big.Float) *big.Float
+		plain func(x *big.Float) *big.Float
+	}{
+		{"Exp", c.Exp, Exp},
+		{"Log", c.Log, Log},
+		{"Sqrt", c.Sqrt, Sqrt},
+		{"Sin", c.Sin, Sin},
+		{"Cos", c.Cos, Cos},
+		{"Atan", c.Atan, Atan},
+		{"Pow(x, 1/3)", func(x *big.Float) *big.Float { return c.Pow(x, third) },
+			func(x *big.Float) *big.Float { return Pow(x, third) }},
+	}
+}
+
+func TestDefaultContext(t *testing.T) {
+	for _, fn := range contextFunctions(&DefaultContext) {
+		for _, mode := range roundingModes {
+			for _, s := range []string{"0", "0.1", "2.5", "-7.3", "1000.1"} {
+				x := newRatFloat(s, 100)
+				x.SetMode(mode)
+				got, want := fn.fn(x), fn.plain(x)
+				if got.Cmp(want) != 0 || got.Prec() != want.Prec() || got.Mode() != want.Mode() {
+					t.Errorf("DefaultContext.%s(%s) in %v = %v, want %v", fn.name, s, mode, got, want)
+				}
+			}
+		}
+	}
+
+	// The functions that are not correctly rounded carry 32 more bits of
+	// the argument, which makes them at least as accurate.
+	for _, s := range []string{"0.1", "-0.75", "0.5"} {
+		x := newRatFloat(s, 100)
+		ref := new(big.Float).SetPrec(400).Set(x)
+		for _, test := range []struct {
+			name      string
+			got, want *big.Float
+		}{
+			{"Tan", DefaultContext.Tan(x), Tan(ref)},
+			{"Asin", DefaultContext.Asin(x), Asin(ref)},
+			{"Acos", DefaultContext.Acos(x), Acos(ref)},
+			{"Atan2", DefaultContext.Atan2(x, newRatFloat("-3", 100)), Atan2(ref, newRatFloat("-3", 400))},
+			{"Sinh", DefaultContext.Sinh(x), Sinh(ref)},
+			{"Cosh", DefaultContext.Cosh(x), Cosh(ref)},
+			{"Tanh", DefaultContext.Tanh(x), Tanh(ref)},
+			{"Sec", DefaultContext.Sec(x), Sec(ref)},
+			{"Csc", DefaultContext.Csc(x), Csc(ref)},
+			{"Cot", DefaultContext.Cot(x), Cot(ref)},
+			{"Gamma", DefaultContext.Gamma(x), Gamma(ref)},
+		} {
+			if test.got.Prec() != 100 || !withinUlp(test.got, test.want) {
+				t.Errorf("DefaultContext.%s(%s) = %v, want %v", test.name, s, test.got, test.want)
+			}
+		}
+	}
+}
+
+func TestContextPrecision(t *testing.T) {
+	for _, prec := range []uint{24, 200, 512} {
+		for _, mode := range roundingModes {
+			c := &Context{Prec: prec, Mode: mode}
+			for _, fn := range contextFunctions(c) {
+				for _, s := range []string{"0.1", "2.5", "-7.3"} {
+					// The argument has 64 bits, but the result is that of the
+					// exact argument at the Context precision.
+					x := newRatFloat(s, 64)
+					want := new(big.Float).SetPrec(prec).SetMode(mode).Set(fn.plain(new(big.Float).SetPrec(prec + 256).Set(x)))
+					got := fn.fn(x)
+					if got.Cmp(want) != 0 || got.Prec() != prec || got.Mode() != mode {
+						t.Errorf("Context{%d, %v}.%s(%s) = %s (%d bits, %v), want %s", prec, mode, fn.name, s,
+							got.Text('g', 20), got.Prec(), got.Mode(), want.Text('g', 20))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestContextConstants(t *testing.T) {
+	for _, prec := range []uint{0, 24, 200, 1000} {
+		for _, mode := range roundingModes {
+			c := &Context{Prec: prec, Mode: mode}
+			wantPrec, wantMode := prec, mode
+			if prec == 0 {
+				wantPrec, wantMode = 53, big.ToNearestEven
+			}
+			for _, test := range []struct {
+				name      string
+				got, want *big.Float
+			}{
+				{"Pi", c.Pi(), ComputePi(wantPrec + 256)},
+				{"E", c.E(), ComputeE(wantPrec + 256)},
+				{"Ln2", c.Ln2(), ComputeLn2(wantPrec + 256)},
+			} {
+				want := new(big.Float).SetPrec(wantPrec).SetMode(wantMode).Set(test.want)
+				if test.got.Cmp(want) != 0 || test.got.Prec() != wantPrec || test.got.Mode() != wantMode {
+					t.Errorf("Context{%d, %v}.%s() = %s (%d bits, %v), want %s", prec, mode, test.name,
+						test.got.Text('g', 20), test.got.Prec(), test.got.Mode(), want.Text('g', 20))
+				}
+			}
+		}
+	}
+}
+
+func TestContextGuardBits(t *testing.T) {
+	x := newRatFloat("2.5", 200)
+	for _, guard := range []uint{1, 8, 32, 300} {
+		c := &Context{GuardBits: guard}
+		for _, fn := range contextFunctions(c) {
+			if got, want := fn.fn(x), fn.plain(x); got.Cmp(want) != 0 {
+				t.Errorf("Context{GuardBits: %d}.%s(2.5) = %v, want %v", guard, fn.name, got, want)
+			}
+		}
+	}
+}
+
+func TestContextMaxIterations(t *testing.T) {
+	// With a single approximation of one guard bit, most results can not
+	// be proven correctly rounded. They are still within a unit in the
+	// last place.
+	c := &Context{GuardBits: 1, MaxIterations: 1}
+	for _, fn := range contextFunctions(c) {
+		for _, s := range []string{"0.1", "2.5", "7.3"} {
+			x := newRatFloat(s, 200)
+			got := fn.fn(x)
+			want := fn.plain(new(big.Float).SetPrec(400).Set(x))
+			if !withinUlp(got, want) {
+				t.Errorf("Context{GuardBits: 1, MaxIterations: 1}.%s(%s) = %v, want %v", fn.name, s, got, want)
+			}
+		}
+	}
+}
+
+func TestDigitsToBits(t *testing.T) {
+	tests := []struct {
+		digits, bits uint
+	}{
+		{0, 0},
+		{1, 4},
+		{15, 50},
+		{16, 54},
+		{34, 113},
+		{100, 333},
+		{1000000, 3321929},
+	}
+	for _, test := range tests {
+		if got := DigitsToBits(test.digits); got != test.bits {
+			t.Errorf("DigitsToBits(%d) = %d, want %d", test.digits, got, test.bits)
+		}
+	}
+}
+
+func TestBitsToDigits(t *testing.T) {
+	tests := []struct {
+		bits, digits uint
+	}{
+		{0, 0},
+		{24, 7},
+		{53, 15},
+		{64, 19},
+		{113, 34},
+		{1000, 301},
+	}
+	for _, test := range tests {
+		if got := BitsToDigits(test.bits); got != test.digits {
+			t.Errorf("BitsToDigits(%d) = %d, want %d", test.bits, got, test.digits)
+		}
+	}
+
+	// Every precision holds the digits it is said to, and needs no more
+	// than the bits it is given.
+	for bits := uint(1); bits < 5000; bits++ {
+		if DigitsToBits(BitsToDigits(bits)) > bits {
+			t.Errorf("DigitsToBits(BitsToDigits(%d)) = %d", bits, DigitsToBits(BitsToDigits(bits)))
+		}
+	}
+}
+
+func BenchmarkContext(b *testing.B) {
+	x := newRatFloat("2.5", 64)
+	for _, prec := range []uint{128, 512} {
+		c := &Context{Prec: prec}
+		for _, fn := range contextFunctions(c) {
+			b.Run(fmt.Sprintf("%s/precision_%d", fn.name, prec), func(b *testing.B) {
+				for b.Loop() {
+					fn.fn(x)
+				}
+			})
+		}
+	}
+}
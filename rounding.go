@@ -0,0 +1,108 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"math/big"
+)
+
+const (
+	// zivGuardBits is the number of bits beyond the target precision used
+	// for the first approximation in correctlyRounded. Each retry doubles
+	// it.
+	zivGuardBits = 32
+
+	// zivErrorBits bounds the error of the approximations handed to
+	// correctlyRounded: a value v computed with work bits is within
+	// 2^(exponent(v) - work + zivErrorBits) of the exact result, that is
+	// two units in its last place.
+	zivErrorBits = 1
+
+	// zivMinGuardLimit is the smallest number of guard bits
+	// correctlyRounded gives up at, whatever the target precision.
+	zivMinGuardLimit = 1024
+)
+
+// roundNudged sets z to a + sign·δ, for a δ > 0 too small to matter,
+// rounded to the precision of z in its rounding mode, and returns z. It
+// rounds every value strictly between a and a + sign·2^(exp-p-4), where
+// exp is the binary exponent of a and p the larger of the precisions of a
+// and z, the same way, as no rounding boundary lies between them. This
+// gives the correctly rounded f(x) = a + c for tiny x, where c has the
+// sign of sign and is known to be that small, which the Ziv loop of
+// correctlyRounded can never separate from a.
+func roundNudged(z, a *big.Float, sign int) *big.Float {
+	p := max(a.Prec(), z.Prec())
+	d := new(big.Float).SetMantExp(big.NewFloat(float64(sign)), a.MantExp(nil)-int(p)-5)
+
+	return z.Set(new(big.Float).SetPrec(p+8).Add(a, d))
+}
+
+// correctlyRounded sets z to a value correctly rounded to the precision
+// of z in its rounding mode, in the style of Ziv's strategy, and returns
+// z. The precision of z must not be 0.
+//
+// approx(work) returns an approximation with work bits that is accurate to
+// within zivErrorBits of its last place. If both ends of that error bound
+// round to the same value, that value is the correctly rounded result;
+// otherwise the approximation is repeated with twice as many guard bits.
+// approx may read the arguments of the function even if z is one of them,
+// as z is only written once the result is known.
+//
+// A result that is exactly representable in one more bit than z can never
+// be separated from its neighbours this way. If exact is not nil, it
+// reports whether the candidate c, the approximation rounded to that many
+// bits, is the exact result, which then rounds directly. Without it, and
+// for the rare inputs that are extraordinarily close to a rounding
+// boundary, the loop stops after max(4·prec, zivMinGuardLimit) guard bits
+// and sets z to the approximation rounded to nearest, along with
+// ErrNoConvergence.
+//
+// The GuardBits and MaxIterations of c, which may be nil, replace the
+// number of guard bits of the first approximation and limit the number of
+// approximations.
+func (c *Context) correctlyRounded(z *big.Float, approx func(work uint) *big.Float, exact func(c *big.Float) bool) (*big.Float, error) {
+	prec, mode := z.Prec(), z.Mode()
+	limit := max(4*prec, zivMinGuardLimit)
+
+	s := getScratch()
+	defer s.release()
+
+	for i, guard := 1, c.guardBits(); ; i, guard = i+1, guard*2 {
+		work := prec + guard
+		v := approx(work)
+		if v.IsInf() || v.Sign() == 0 {
+			return z.Set(v), nil
+		}
+
+		e := s.float(work).SetMantExp(one, v.MantExp(nil)-int(work)+zivErrorBits)
+		lo := s.float(prec).SetMode(mode).Set(s.float(work).SetMode(big.ToNegativeInf).Sub(v, e))
+		hi := s.float(prec).SetMode(mode).Set(s.float(work).SetMode(big.ToPositiveInf).Add(v, e))
+		if lo.Cmp(hi) == 0 {
+			return z.Set(lo), nil
+		}
+
+		if exact != nil {
+			c := s.float(prec + 1).Set(v)
+			if exact(c) {
+				return z.Set(c), nil
+			}
+		}
+
+		if guard >= limit || c != nil && c.MaxIterations > 0 && i >= c.MaxIterations {
+			return z.SetMode(big.ToNearestEven).Set(v).SetMode(mode), ErrNoConvergence
+		}
+	}
+}
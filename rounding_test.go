@@ -0,0 +1,293 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+var roundingModes = []big.RoundingMode{
+	big.ToNearestEven, big.ToNearestAway, big.ToZero, big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf,
+}
+
+var correctlyRoundedFunctions = []struct {
+	name string
+	fn   func(x *big.Float) *big.Float
+}{
+	{"Exp", Exp},
+	{"Log", Log},
+	{"Sin", Sin},
+	{"Cos", Cos},
+	{"Atan", Atan},
+	{"Sqrt", Sqrt},
+	{"Pow(x, 1/3)", func(x *big.Float) *big.Float { return Pow(x, newRatFloat("1/3", 24)) }},
+	{"Pow(x, -7.3)", func(x *big.Float) *big.Float { return Pow(x, newRatFloat("-7.3", 24)) }},
+	{"Pow(x, 13)", func(x *big.Float) *big.Float { return Pow(x, newRatFloat("13", 24)) }},
+}
+
+// newRatFloat returns the rational s correctly rounded to prec bits.
+func newRatFloat(s string, prec uint) *big.Float {
+	r, _ := new(big.Rat).SetString(s)
+
+	return new(big.Float).SetPrec(prec).SetRat(r)
+}
+
+func TestCorrectRounding(t *testing.T) {
+	values := []string{"0.1", "1/3", "2.5", "7.3", "31.7", "1e-30", "1000.1", "355/113", "1.0000001", "0.99999999999"}
+
+	for _, fn := range correctlyRoundedFunctions {
+		for _, prec := range []uint{24, 53, 64, 200, 1000} {
+			for _, s := range values {
+				x := newRatFloat(s, prec)
+
+				// With 256 more bits, rounding the reference in a given
+				// mode gives the correctly rounded result.
+				ref := fn.fn(new(big.Float).SetPrec(prec + 256).Set(x))
+
+				for _, mode := range roundingModes {
+					t.Run(fmt.Sprintf("%s(%s)/precision_%d/%v", fn.name, s, prec, mode), func(t *testing.T) {
+						got := fn.fn(new(big.Float).SetPrec(prec).SetMode(mode).Set(x))
+						want := new(big.Float).SetPrec(prec).SetMode(mode).Set(ref)
+						if got.Cmp(want) != 0 || got.Prec() != prec || got.Mode() != mode {
+							t.Errorf("got %s (%d bits, %v), want %s", got.Text('p', 0), got.Prec(), got.Mode(), want.Text('p', 0))
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestCorrectRoundingDirected(t *testing.T) {
+	x := new(big.Float).SetPrec(100).SetInt64(1)
+	lo := Exp(new(big.Float).Copy(x).SetMode(big.ToNegativeInf))
+	hi := Exp(new(big.Float).Copy(x).SetMode(big.ToPositiveInf))
+
+	// e lies strictly between the two, which are a unit in the last place
+	// apart.
+	e := Exp(new(big.Float).SetPrec(400).SetInt64(1))
+	if lo.Cmp(e) >= 0 || hi.Cmp(e) <= 0 {
+		t.Errorf("Exp(1) rounded down = %v and up = %v do not bracket e", lo, hi)
+	}
+	ulp := new(big.Float).SetMantExp(one, lo.MantExp(nil)-int(lo.Prec()))
+	if d := new(big.Float).Sub(hi, lo); d.Cmp(ulp) != 0 {
+		t.Errorf("Exp(1) rounded up and down differ by %v, want %v", d, ulp)
+	}
+}
+
+func TestCorrectRoundingTiny(t *testing.T) {
+	// For tiny x each function lies just off a power of two a, on the side
+	// given by dir, too close for the Ziv loop to ever decide. Rounded away
+	// from a it must give the neighbouring float on that side, otherwise a.
+	const prec = 53
+	pow2 := func(k int) *big.Float { return new(big.Float).SetMantExp(one, k) }
+	tests := []struct {
+		name string
+		fn   func(z, x *big.Float) (*big.Float, error)
+		a    func(x *big.Float) *big.Float
+		dir  func(x *big.Float) int
+	}{
+		{"Sin", func(z, x *big.Float) (*big.Float, error) { return sinTo(context.Background(), nil, z, x) },
+			func(x *big.Float) *big.Float { return x }, func(x *big.Float) int { return -x.Sign() }},
+		{"Atan", func(z, x *big.Float) (*big.Float, error) { return atanTo(context.Background(), nil, z, x) },
+			func(x *big.Float) *big.Float { return x }, func(x *big.Float) int { return -x.Sign() }},
+		{"Tan", func(_, x *big.Float) (*big.Float, error) { return Tan(x), nil },
+			func(x *big.Float) *big.Float { return x }, func(x *big.Float) int { return x.Sign() }},
+		{"Cos", func(z, x *big.Float) (*big.Float, error) { return cosTo(context.Background(), nil, z, x) },
+			func(*big.Float) *big.Float { return one }, func(*big.Float) int { return -1 }},
+		{"Exp", func(z, x *big.Float) (*big.Float, error) { return expTo(context.Background(), nil, z, x) },
+			func(*big.Float) *big.Float { return one }, func(x *big.Float) int { return x.Sign() }},
+	}
+	for _, tt := range tests {
+		for _, k := range []int{-600, -2000} {
+			for _, neg := range []bool{false, true} {
+				x := pow2(k).SetPrec(prec)
+				if neg {
+					x.Neg(x)
+				}
+				a, dir := tt.a(x), tt.dir(x)
+				for _, mode := range roundingModes {
+					x.SetMode(mode)
+					got, err := tt.fn(new(big.Float).SetPrec(prec).SetMode(mode), x)
+					if err != nil {
+						t.Errorf("%s(%v) in mode %v: unexpected error %v", tt.name, x, mode, err)
+						continue
+					}
+
+					// Which way the mode rounds a value just off a.
+					var away int
+					switch mode {
+					case big.ToZero:
+						away = -a.Sign()
+					case big.AwayFromZero:
+						away = a.Sign()
+					case big.ToNegativeInf:
+						away = -1
+					case big.ToPositiveInf:
+						away = 1
+					}
+					want := new(big.Float).SetPrec(prec + 2).Set(a)
+					if away == dir {
+						// a is a power of two, so the float below it in
+						// magnitude is half as far off as the one above.
+						e := a.MantExp(nil) - prec
+						if dir != a.Sign() {
+							e--
+						}
+						want.Add(want, new(big.Float).SetMantExp(big.NewFloat(float64(dir)), e))
+					}
+					if got.Cmp(want) != 0 {
+						t.Errorf("%s(%v) in mode %v = %v, want %v", tt.name, x, mode, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCorrectRoundingExact(t *testing.T) {
+	tests := []struct {
+		name string
+		got  func(mode big.RoundingMode) *big.Float
+		want string
+	}{
+		{"Sqrt(4)", func(mode big.RoundingMode) *big.Float {
+			return Sqrt(new(big.Float).SetMode(mode).SetInt64(4))
+		}, "2"},
+		{"Sqrt(2.25)", func(mode big.RoundingMode) *big.Float {
+			return Sqrt(new(big.Float).SetMode(mode).SetFloat64(2.25))
+		}, "1.5"},
+		{"Pow(4, 0.5)", func(mode big.RoundingMode) *big.Float {
+			return Pow(new(big.Float).SetMode(mode).SetInt64(4), big.NewFloat(0.5))
+		}, "2"},
+		{"Pow(9, 1.5)", func(mode big.RoundingMode) *big.Float {
+			return Pow(new(big.Float).SetMode(mode).SetInt64(9), big.NewFloat(1.5))
+		}, "27"},
+		{"Pow(16, -0.25)", func(mode big.RoundingMode) *big.Float {
+			return Pow(new(big.Float).SetMode(mode).SetInt64(16), big.NewFloat(-0.25))
+		}, "0.5"},
+		{"Pow(2, -3)", func(mode big.RoundingMode) *big.Float {
+			return Pow(new(big.Float).SetMode(mode).SetInt64(2), big.NewFloat(-3))
+		}, "0.125"},
+		{"Pow(3, 40)", func(mode big.RoundingMode) *big.Float {
+			return Pow(new(big.Float).SetPrec(64).SetMode(mode).SetInt64(3), big.NewFloat(40))
+		}, "12157665459056928801"},
+		{"Log(1)", func(mode big.RoundingMode) *big.Float {
+			return Log(new(big.Float).SetMode(mode).SetInt64(1))
+		}, "0"},
+		{"Exp(0)", func(mode big.RoundingMode) *big.Float {
+			return Exp(new(big.Float).SetMode(mode).SetInt64(0))
+		}, "1"},
+	}
+	for _, test := range tests {
+		for _, mode := range roundingModes {
+			got := test.got(mode)
+			if got.Text('f', -1) != test.want {
+				t.Errorf("%s in %v = %s, want %s", test.name, mode, got.Text('f', -1), test.want)
+			}
+		}
+	}
+
+	// Pow leaves its arguments alone.
+	y := big.NewFloat(-2)
+	if got := Pow(big.NewFloat(4), y); got.Cmp(big.NewFloat(0.0625)) != 0 || y.Cmp(big.NewFloat(-2)) != 0 {
+		t.Errorf("Pow(4, -2) = %v, y = %v", got, y)
+	}
+}
+
+func BenchmarkCorrectlyRounded(b *testing.B) {
+	for _, fn := range correctlyRoundedFunctions {
+		for _, prec := range []uint{53, 256, 1000} {
+			b.Run(fmt.Sprintf("%s/precision_%d", fn.name, prec), func(b *testing.B) {
+				x := newRatFloat("2.5", prec)
+				for b.Loop() {
+					fn.fn(x)
+				}
+			})
+		}
+	}
+}
+
+var toFunctions = []struct {
+	name  string
+	to    func(z, x *big.Float) *big.Float
+	plain func(x *big.Float) *big.Float
+}{
+	{"ExpTo", ExpTo, Exp},
+	{"LogTo", LogTo, Log},
+	{"SinTo", SinTo, Sin},
+	{"CosTo", CosTo, Cos},
+	{"AtanTo", AtanTo, Atan},
+	{"SqrtTo", SqrtTo, Sqrt},
+	{"PowTo(z, x, 1/3)", func(z, x *big.Float) *big.Float { return PowTo(z, x, newRatFloat("1/3", 24)) },
+		func(x *big.Float) *big.Float { return Pow(x, newRatFloat("1/3", 24)) }},
+}
+
+func TestToVariants(t *testing.T) {
+	for _, fn := range toFunctions {
+		for _, s := range []string{"0", "0.1", "2.5", "-7.3", "1e-30", "1000.1"} {
+			x := newRatFloat(s, 100).SetMode(big.ToPositiveInf)
+			want := fn.plain(x)
+
+			// A zero z takes the precision of x.
+			if got := fn.to(new(big.Float).SetMode(x.Mode()), x); got.Cmp(want) != 0 || got.Prec() != want.Prec() {
+				t.Errorf("%s(0 bits, %s) = %v (%d bits), want %v (%d bits)", fn.name, s, got, got.Prec(), want, want.Prec())
+			}
+
+			// z may be x.
+			z := new(big.Float).Copy(x)
+			if got := fn.to(z, z); got != z || got.Cmp(want) != 0 {
+				t.Errorf("%s(x, x) with x = %s = %v, want %v", fn.name, s, got, want)
+			}
+
+			// The result rounds to the precision and mode of z.
+			for _, mode := range roundingModes {
+				z := new(big.Float).SetPrec(200).SetMode(mode)
+				want := fn.plain(new(big.Float).SetPrec(200).SetMode(mode).Set(x))
+				if got := fn.to(z, x); got.Cmp(want) != 0 || got.Prec() != 200 || got.Mode() != mode {
+					t.Errorf("%s(200 bits %v, %s) = %v, want %v", fn.name, mode, s, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestToAllocations(t *testing.T) {
+	x := newRatFloat("0.7", 128)
+	z := new(big.Float).SetPrec(128)
+	for _, fn := range toFunctions[:4] {
+		// Summing a series at 128 bits used to take over a thousand
+		// allocations.
+		if allocs := testing.AllocsPerRun(100, func() { fn.to(z, x) }); allocs > 200 {
+			t.Errorf("%s allocates %v times per call", fn.name, allocs)
+		}
+	}
+}
+
+func BenchmarkToVariants(b *testing.B) {
+	for _, fn := range toFunctions {
+		b.Run(fn.name+"/precision_128", func(b *testing.B) {
+			x := newRatFloat("2.5", 128)
+			z := new(big.Float).SetPrec(128)
+			b.ReportAllocs()
+			for b.Loop() {
+				fn.to(z, x)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"math/big"
+	"sync"
+)
+
+// scratch is a set of temporary big.Floats that a computation borrows from
+// scratchPool and hands back when it is done, so that the mantissa storage
+// is reused from one call to the next instead of being garbage.
+type scratch struct {
+	floats []*big.Float
+	used   int
+}
+
+var scratchPool = sync.Pool{
+	New: func() any { return new(scratch) },
+}
+
+// getScratch returns an empty scratch set from the pool.
+func getScratch() *scratch {
+	return scratchPool.Get().(*scratch)
+}
+
+// float returns a temporary with value 0, precision prec and the default
+// rounding mode. It stays valid until the scratch set is released.
+func (s *scratch) float(prec uint) *big.Float {
+	if s.used == len(s.floats) {
+		s.floats = append(s.floats, new(big.Float))
+	}
+	f := s.floats[s.used]
+	s.used++
+
+	// Setting the value first keeps SetPrec from rounding a stale value.
+	return f.SetInt64(0).SetMode(big.ToNearestEven).SetPrec(prec)
+}
+
+// release returns s to the pool. None of its temporaries may be used
+// afterwards.
+func (s *scratch) release() {
+	s.used = 0
+	scratchPool.Put(s)
+}
@@ -14,11 +14,22 @@
 
 package bigmath
 
-import "math/big"
+import (
+	"math/big"
+)
 
 // Sec calculates sec(x) as 1/cos(x).
+//
+// The special case is:
+//
+//	Sec(±Inf) = +Inf, and SecE returns ErrDomain
 func Sec(x *big.Float) *big.Float {
 	precision := x.Prec()
+	if x.IsInf() {
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return new(big.Float).SetPrec(precision).SetInf(false)
+	}
+
 	cosX := Cos(x)
 
 	result := new(big.Float).SetPrec(precision)
@@ -28,7 +39,20 @@ func Sec(x *big.Float) *big.Float {
 	return result
 }
 
+// SecE is like Sec, and also returns an error wrapping ErrDomain for ±Inf.
+func SecE(x *big.Float) (*big.Float, error) {
+	if x.IsInf() {
+		return Sec(x), funcError(ErrDomain, "Sec", x)
+	}
+
+	return Sec(x), nil
+}
+
 // Asec calculates inverse secant using the identity asec(x) = acos(1/x).
+//
+// The special case is:
+//
+//	Asec(x) = +Inf if -1 < x < 1, and AsecE returns ErrDomain
 func Asec(x *big.Float) *big.Float {
 	precision := x.Prec()
 
@@ -38,7 +62,7 @@ func Asec(x *big.Float) *big.Float {
 
 	if abs.Cmp(one) < 0 {
 		result := new(big.Float).SetPrec(precision)
-		result.SetInf(false) // Return NaN for out of domain
+		result.SetInf(false) // Return +Inf for out of domain (big.Float has no NaN)
 
 		return result
 	}
@@ -49,6 +73,16 @@ func Asec(x *big.Float) *big.Float {
 	return Acos(reciprocal)
 }
 
+// AsecE is like Asec, and also returns an error wrapping ErrDomain for
+// -1 < x < 1.
+func AsecE(x *big.Float) (*big.Float, error) {
+	if new(big.Float).Abs(x).Cmp(one) < 0 {
+		return Asec(x), funcError(ErrDomain, "Asec", x)
+	}
+
+	return Asec(x), nil
+}
+
 // Sech calculates hyperbolic secant using the formula: sech(x) = 1/cosh(x)
 // This is a placeholder implementation.
 func Sech(x *big.Float) *big.Float {
@@ -75,36 +109,17 @@ func Asech(x *big.Float) *big.Float {
 	return result
 }
 
-// secSeries calculates sec(x) using direct series expansion.
-// Uses the series: sec(x) = 1 + x²/2 + 5x⁴/24 + 61x⁶/720 + ...
+// secSeries calculates sec(x) as the reciprocal of the Taylor series
+// cos(x) = 1 - x²/2! + x⁴/4! - x⁶/6! + ..., whose coefficients, unlike the
+// Euler numbers of sec(x) = 1 + x²/2 + 5x⁴/24 + 61x⁶/720 + ..., need no
+// table, summed after reducing x by a multiple of π/2. x must be finite.
 // This is a package-private method for performance comparison.
 func secSeries(x *big.Float) *big.Float {
 	precision := x.Prec()
 
-	// Euler numbers for sec(x) series (simplified approximation)
-	result := new(big.Float).SetPrec(precision).SetInt64(1)
-	term := new(big.Float).SetPrec(precision).SetInt64(1)
-	xSquared := new(big.Float).SetPrec(precision).Mul(x, x)
-
-	// Coefficients for sec(x) = 1 + x²/2! + 5x⁴/4! + 61x⁶/6! + ...
-	eulerCoeffs := []float64{1.0 / 2, 5.0 / 24, 61.0 / 720, 1385.0 / 40320}
-
-	for i := 0; i < len(eulerCoeffs) && i < 20; i++ {
-		term.Mul(term, xSquared)
-		coeff := new(big.Float).SetPrec(precision).SetFloat64(eulerCoeffs[i])
-		termValue := new(big.Float).SetPrec(precision).Mul(term, coeff)
-
-		result.Add(result, termValue)
-
-		// Check convergence
-		convergenceThreshold := new(big.Float).SetPrec(precision).Quo(
-			new(big.Float).SetInt64(1),
-			new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision/4)), nil)),
-		)
-		if termValue.Cmp(convergenceThreshold) < 0 {
-			break
-		}
-	}
+	// Reduced by a multiple of π/2, cos(x) is ±cos r or ±sin r, and the
+	// series of sin r keeps its relative precision near the poles.
+	_, cos := taylorSinCos(x, seriesWork(precision))
 
-	return result
+	return new(big.Float).SetPrec(precision).Quo(one, cos)
 }
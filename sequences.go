@@ -0,0 +1,438 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// harmonicMaxTerms is the largest number of terms of the asymptotic
+// expansion HarmonicFloat will use before switching to the exact sum.
+const harmonicMaxTerms = 200
+
+// Fibonacci returns the n-th Fibonacci number, with F(0) = 0, F(1) = 1
+// and F(n) = F(n-1) + F(n-2), using the fast doubling method.
+//
+// Negative n follow the same recurrence, giving F(-n) = (-1)^(n+1)·F(n).
+func Fibonacci(n int64) *big.Int {
+	if n < 0 {
+		f, _ := fibonacciPair(uint64(-n))
+		if n&1 == 0 {
+			f.Neg(f)
+		}
+
+		return f
+	}
+
+	f, _ := fibonacciPair(uint64(n))
+
+	return f
+}
+
+// Lucas returns the n-th Lucas number, with L(0) = 2, L(1) = 1 and
+// L(n) = L(n-1) + L(n-2), using the fast doubling method.
+//
+// Negative n follow the same recurrence, giving L(-n) = (-1)^n·L(n).
+func Lucas(n int64) *big.Int {
+	m := uint64(n)
+	if n < 0 {
+		m = uint64(-n)
+	}
+
+	// L(n) = 2·F(n+1) - F(n)
+	f, f1 := fibonacciPair(m)
+	l := f1.Lsh(f1, 1)
+	l.Sub(l, f)
+
+	if n < 0 && n&1 == 1 {
+		l.Neg(l)
+	}
+
+	return l
+}
+
+// fibonacciPair returns F(n) and F(n+1) using the doubling identities
+//
+//	F(2k)   = F(k)·(2·F(k+1) - F(k))
+//	F(2k+1) = F(k)² + F(k+1)²
+func fibonacciPair(n uint64) (*big.Int, *big.Int) {
+	a := big.NewInt(0)
+	b := big.NewInt(1)
+	c := new(big.Int)
+	d := new(big.Int)
+	t := new(big.Int)
+
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		// c = F(2k), d = F(2k+1)
+		c.Lsh(b, 1)
+		c.Sub(c, a)
+		c.Mul(c, a)
+		d.Mul(a, a)
+		t.Mul(b, b)
+		d.Add(d, t)
+
+		if n>>uint(i)&1 == 0 {
+			a, c = c, a
+			b, d = d, b
+		} else {
+			a, d = d, a
+			b.Add(a, c)
+		}
+	}
+
+	return a, b
+}
+
+// Catalan returns the n-th Catalan number C(2n, n) / (n+1).
+//
+// Negative n return 0, and nil is returned if the result is too large to
+// be represented.
+func Catalan(n int64) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+	if n > math.MaxInt64/2 {
+		return nil
+	}
+
+	result := Binomial(2*n, n)
+
+	return result.Quo(result, big.NewInt(n+1))
+}
+
+// StirlingFirst returns the unsigned Stirling number of the first kind
+// [n k], the number of permutations of n items with exactly k cycles.
+//
+// The signed Stirling number s(n, k) is (-1)^(n-k)·[n k]. Negative n or k
+// return 0.
+func StirlingFirst(n, k int64) *big.Int {
+	switch {
+	case n < 0 || k < 0 || k > n:
+		return big.NewInt(0)
+	case k == n:
+		return big.NewInt(1)
+	case k == 0:
+		return big.NewInt(0)
+	}
+
+	// Work down the rows of [m j] = [m-1 j-1] + (m-1)·[m-1 j], keeping
+	// only the columns up to k.
+	row := make([]*big.Int, k+1)
+	row[0] = big.NewInt(1)
+	for j := int64(1); j <= k; j++ {
+		row[j] = new(big.Int)
+	}
+
+	t := new(big.Int)
+	for m := int64(1); m <= n; m++ {
+		mm1 := big.NewInt(m - 1)
+		for j := min(m, k); j >= 1; j-- {
+			t.Mul(mm1, row[j])
+			row[j].Add(row[j-1], t)
+		}
+		row[0].SetInt64(0)
+	}
+
+	return row[k]
+}
+
+// StirlingSecond returns the Stirling number of the second kind {n k},
+// the number of ways of partitioning n items into k non-empty subsets.
+//
+//	{n k} = 1/k! · Σ_{j=0}^{k} (-1)^(k-j)·C(k, j)·j^n
+//
+// Negative n or k return 0.
+func StirlingSecond(n, k int64) *big.Int {
+	switch {
+	case n < 0 || k < 0 || k > n:
+		return big.NewInt(0)
+	case k == n:
+		return big.NewInt(1)
+	case k == 0:
+		return big.NewInt(0)
+	}
+
+	sum := new(big.Int)
+	coef := big.NewInt(1) // C(k, j)
+	bn := big.NewInt(n)
+	t := new(big.Int)
+	for j := int64(0); j <= k; j++ {
+		t.Exp(big.NewInt(j), bn, nil)
+		t.Mul(t, coef)
+		if (k-j)&1 == 1 {
+			sum.Sub(sum, t)
+		} else {
+			sum.Add(sum, t)
+		}
+
+		// C(k, j+1) = C(k, j)·(k-j)/(j+1)
+		coef.Mul(coef, big.NewInt(k-j))
+		coef.Quo(coef, big.NewInt(j+1))
+	}
+
+	return sum.Quo(sum, Factorial(k))
+}
+
+// Bell returns the n-th Bell number, the number of ways of partitioning
+// n items into any number of non-empty subsets, using the Bell triangle.
+//
+// Negative n return 0.
+func Bell(n int64) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+	if n == 0 {
+		return big.NewInt(1)
+	}
+
+	// Each row of the triangle starts with the last entry of the row
+	// before it, and each entry is the sum of its left neighbour and the
+	// entry above that neighbour.
+	row := []*big.Int{big.NewInt(1)}
+	for i := int64(1); i < n; i++ {
+		next := make([]*big.Int, 0, i+1)
+		next = append(next, row[len(row)-1])
+		for j := range row {
+			next = append(next, new(big.Int).Add(next[j], row[j]))
+		}
+		row = next
+	}
+
+	return row[len(row)-1]
+}
+
+// Partitions returns p(n), the number of ways of writing n as a sum of
+// positive integers without regard to order, using Euler's pentagonal
+// number recurrence
+//
+//	p(n) = Σ_{k≥1} (-1)^(k+1)·(p(n - k(3k-1)/2) + p(n - k(3k+1)/2))
+//
+// Negative n return 0.
+func Partitions(n int64) *big.Int {
+	if n < 0 {
+		return big.NewInt(0)
+	}
+
+	p := make([]*big.Int, n+1)
+	p[0] = big.NewInt(1)
+	for m := int64(1); m <= n; m++ {
+		sum := new(big.Int)
+		for k := int64(1); ; k++ {
+			g1 := k * (3*k - 1) / 2
+			if g1 > m {
+				break
+			}
+			g2 := g1 + k
+
+			if k&1 == 1 {
+				sum.Add(sum, p[m-g1])
+				if g2 <= m {
+					sum.Add(sum, p[m-g2])
+				}
+			} else {
+				sum.Sub(sum, p[m-g1])
+				if g2 <= m {
+					sum.Sub(sum, p[m-g2])
+				}
+			}
+		}
+		p[m] = sum
+	}
+
+	return p[n]
+}
+
+// Harmonic returns the n-th harmonic number H(n) = 1 + 1/2 + … + 1/n
+// exactly. The sum is evaluated by binary splitting over the least common
+// multiple of the denominators, so that only one reduction to lowest terms
+// is needed. Values of n less than 1 return 0.
+func Harmonic(n int64) *big.Rat {
+	if n < 1 {
+		return new(big.Rat)
+	}
+
+	num, den := harmonicSplit(1, n+1)
+
+	return new(big.Rat).SetFrac(num, den)
+}
+
+// harmonicSplit returns a numerator and the denominator lcm(a, …, b-1)
+// of Σ_{k=a}^{b-1} 1/k. The fraction is not necessarily reduced.
+func harmonicSplit(a, b int64) (num, den *big.Int) {
+	if b-a == 1 {
+		return big.NewInt(1), big.NewInt(a)
+	}
+
+	m := a + (b-a)/2
+	n1, d1 := harmonicSplit(a, m)
+	n2, d2 := harmonicSplit(m, b)
+
+	// With g = gcd(d1, d2),
+	// n1/d1 + n2/d2 = (n1·(d2/g) + n2·(d1/g)) / (d1·(d2/g))
+	g := new(big.Int).GCD(nil, nil, d1, d2)
+	d2.Quo(d2, g)
+	g.Quo(d1, g)
+	n1.Mul(n1, d2)
+	n2.Mul(n2, g)
+
+	return n1.Add(n1, n2), d1.Mul(d1, d2)
+}
+
+// HarmonicFloat returns the n-th harmonic number to the given precision.
+//
+// For large n it uses the asymptotic expansion
+//
+//	H(n) = ln(n) + γ + 1/(2n) - Σ_{k≥1} B(2k) / (2k·n^(2k))
+//
+// where γ is the Euler–Mascheroni constant and B(2k) are the Bernoulli
+// numbers, so n may be far too large for the exact sum. When n is too
+// small for the expansion to reach the precision, the exact sum is
+// rounded instead. Values of n less than 1 return 0.
+func HarmonicFloat(n *big.Int, precision uint) *big.Float {
+	if n.Sign() < 1 {
+		return new(big.Float).SetPrec(precision)
+	}
+
+	work := precision + 32
+
+	terms, ok := harmonicTerms(n, work)
+	if !ok && n.IsInt64() {
+		// Skip reducing the fraction, which costs far more than
+		// rounding it.
+		num, den := harmonicSplit(1, n.Int64()+1)
+		result := new(big.Float).SetPrec(precision).SetInt(num)
+
+		return result.Quo(result, new(big.Float).SetPrec(precision).SetInt(den))
+	}
+
+	nf := new(big.Float).SetPrec(work).SetInt(n)
+	result := Log(nf)
+	result.Add(result, ComputeEulerGamma(work))
+
+	// 1/(2n)
+	t := new(big.Float).SetPrec(work).SetInt64(1)
+	t.Quo(t, nf)
+	t.Quo(t, two)
+	result.Add(result, t)
+
+	// 1/n²
+	invN2 := new(big.Float).SetPrec(work).Mul(nf, nf)
+	invN2.Quo(new(big.Float).SetPrec(work).SetInt64(1), invN2)
+
+	bernoulli := bernoulliEven(terms)
+	power := new(big.Float).SetPrec(work).SetInt64(1)
+	b := new(big.Float).SetPrec(work)
+	for k := 1; k <= terms; k++ {
+		power.Mul(power, invN2)
+
+		b.SetRat(bernoulli[k])
+		b.Quo(b, t.SetInt64(int64(2*k)))
+		b.Mul(b, power)
+		result.Sub(result, b)
+	}
+
+	return result.SetPrec(precision)
+}
+
+// harmonicTerms estimates the number of terms of the asymptotic expansion
+// of H(n) needed to reach the given precision. It reports false if the
+// expansion can not reach it within harmonicMaxTerms terms.
+//
+// The k-th term is about 2·(2k)! / ((2π)^(2k)·2k·n^(2k)) in magnitude.
+func harmonicTerms(n *big.Int, precision uint) (int, bool) {
+	log2n := float64(n.BitLen() - 1)
+	if n.BitLen() < 1000 {
+		nf, _ := new(big.Float).SetInt(n).Float64()
+		log2n = math.Log2(nf)
+	}
+
+	limit := harmonicMaxTerms
+	if !n.IsInt64() {
+		// The exact sum is out of reach, so use as many terms as it takes.
+		limit = math.MaxInt32
+	}
+
+	prev := math.Inf(1)
+	for k := 1; k <= limit; k++ {
+		lg, _ := math.Lgamma(float64(2*k) + 1)
+		size := (1 + lg/math.Ln2) - float64(2*k)*math.Log2(2*math.Pi) -
+			math.Log2(float64(2*k)) - float64(2*k)*log2n
+		if size < -float64(precision) {
+			return k, true
+		}
+		if size > prev {
+			// The terms have started to grow again.
+			return k, false
+		}
+		prev = size
+	}
+
+	return limit, false
+}
+
+// bernoulliCache holds the even Bernoulli numbers B(0), B(2), B(4), …
+// computed so far.
+var bernoulliCache struct {
+	sync.Mutex
+	numbers []*big.Rat
+}
+
+// bernoulliEven returns the even Bernoulli numbers B(0), B(2), …, B(2m)
+// exactly. The values are shared and must not be modified.
+//
+// They are computed from the tangent numbers T(k), which need only integer
+// arithmetic, using B(2k) = (-1)^(k-1)·2k·T(k) / (2^(2k)·(2^(2k) - 1)).
+func bernoulliEven(m int) []*big.Rat {
+	bernoulliCache.Lock()
+	defer bernoulliCache.Unlock()
+
+	if len(bernoulliCache.numbers) > m {
+		return bernoulliCache.numbers[:m+1]
+	}
+
+	// The tangent numbers by Brent and Harvey's in-place recurrence.
+	tangent := make([]*big.Int, m+1)
+	tangent[1] = big.NewInt(1)
+	for k := 2; k <= m; k++ {
+		tangent[k] = new(big.Int).Mul(big.NewInt(int64(k-1)), tangent[k-1])
+	}
+	t := new(big.Int)
+	for k := 2; k <= m; k++ {
+		for j := k; j <= m; j++ {
+			t.Mul(big.NewInt(int64(j-k)), tangent[j-1])
+			tangent[j].Mul(big.NewInt(int64(j-k+2)), tangent[j])
+			tangent[j].Add(tangent[j], t)
+		}
+	}
+
+	numbers := make([]*big.Rat, m+1)
+	numbers[0] = big.NewRat(1, 1)
+	for k := 1; k <= m; k++ {
+		num := new(big.Int).Mul(big.NewInt(int64(2*k)), tangent[k])
+		if k&1 == 0 {
+			num.Neg(num)
+		}
+		p := new(big.Int).Lsh(big.NewInt(1), uint(2*k))
+		den := new(big.Int).Sub(p, big.NewInt(1))
+		den.Mul(den, p)
+		numbers[k] = new(big.Rat).SetFrac(num, den)
+	}
+	bernoulliCache.numbers = numbers
+
+	return numbers
+}
@@ -0,0 +1,299 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFibonacciLucas(t *testing.T) {
+	// Walk the recurrences in both directions from F(0), F(1) and L(0), L(1).
+	f0, f1 := big.NewInt(0), big.NewInt(1)
+	l0, l1 := big.NewInt(2), big.NewInt(1)
+	for n := int64(0); n <= 500; n++ {
+		if got := Fibonacci(n); got.Cmp(f0) != 0 {
+			t.Errorf("Fibonacci(%d) = %v, want %v", n, got, f0)
+		}
+		if got := Lucas(n); got.Cmp(l0) != 0 {
+			t.Errorf("Lucas(%d) = %v, want %v", n, got, l0)
+		}
+		f0, f1 = f1, new(big.Int).Add(f0, f1)
+		l0, l1 = l1, new(big.Int).Add(l0, l1)
+	}
+
+	f0, f1 = big.NewInt(0), big.NewInt(1)
+	l0, l1 = big.NewInt(2), big.NewInt(1)
+	for n := int64(0); n >= -100; n-- {
+		if got := Fibonacci(n); got.Cmp(f0) != 0 {
+			t.Errorf("Fibonacci(%d) = %v, want %v", n, got, f0)
+		}
+		if got := Lucas(n); got.Cmp(l0) != 0 {
+			t.Errorf("Lucas(%d) = %v, want %v", n, got, l0)
+		}
+		// F(n-1) = F(n+1) - F(n)
+		f0, f1 = new(big.Int).Sub(f1, f0), f0
+		l0, l1 = new(big.Int).Sub(l1, l0), l0
+	}
+}
+
+func TestFibonacciIdentities(t *testing.T) {
+	for _, n := range []int64{1000, 4321, 100000} {
+		// F(2n) = F(n)·L(n)
+		want := new(big.Int).Mul(Fibonacci(n), Lucas(n))
+		if got := Fibonacci(2 * n); got.Cmp(want) != 0 {
+			t.Errorf("Fibonacci(%d) != Fibonacci(%d)·Lucas(%d)", 2*n, n, n)
+		}
+
+		// L(n)² - 5·F(n)² = 4·(-1)^n
+		f := Fibonacci(n)
+		l := Lucas(n)
+		got := new(big.Int).Mul(l, l)
+		got.Sub(got, f.Mul(f, f).Mul(f, big.NewInt(5)))
+		want = big.NewInt(4)
+		if n&1 == 1 {
+			want.Neg(want)
+		}
+		if got.Cmp(want) != 0 {
+			t.Errorf("Lucas(%d)² - 5·Fibonacci(%d)² = %v, want %v", n, n, got, want)
+		}
+	}
+}
+
+func TestCatalan(t *testing.T) {
+	want := []int64{1, 1, 2, 5, 14, 42, 132, 429, 1430, 4862, 16796, 58786}
+	for n, w := range want {
+		if got := Catalan(int64(n)); got.Int64() != w {
+			t.Errorf("Catalan(%d) = %v, want %d", n, got, w)
+		}
+	}
+
+	if got := Catalan(-1); got.Sign() != 0 {
+		t.Errorf("Catalan(-1) = %v, want 0", got)
+	}
+	if got := Catalan(math.MaxInt64); got != nil {
+		t.Errorf("Catalan(MaxInt64) = %v, want nil", got)
+	}
+
+	// C(n+1) = Σ C(i)·C(n-i)
+	n := int64(60)
+	sum := new(big.Int)
+	for i := int64(0); i <= n; i++ {
+		sum.Add(sum, new(big.Int).Mul(Catalan(i), Catalan(n-i)))
+	}
+	if got := Catalan(n + 1); got.Cmp(sum) != 0 {
+		t.Errorf("Catalan(%d) = %v, want %v", n+1, got, sum)
+	}
+}
+
+func TestStirlingNumbers(t *testing.T) {
+	tests := []struct {
+		n, k          int64
+		first, second int64
+	}{
+		{0, 0, 1, 1},
+		{1, 0, 0, 0},
+		{4, 5, 0, 0},
+		{-1, 0, 0, 0},
+		{3, -1, 0, 0},
+		{4, 1, 6, 1},
+		{4, 2, 11, 7},
+		{5, 3, 35, 25},
+		{6, 2, 274, 31},
+		{7, 4, 735, 350},
+		{10, 3, 1172700, 9330},
+		{10, 9, 45, 45},
+	}
+
+	for _, test := range tests {
+		if got := StirlingFirst(test.n, test.k); got.Int64() != test.first {
+			t.Errorf("StirlingFirst(%d, %d) = %v, want %d", test.n, test.k, got, test.first)
+		}
+		if got := StirlingSecond(test.n, test.k); got.Int64() != test.second {
+			t.Errorf("StirlingSecond(%d, %d) = %v, want %d", test.n, test.k, got, test.second)
+		}
+	}
+}
+
+func TestStirlingIdentities(t *testing.T) {
+	for _, n := range []int64{1, 12, 75} {
+		// Σ_k [n k] = n! and Σ_k {n k} = Bell(n)
+		first := new(big.Int)
+		second := new(big.Int)
+		for k := int64(0); k <= n; k++ {
+			first.Add(first, StirlingFirst(n, k))
+			second.Add(second, StirlingSecond(n, k))
+		}
+
+		if first.Cmp(Factorial(n)) != 0 {
+			t.Errorf("Σ StirlingFirst(%d, k) = %v, want %d!", n, first, n)
+		}
+		if second.Cmp(Bell(n)) != 0 {
+			t.Errorf("Σ StirlingSecond(%d, k) = %v, want Bell(%d) = %v", n, second, n, Bell(n))
+		}
+	}
+}
+
+func TestBell(t *testing.T) {
+	want := []int64{1, 1, 2, 5, 15, 52, 203, 877, 4140, 21147, 115975, 678570}
+	for n, w := range want {
+		if got := Bell(int64(n)); got.Int64() != w {
+			t.Errorf("Bell(%d) = %v, want %d", n, got, w)
+		}
+	}
+
+	if got := Bell(-1); got.Sign() != 0 {
+		t.Errorf("Bell(-1) = %v, want 0", got)
+	}
+}
+
+func TestPartitions(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{-1, "0"},
+		{0, "1"},
+		{1, "1"},
+		{5, "7"},
+		{10, "42"},
+		{100, "190569292"},
+		{1000, "24061467864032622473692149727991"},
+	}
+
+	for _, test := range tests {
+		if got := Partitions(test.n); got.String() != test.want {
+			t.Errorf("Partitions(%d) = %v, want %s", test.n, got, test.want)
+		}
+	}
+}
+
+func TestHarmonic(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{-1, "0/1"},
+		{0, "0/1"},
+		{1, "1/1"},
+		{2, "3/2"},
+		{3, "11/6"},
+		{5, "137/60"},
+		{10, "7381/2520"},
+		{20, "55835135/15519504"},
+	}
+
+	for _, test := range tests {
+		if got := Harmonic(test.n); got.String() != test.want {
+			t.Errorf("Harmonic(%d) = %v, want %s", test.n, got, test.want)
+		}
+	}
+
+	// H(n) - H(n-1) = 1/n
+	diff := new(big.Rat).Sub(Harmonic(1001), Harmonic(1000))
+	if diff.Cmp(big.NewRat(1, 1001)) != 0 {
+		t.Errorf("Harmonic(1001) - Harmonic(1000) = %v, want 1/1001", diff)
+	}
+}
+
+func TestHarmonicFloat(t *testing.T) {
+	tests := []struct {
+		n    int64
+		prec uint
+	}{
+		{1, 53},
+		{20, 53},
+		{20, 500},
+		{5000, 2000},
+		{100000, 300},
+		{100000, 3000},
+	}
+
+	for _, test := range tests {
+		want := new(big.Float).SetPrec(test.prec + 64).SetRat(Harmonic(test.n))
+		got := HarmonicFloat(big.NewInt(test.n), test.prec)
+		if got.Prec() != test.prec {
+			t.Errorf("HarmonicFloat(%d, %d) has precision %d", test.n, test.prec, got.Prec())
+		}
+		if agree := bitsOfAgreement(got, want); agree < int(test.prec)-2 {
+			t.Errorf("HarmonicFloat(%d, %d) only agrees to %d bits", test.n, test.prec, agree)
+		}
+	}
+
+	if got := HarmonicFloat(big.NewInt(0), 100); got.Sign() != 0 {
+		t.Errorf("HarmonicFloat(0) = %v, want 0", got)
+	}
+
+	// H(10^30) ≈ ln(10^30) + γ
+	n, _ := new(big.Int).SetString("1000000000000000000000000000000", 10)
+	got, _ := HarmonicFloat(n, 200).Float64()
+	if want := 30*math.Ln10 + 0.5772156649015329; math.Abs(got-want) > 1e-13 {
+		t.Errorf("HarmonicFloat(10^30) = %v, want %v", got, want)
+	}
+
+	// H(2n) - H(n) → ln(2) + O(1/n)
+	n.Lsh(big.NewInt(1), 400)
+	diff := HarmonicFloat(new(big.Int).Lsh(n, 1), 1000)
+	diff.Sub(diff, HarmonicFloat(n, 1000))
+	if agree := bitsOfAgreement(diff, ComputeLn2(1000)); agree < 395 || agree > 405 {
+		t.Errorf("HarmonicFloat(2^401) - HarmonicFloat(2^400) agrees with ln(2) to %d bits, want about 400", agree)
+	}
+}
+
+func TestBernoulliEven(t *testing.T) {
+	want := []string{"1/1", "1/6", "-1/30", "1/42", "-1/30", "5/66", "-691/2730", "7/6", "-3617/510", "43867/798", "-174611/330"}
+	got := bernoulliEven(len(want) - 1)
+	for k, w := range want {
+		if got[k].String() != w {
+			t.Errorf("B(%d) = %v, want %s", 2*k, got[k], w)
+		}
+	}
+
+	// A smaller request after a larger one uses the cache.
+	if got := bernoulliEven(2); len(got) != 3 || got[2].String() != "-1/30" {
+		t.Errorf("bernoulliEven(2) = %v", got)
+	}
+}
+
+func BenchmarkFibonacci(b *testing.B) {
+	for _, n := range []int64{100, 10000, 1000000} {
+		b.Run(fmt.Sprintf("Fibonacci_%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_ = Fibonacci(n)
+			}
+		})
+	}
+}
+
+func BenchmarkHarmonic(b *testing.B) {
+	for _, n := range []int64{100, 10000} {
+		b.Run(fmt.Sprintf("Harmonic_%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_ = Harmonic(n)
+			}
+		})
+	}
+
+	n := new(big.Int).Lsh(big.NewInt(1), 100)
+	for _, prec := range []uint{256, 1000} {
+		b.Run(fmt.Sprintf("HarmonicFloat_2^100_prec_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				_ = HarmonicFloat(n, prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// The Taylor-based kernels of the package share one series engine,
+// sumSeries, for the series whose consecutive coefficients have a ratio of
+// small integers. It stops at a bound worked out once from the binary
+// exponents of the first term and the precision, caps the number of terms
+// by how fast the powers of the argument fall, and keeps its terms in two
+// scratch buffers, so that a series allocates nothing per term beyond what
+// big.Float.Quo does internally.
+//
+// At lower precisions, e^x, sin x and cos x are summed directly as power
+// series in big.Float arithmetic, after reducing the argument and then
+// halving it a number of times so that the series converges quickly. The
+// halvings are undone with doubling formulas that are well conditioned, so
+// only a few extra bits cover the rounding errors of the whole chain. Every
+// temporary comes from a scratch set, so these kernels allocate little more
+// than their results. At higher precisions the bit-burst algorithm is
+// faster.
+
+const (
+	// expSeriesMaxPrec is the default precision at and above which
+	// expApprox switches from expSeries to expBitBurst.
+	expSeriesMaxPrec = 6000
+
+	// sinCosSeriesMaxPrec is the default precision at and above which
+	// sinCosApprox switches from sinCosSeries to sinCosBitBurst.
+	sinCosSeriesMaxPrec = 24000
+)
+
+// expApprox returns e^x with the precision of x, to within a unit in its
+// last place.
+func expApprox(ctx context.Context, x *big.Float) *big.Float {
+	if x.Prec() >= currentThresholds().ExpBitBurstPrec {
+		return expBitBurst(ctx, x)
+	}
+
+	return expSeries(ctx, x)
+}
+
+// sinCosApprox returns sin(x) and cos(x) with the precision of x, each to
+// within a unit in its last place.
+func sinCosApprox(ctx context.Context, x *big.Float) (sin, cos *big.Float) {
+	if x.Prec() >= currentThresholds().SinCosBitBurstPrec {
+		return sinCosBitBurst(ctx, x)
+	}
+
+	return sinCosSeries(ctx, x)
+}
+
+// seriesHalvings returns the number of times to halve an argument of at
+// most 1 in magnitude before summing a series at work bits. About √work
+// halvings balance the cost of the series against that of undoing them.
+func seriesHalvings(work uint) int {
+	return bits.Len(work) + int(sqrtUint(work)/2)
+}
+
+// sqrtUint returns ⌊√n⌋.
+func sqrtUint(n uint) uint {
+	r := uint(0)
+	for bit := uint(1) << (bits.Len(n) &^ 1); bit > 0; bit >>= 2 {
+		if n >= r+bit {
+			n -= r + bit
+			r = r>>1 + bit
+		} else {
+			r >>= 1
+		}
+	}
+
+	return r
+}
+
+// expSeries calculates e^x by reducing x by ln(2) and summing the series
+// for e^t − 1 on the reduced argument t = r/2^m.
+func expSeries(ctx context.Context, x *big.Float) *big.Float {
+	prec := x.Prec()
+	if result := expSpecial(x); result != nil {
+		return result
+	}
+
+	work := prec + 32 + uint(bits.Len(prec))
+	r, k := reduceLn2(ctx, x, work)
+
+	s := getScratch()
+	defer s.release()
+
+	// Each doubling t ← t·(t + 2), which takes e^t − 1 to e^(2t) − 1, adds
+	// at most a couple of units of rounding error.
+	m := seriesHalvings(work)
+	w := work + uint(bits.Len(uint(m))) + 8
+
+	t := s.float(w).SetMantExp(r, -m)
+	// e^t − 1 = t + t²/2! + t³/3! + …
+	sum := sumSeries(ctx, s, s.float(w), t, t, expm1Ratio)
+
+	// big.Float.Mul allocates when its result is one of its operands, so
+	// the products alternate between two buffers.
+	next := s.float(w)
+	for range m {
+		t.Add(sum, two)
+		next.Mul(sum, t)
+		sum, next = next, sum
+	}
+
+	result := new(big.Float).SetPrec(work).Add(sum, one)
+	result.SetMantExp(result, k)
+
+	return result.SetPrec(prec)
+}
+
+// sinCosSeries calculates sin(x) and cos(x) together by reducing x by π/2
+// and summing the series for 1 − cos t on the reduced argument t = r/2^m.
+func sinCosSeries(ctx context.Context, x *big.Float) (sin, cos *big.Float) {
+	prec := x.Prec()
+
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x), new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	work := prec + 32 + uint(bits.Len(prec))
+	r, quadrant := reduceHalfPi(ctx, x, work)
+
+	s := getScratch()
+	defer s.release()
+
+	// Each doubling v ← 2v·(2 − v), which takes v = 1 − cos t to
+	// 1 − cos 2t, adds at most a couple of units of rounding error.
+	m := seriesHalvings(work)
+	w := work + uint(bits.Len(uint(m))) + 8
+
+	t := s.float(w).SetMantExp(r, -m)
+	// 1 − cos t = t²/2! − t⁴/4! + t⁶/6! − …
+	t2 := s.float(w).Mul(t, t)
+	first := s.float(w).Quo(t2, two)
+	v := sumSeries(ctx, s, s.float(w), first, t2.Neg(t2), oneMinusCosRatio)
+	u := s.float(w)
+	next := s.float(w)
+	for range m {
+		u.Sub(two, v)
+		next.Mul(v, u)
+		v, next = next.SetMantExp(next, 1), v
+	}
+
+	// With |r| <= π/4, v <= 1 − cos(π/4) < 0.3, so neither cos r = 1 − v
+	// nor sin r = ±√(v·(2 − v)) loses any bits to cancellation.
+	c := s.float(w).Sub(one, v)
+	sr := s.float(w)
+	if r.Sign() != 0 {
+		u.Sub(two, v)
+		sr.Mul(v, u)
+		sr.Sqrt(sr)
+		if r.Sign() < 0 {
+			sr.Neg(sr)
+		}
+	}
+
+	return unreduceHalfPi(sr, c, quadrant, prec)
+}
+
+// A seriesRatio returns the ratio num/den of the coefficients of the terms
+// of degree n and n − 1 of a power series, for n ≥ 1.
+type seriesRatio func(n uint64) (num, den uint64)
+
+// sumSeries sets sum to term₀ + term₁ + term₂ + …, where term₀ = first and
+//
+//	termₙ = termₙ₋₁ · y · num(n)/den(n),
+//
+// to the precision of sum, and returns sum. The ratios num(n)/den(n) must
+// be at most 1, and |sum| must be at least |first|/2 with the terms falling
+// by at least half once they are below it, which holds for every series
+// here on its reduced argument, so that the sum can stop at the first term
+// below 2^-(prec+3) of first. Callers reduce their arguments so that
+// |y| < 1, which bounds the number of terms; a larger y is summed until
+// its terms fall below the bound, with the cancellation of the terms
+// larger than the sum unaccounted for.
+func sumSeries(ctx context.Context, s *scratch, sum, first, y *big.Float, ratio seriesRatio) *big.Float {
+	sum.Set(first)
+	if first.Sign() == 0 || y.Sign() == 0 {
+		return sum
+	}
+
+	w := sum.Prec()
+	bound := first.MantExp(nil) - int(w) - 3
+	limit := powerSeriesTerms(w+3, y)
+
+	// Every term may be off by 2^(bound-guard), which keeps the error of
+	// the whole sum below 2^bound for up to 2^(guard/2 - 2) terms. As the
+	// terms fall they need fewer bits for that, which makes the later ones
+	// cheaper to compute and to add.
+	guard := 2*bits.Len(w) + 4
+
+	// big.Float.Mul allocates when its result is one of its operands, so
+	// the products alternate between the two term buffers.
+	term := s.float(w).Set(first)
+	next := s.float(w)
+	d := s.float(64)
+	for n := uint64(1); limit == 0 || n <= limit; n++ {
+		checkCtx(ctx)
+		p := uint(min(term.MantExp(nil)-bound+guard, int(w)))
+		num, den := ratio(n)
+		next.SetPrec(p).Mul(term, y)
+		if num != 1 {
+			term.SetPrec(p).Mul(next, d.SetUint64(num))
+			term, next = next, term
+		}
+		term.SetPrec(p).Quo(next, d.SetUint64(den))
+		if term.Sign() == 0 || term.MantExp(nil) < bound {
+			break
+		}
+		sum.Add(sum, term)
+	}
+
+	return sum
+}
+
+// powerSeriesTerms returns an upper bound on the number of terms after the
+// first that a series in powers of y with ratios at most 1 needs to fall
+// by 2^-prec, or 0 if |y| >= 1 gives no bound at all.
+func powerSeriesTerms(prec uint, y *big.Float) uint64 {
+	// |y| < 2^e, so each term is below 2^e of the one before.
+	e := y.MantExp(nil)
+	if e > 0 {
+		return 0
+	}
+	if e < 0 {
+		return uint64(prec)/uint64(-e) + 1
+	}
+
+	// 1/2 <= |y| < 1, where each term falls by log2|y|, rounded up to be
+	// safe.
+	f, _ := new(big.Float).Abs(y).Float64()
+	fall := -math.Log2(math.Nextafter(f, 1))
+	if fall <= 0 {
+		return 0
+	}
+
+	return uint64(math.Ceil(float64(prec)/fall)) + 1
+}
+
+// The ratios of the series summed by the package, named for their sums.
+
+func expm1Ratio(n uint64) (num, den uint64)       { return 1, n + 1 }
+func oneMinusCosRatio(n uint64) (num, den uint64) { return 1, (2*n + 1) * (2*n + 2) }
+func sinRatio(n uint64) (num, den uint64)         { return 1, 2 * n * (2*n + 1) }
+func cosRatio(n uint64) (num, den uint64)         { return 1, (2*n - 1) * 2 * n }
+func asinRatio(n uint64) (num, den uint64)        { return (2*n - 1) * (2*n - 1), 2 * n * (2*n + 1) }
+func atanRatio(n uint64) (num, den uint64)        { return 2*n - 1, 2*n + 1 }
+
+// seriesWork returns the working precision of a series kernel for a result
+// of prec bits, with room for the rounding errors of its terms.
+func seriesWork(prec uint) uint {
+	return prec + uint(bits.Len(prec)) + 8
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+var seriesTestValues = []string{
+	"1e-40", "0.001", "0.3", "-0.3", "1", "1.5707963267948966", "-2.5",
+	"3.14159265358979323846264338327950288", "7.3", "-12.5", "100.25", "-745.1", "1e6",
+}
+
+// withinUlp reports whether got is within one unit in the last place of
+// its precision of the more precise want.
+func withinUlp(got, want *big.Float) bool {
+	if got.Sign() == 0 || want.Sign() == 0 {
+		return got.Cmp(want) == 0
+	}
+	ulp := new(big.Float).SetMantExp(one, got.MantExp(nil)-int(got.Prec()))
+	diff := new(big.Float).SetPrec(want.Prec()).Sub(got, want)
+
+	return diff.Abs(diff).Cmp(ulp) <= 0
+}
+
+func TestExpSeries(t *testing.T) {
+	ctx := context.Background()
+	for _, prec := range []uint{24, 53, 64, 113, 200, 500, 1000, 2000, 6000} {
+		for _, s := range seriesTestValues {
+			x := newRatFloat(s, prec)
+			got := expSeries(ctx, x)
+			want := expBitBurst(ctx, new(big.Float).SetPrec(prec+64).Set(x))
+			if got.Prec() != prec || !withinUlp(got, want) {
+				t.Errorf("expSeries(%s) at %d bits = %s, want %s", s, prec, got.Text('g', 40), want.Text('g', 40))
+			}
+		}
+	}
+}
+
+func TestSinCosSeries(t *testing.T) {
+	ctx := context.Background()
+	for _, prec := range []uint{24, 53, 64, 113, 200, 500, 1000, 2000, 6000} {
+		for _, s := range seriesTestValues {
+			x := newRatFloat(s, prec)
+			sin, cos := sinCosSeries(ctx, x)
+			wantSin, wantCos := sinCosBitBurst(ctx, new(big.Float).SetPrec(prec+64).Set(x))
+			if sin.Prec() != prec || !withinUlp(sin, wantSin) {
+				t.Errorf("sin of %s at %d bits = %s, want %s", s, prec, sin.Text('g', 40), wantSin.Text('g', 40))
+			}
+			if cos.Prec() != prec || !withinUlp(cos, wantCos) {
+				t.Errorf("cos of %s at %d bits = %s, want %s", s, prec, cos.Text('g', 40), wantCos.Text('g', 40))
+			}
+		}
+	}
+}
+
+func TestSumSeries(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		first func(x *big.Float) *big.Float
+		y     func(x *big.Float) *big.Float
+		ratio seriesRatio
+		want  func(x *big.Float) *big.Float
+	}{
+		{"sin", func(x *big.Float) *big.Float { return x }, negSquare, sinRatio, Sin},
+		{"cos", func(*big.Float) *big.Float { return one }, negSquare, cosRatio, Cos},
+		{"atan", func(x *big.Float) *big.Float { return x }, negSquare, atanRatio, Atan},
+		{"asin", func(x *big.Float) *big.Float { return x }, square, asinRatio, func(x *big.Float) *big.Float {
+			// arcsin(x) = arctan(x / √(1 - x²))
+			c := new(big.Float).SetPrec(x.Prec()).Mul(x, x)
+			c.Sub(one, c).Sqrt(c)
+
+			return Atan(c.Quo(x, c))
+		}},
+	}
+	for _, test := range tests {
+		for _, prec := range []uint{24, 53, 113, 500, 2000, 6000} {
+			for _, v := range []string{"1/3", "-1/5", "1/100", "-3/1000000"} {
+				x := newRatFloat(v, prec)
+				s := getScratch()
+				got := sumSeries(ctx, s, s.float(seriesWork(prec)), test.first(x), test.y(x), test.ratio)
+				got = new(big.Float).SetPrec(prec).Set(got)
+				s.release()
+				want := test.want(new(big.Float).SetPrec(prec + 64).Set(x))
+				if !withinUlp(got, want) {
+					t.Errorf("%s series(%s) at %d bits = %s, want %s", test.name, v, prec, got.Text('g', 40), want.Text('g', 40))
+				}
+			}
+		}
+	}
+}
+
+func square(x *big.Float) *big.Float {
+	return new(big.Float).SetPrec(2*x.Prec()).Mul(x, x)
+}
+
+func negSquare(x *big.Float) *big.Float {
+	y := square(x)
+
+	return y.Neg(y)
+}
+
+func TestTaylorLargeArguments(t *testing.T) {
+	// Summed directly, the series of sin 1e6 and cos 100 cancel away most of
+	// their bits; reduced by π/2 first, they keep all of them.
+	for _, prec := range []uint{53, 200, 1000} {
+		for _, v := range []float64{100, -1e6, 1.5707963267948966, 1e300} {
+			x := new(big.Float).SetPrec(prec).SetFloat64(v)
+			ref := new(big.Float).SetPrec(prec + 64).Set(x)
+
+			want := new(big.Float).SetPrec(prec).Set(Sin(ref))
+			if got := sinTaylor(x); !withinUlp(got, want) {
+				t.Errorf("sinTaylor(%g) at %d bits = %s, want %s", v, prec, got.Text('g', 20), want.Text('g', 20))
+			}
+
+			want.Quo(one, Cos(ref))
+			if got := secSeries(x); !withinUlp(got, want) {
+				t.Errorf("secSeries(%g) at %d bits = %s, want %s", v, prec, got.Text('g', 20), want.Text('g', 20))
+			}
+		}
+	}
+}
+
+func TestPowerSeriesTerms(t *testing.T) {
+	tests := []struct {
+		prec uint
+		y    float64
+		want uint64
+	}{
+		{100, 1.5, 0},
+		{100, -1, 0},
+		{100, 0.75, 242},
+		{100, -0.5, 102},
+		{100, 0.25, 101},
+		{100, -0.1, 34},
+		{100, 1e-6, 6},
+	}
+	for _, test := range tests {
+		if got := powerSeriesTerms(test.prec, big.NewFloat(test.y)); got != test.want {
+			t.Errorf("powerSeriesTerms(%d, %g) = %d, want %d", test.prec, test.y, got, test.want)
+		}
+	}
+}
+
+func TestSqrtUint(t *testing.T) {
+	for n := uint(0); n < 10000; n++ {
+		r := sqrtUint(n)
+		if r*r > n || (r+1)*(r+1) <= n {
+			t.Errorf("sqrtUint(%d) = %d", n, r)
+		}
+	}
+}
+
+func BenchmarkExpKernels(b *testing.B) {
+	ctx := context.Background()
+	for _, prec := range []uint{128, 1024, 4096, 16384} {
+		x := newRatFloat("7/3", prec)
+		b.Run(fmt.Sprintf("Series/precision_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				expSeries(ctx, x)
+			}
+		})
+		b.Run(fmt.Sprintf("BitBurst/precision_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				expBitBurst(ctx, x)
+			}
+		})
+	}
+}
+
+func BenchmarkSinCosKernels(b *testing.B) {
+	ctx := context.Background()
+	for _, prec := range []uint{128, 1024, 4096, 16384} {
+		x := newRatFloat("7/3", prec)
+		b.Run(fmt.Sprintf("Series/precision_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				sinCosSeries(ctx, x)
+			}
+		})
+		b.Run(fmt.Sprintf("BitBurst/precision_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				sinCosBitBurst(ctx, x)
+			}
+		})
+	}
+}
@@ -15,52 +15,93 @@
 package bigmath
 
 import (
+	"context"
 	"math/big"
 )
 
 // Sin returns the sine of the radian argument x.
 //
-// Choose the best available algorithm for maximum precision.
-// Automatically selects between Taylor series, Chebyshev polynomials,
-// minimax approximation, and CORDIC based on argument size and
-// precision requirements.
+// The result has the precision of x and is correctly rounded in its
+// rounding mode. Sin reduces x by a multiple of π/2, with enough extra bits
+// to keep the full precision of arguments that are huge or very close to a
+// multiple of π, and then sums a power series or, at tens of thousands of
+// bits, uses the bit-burst algorithm.
 //
 // The special cases are:
 //
 //	Sin(±0) = ±0
-//	Sin(±Inf) = NaN
-//	Sin(NaN) = NaN
+//	Sin(±Inf) = +Inf, and SinE returns ErrDomain
 func Sin(x *big.Float) *big.Float {
-	precision := x.Prec()
+	z, _ := SinE(x)
 
-	// Use argument reduction first
-	reducedX, quadrant := reduceArgument(x)
+	return z
+}
 
-	absX := new(big.Float).SetPrec(precision).Abs(reducedX)
-	pi := ComputePi(precision)
-	quarterPi := new(big.Float).SetPrec(precision).Quo(pi, big.NewFloat(4))
+// SinE is like Sin, and also returns an error wrapping ErrDomain for ±Inf,
+// or ErrNoConvergence if the rounding could not be proven correct.
+func SinE(x *big.Float) (*big.Float, error) {
+	return sinE(context.Background(), x)
+}
+
+// SinCtx is like SinE, but stops and returns ctx.Err() if ctx is done
+// before the result is ready.
+func SinCtx(ctx context.Context, x *big.Float) (z *big.Float, err error) {
+	defer recoverCtx(&err)
 
-	var result *big.Float
+	return sinE(ctx, x)
+}
+
+// SinTo sets z to the sine of the radian argument x, correctly rounded to
+// the precision of z in its rounding mode, and returns z. If the precision
+// of z is 0, it is changed to the precision of x first, so that
+// SinTo(new(big.Float).SetMode(x.Mode()), x) gives the same result as
+// Sin(x). z may be x.
+//
+// Like the methods of big.Float, SinTo reuses the storage of z, and its
+// temporaries are reused from one call to the next, so it allocates far
+// less than Sin when called in a loop.
+func SinTo(z, x *big.Float) *big.Float {
+	if z.Prec() == 0 {
+		z.SetPrec(x.Prec())
+	}
+	z, _ = sinTo(context.Background(), nil, z, x)
 
-	// Choose algorithm based on argument size and precision requirements
+	return z
+}
+
+// sinE implements SinE.
+func sinE(ctx context.Context, x *big.Float) (*big.Float, error) {
+	return sinTo(ctx, nil, new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode()), x)
+}
+
+// sinTo implements SinTo, and also returns the error of SinE. The error
+// describes x as it is after the call, so it is only meaningful if z is not
+// x. The Context c, which may be nil, sets the guard bits and iteration
+// limit of the rounding.
+func sinTo(ctx context.Context, c *Context, z, x *big.Float) (*big.Float, error) {
 	switch {
-	case precision >= 1000:
-		// Very high precision: use CORDIC
-		result = sinCORDICImproved(reducedX)
-	case absX.Cmp(quarterPi) <= 0:
-		// Small arguments: use minimax polynomial
-		result = sinMinimax(reducedX)
-	default:
-		// Medium arguments: use Chebyshev approximation
-		result = sinChebyshev(reducedX)
+	case x.Sign() == 0:
+		return z.Set(x), nil
+	case x.IsInf():
+		err := funcError(ErrDomain, "Sin", x)
+
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return z.SetInf(false), err
+	case 2*x.MantExp(nil) <= -int(max(x.Prec(), z.Prec()))-4:
+		// sin x = x − x³/6 + …, below x in magnitude by less than |x|³.
+		return roundNudged(z, x, -x.Sign()), nil
 	}
 
-	// Apply quadrant transformations
-	if quadrant == 2 {
-		result.Neg(result)
+	z, err := c.correctlyRounded(z, func(work uint) *big.Float {
+		sin, _ := sinCosApprox(ctx, new(big.Float).SetPrec(work).Set(x))
+
+		return sin
+	}, nil)
+	if err != nil {
+		return z, funcError(err, "Sin", x)
 	}
 
-	return result
+	return z, nil
 }
 
 // Asin returns the arcsine, in radians, of x.
@@ -68,7 +109,7 @@ func Sin(x *big.Float) *big.Float {
 // The special cases are:
 //
 //	Asin(±0) = ±0
-//	Asin(x) = NaN if x < -1 or x > 1
+//	Asin(x) = +Inf if x < -1 or x > 1, and AsinE returns ErrDomain
 func Asin(x *big.Float) *big.Float {
 	precision := x.Prec()
 
@@ -78,18 +119,20 @@ func Asin(x *big.Float) *big.Float {
 
 	if x.Cmp(one) > 0 || x.Cmp(negOne) < 0 {
 		result := new(big.Float).SetPrec(precision)
-		result.SetInf(false) // Return +Inf for out of domain
+		result.SetInf(false) // Return +Inf for out of domain (big.Float has no NaN)
 
 		return result
 	}
 
-	// For |x| close to 1, use identity: arcsin(x) = π/2 - arccos(x)
+	// For |x| > 1/2, use identity: arcsin(x) = π/2 - arccos(x), so that the
+	// series below sees an argument of at most 1/2.
 	abs := new(big.Float).SetPrec(precision).Abs(x)
-	threshold := new(big.Float).SetPrec(precision).SetFloat64(0.95)
+	threshold := new(big.Float).SetPrec(precision).SetFloat64(0.5)
 
 	if abs.Cmp(threshold) > 0 {
 		// Use arcsin(x) = π/2 - 2*arcsin(sqrt((1-x)/2)) for x > 0
-		halfPi := new(big.Float).SetPrec(precision).Quo(bigPi, big.NewFloat(2))
+		halfPi := piCache.get(context.Background(), precision)
+		halfPi.Quo(halfPi, two)
 		if x.Sign() >= 0 {
 			temp := new(big.Float).SetPrec(precision).Sub(one, x)
 			temp.Quo(temp, big.NewFloat(2))
@@ -110,41 +153,25 @@ func Asin(x *big.Float) *big.Float {
 		return result
 	}
 
-	threshold = new(big.Float).SetPrec(precision).Quo(
-		one,
-		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision/4)), nil)),
-	)
-
 	// Uses the Taylor series: arcsin(x) = x + x³/6 + 3x⁵/40 + 5x⁷/112 + ...
-	result := new(big.Float).SetPrec(precision).Set(x)
-	term := new(big.Float).SetPrec(precision).Set(x)
-	xSquared := new(big.Float).SetPrec(precision).Mul(x, x)
-
-	for n := int64(1); n < 200; n++ {
-		// term *= x² * (2n-1)² / ((2n)*(2n+1))
-		// term *= x² * (2n-1) / ((2n)*(2n+1))
-		//
-		// i = 1  :   1  /  2*3 = 6
-		// i = 2  :   3  /  4*5 = 20
-		// i = 3  :   5  /  6*7 = 42
-		// i = 4  :   7  /  8*9 = 72
-		// i = 5  :   9  / 10*11 = 90
-
-		term.Mul(term, xSquared)
-		//	term.Mul(term, new(big.Float).SetPrec(precision).SetInt64(2*n-1))
-		term.Mul(term, new(big.Float).SetPrec(precision).SetInt64(2*n-1))
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*n))
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*n+1))
-
-		result.Add(result, term)
-
-		// Check convergence
-		if term.Cmp(threshold) < 0 {
-			break
-		}
+	s := getScratch()
+	defer s.release()
+
+	work := seriesWork(precision)
+	y := s.float(work).Mul(x, x)
+	sum := sumSeries(context.Background(), s, s.float(work), x, y, asinRatio)
+
+	return new(big.Float).SetPrec(precision).Set(sum)
+}
+
+// AsinE is like Asin, and also returns an error wrapping ErrDomain for
+// x < -1 or x > 1.
+func AsinE(x *big.Float) (*big.Float, error) {
+	if new(big.Float).Abs(x).Cmp(one) > 0 {
+		return Asin(x), funcError(ErrDomain, "Asin", x)
 	}
 
-	return result
+	return Asin(x), nil
 }
 
 // Sinh returns the hyperbolic sine of x.
@@ -153,16 +180,24 @@ func Asin(x *big.Float) *big.Float {
 //
 //	Sinh(±0) = ±0
 //	Sinh(±Inf) = ±Inf
-//	Sinh(NaN) = NaN
 func Sinh(x *big.Float) *big.Float {
-	precision := x.Prec()
+	sinh, _ := sinhCosh(x)
 
-	// TODO(rsned): Implement.
-	// For now, return a placeholder result
-	result := new(big.Float).SetPrec(precision)
-	result.SetInt64(0)
+	return sinh
+}
 
-	return result
+// sinhCORDIC calculates sinh(x) by hyperbolic CORDIC, taking one rotation
+// per bit of the precision of x. x must be finite, with |x| < 2^31·ln 2
+// so that e^x is within the exponent range; larger x give meaningless
+// results.
+// This is a package-private method for performance comparison.
+func sinhCORDIC(x *big.Float) *big.Float {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(x.Prec()).Set(x)
+	}
+	sinh, _ := cordicSinhCosh(context.Background(), x)
+
+	return sinh
 }
 
 // Asinh returns the hyperbolic sine of x.
@@ -171,7 +206,6 @@ func Sinh(x *big.Float) *big.Float {
 //
 //	Asinh(±0) = ±0
 //	Asinh(±Inf) = ±Inf
-//	Asinh(NaN) = NaN
 func Asinh(x *big.Float) *big.Float {
 	precision := x.Prec()
 
@@ -232,24 +266,38 @@ func reduceArgument(x *big.Float) (*big.Float, int) {
 	return reducedX, quadrant
 }
 
+// reduceTwoPi returns x − 2πk in [0, 2π) for the integer k, and π, both
+// rounded to the precision of x. It finds k with a single division, taking
+// as many more bits of π as x has integer bits, so the reduction is as
+// exact for large x as for small.
+func reduceTwoPi(x *big.Float) (r, pi *big.Float) {
+	prec := x.Prec()
+	work := prec + uint(max(0, x.MantExp(nil))) + 8
+
+	pi = piCache.get(context.Background(), work)
+	twoPi := new(big.Float).SetPrec(work).Mul(pi, two)
+
+	k := new(big.Int)
+	new(big.Float).SetPrec(work).Quo(x, twoPi).Int(k)
+
+	r = new(big.Float).SetPrec(work).SetInt(k)
+	r.Mul(r, twoPi)
+	r.Sub(x, r)
+	if r.Sign() < 0 {
+		r.Add(r, twoPi)
+	}
+
+	return r.SetPrec(prec), pi.SetPrec(prec)
+}
+
 // sinArgReduction calculates sin(x) using argument reduction and polynomial approximation.
 // This is a package-private method for performance comparison.
 func sinArgReduction(x *big.Float) *big.Float {
 	precision := x.Prec()
 
-	// Reduce argument to [-π/2, π/2]
-	reducedX := new(big.Float).SetPrec(precision).Set(x)
-	twoPi := new(big.Float).SetPrec(precision).Mul(bigPi, two)
-	pi := new(big.Float).SetPrec(precision).Set(bigPi)
-	halfPi := new(big.Float).SetPrec(precision).Quo(bigPi, two)
-
 	// Reduce to [0, 2π]
-	for reducedX.Cmp(twoPi) >= 0 {
-		reducedX.Sub(reducedX, twoPi)
-	}
-	for reducedX.Cmp(zero) < 0 {
-		reducedX.Add(reducedX, twoPi)
-	}
+	reducedX, pi := reduceTwoPi(x)
+	halfPi := new(big.Float).SetPrec(precision).Quo(pi, two)
 
 	// Further reduction using symmetries
 	sign := 1
@@ -273,168 +321,32 @@ func sinArgReduction(x *big.Float) *big.Float {
 	return result
 }
 
-// sinCORDIC calculates sin(x) using CORDIC algorithm.
+// sinCORDIC calculates sin(x) using the CORDIC algorithm, taking as many
+// rotations as the precision of x needs, one per bit. x must be finite.
 // This is a package-private method for performance comparison.
 func sinCORDIC(x *big.Float) *big.Float {
-	precision := x.Prec()
-
-	// CORDIC requires argument reduction to [-π/2, π/2]
-	reducedX := new(big.Float).SetPrec(precision).Set(x)
-	twoPi := new(big.Float).SetPrec(precision).Mul(bigPi, two)
-	pi := new(big.Float).SetPrec(precision).Set(bigPi)
-	halfPi := new(big.Float).SetPrec(precision).Quo(bigPi, two)
-
-	// Reduce to [0, 2π]
-	for reducedX.Cmp(twoPi) >= 0 {
-		reducedX.Sub(reducedX, twoPi)
-	}
-	for reducedX.Cmp(zero) < 0 {
-		reducedX.Add(reducedX, twoPi)
-	}
+	sin, _ := cordicSinCos(context.Background(), x, false)
 
-	// Further reduce and track quadrant
-	sign := 1
-	if reducedX.Cmp(pi) > 0 {
-		reducedX.Sub(reducedX, pi)
-		sign = -1
-	}
-	if reducedX.Cmp(halfPi) > 0 {
-		reducedX.Sub(pi, reducedX)
-	}
-
-	// CORDIC rotation mode
-	// K := PRODUCT(0..n-1) 1 / sqrt(1 + 2^-2i)
-	// K ≈ 0.6072529350088812561694
-	x0 := new(big.Float).SetPrec(precision).SetFloat64(0.6072529350088812561694)
-	y0 := new(big.Float).SetPrec(precision).Set(zero)
-	z0 := new(big.Float).SetPrec(precision).Set(reducedX)
-
-	// CORDIC iterations
-	for i := 0; i < int(precision/2)+10; i++ {
-		// Calculate 2^(-i)
-		powerOf2 := new(big.Float).SetPrec(precision).SetInt64(1)
-		for j := 0; j < i; j++ {
-			powerOf2.Quo(powerOf2, two)
-		}
-
-		// Calculate arctan(2^(-i))
-		var arctanVal *big.Float
-		if i < len(arctanLookupTable) {
-			arctanVal, _, _ = new(big.Float).SetPrec(precision).Parse(arctanLookupTable[i], 10)
-		} else {
-			// For large i, arctan(2^(-i)) ≈ 2^(-i)
-			arctanVal = new(big.Float).SetPrec(precision).Set(powerOf2)
-		}
-
-		if z0.Sign() >= 0 {
-			newX := new(big.Float).SetPrec(precision).Set(x0)
-			temp := new(big.Float).SetPrec(precision).Mul(y0, powerOf2)
-			newX.Sub(newX, temp)
-
-			newY := new(big.Float).SetPrec(precision).Set(y0)
-			temp = new(big.Float).SetPrec(precision).Mul(x0, powerOf2)
-			newY.Add(newY, temp)
-
-			newZ := new(big.Float).SetPrec(precision).Set(z0)
-			newZ.Sub(newZ, arctanVal)
-
-			x0, y0, z0 = newX, newY, newZ
-		} else {
-			newX := new(big.Float).SetPrec(precision).Set(x0)
-			temp := new(big.Float).SetPrec(precision).Mul(y0, powerOf2)
-			newX.Add(newX, temp)
-
-			newY := new(big.Float).SetPrec(precision).Set(y0)
-			temp = new(big.Float).SetPrec(precision).Mul(x0, powerOf2)
-			newY.Sub(newY, temp)
-
-			newZ := new(big.Float).SetPrec(precision).Set(z0)
-			newZ.Add(newZ, arctanVal)
-
-			x0, y0, z0 = newX, newY, newZ
-		}
-	}
-
-	result := new(big.Float).SetPrec(precision).Set(y0)
-	if sign == -1 {
-		result.Neg(result)
-	}
-
-	return result
+	return sin
 }
 
-// sinChebyshev calculates sin(x) using Chebyshev polynomial approximation
-// for x in [-π/2, π/2]
+// sinChebyshev calculates sin(x) from the Chebyshev series of sin and cos
+// on [-π/4, π/4] to the precision of x, after reducing x by a multiple of
+// π/2. x must be finite.
+// This is a package-private method for performance comparison.
 func sinChebyshev(x *big.Float) *big.Float {
-	// Fall back to Taylor series for better reliability
-	// The Chebyshev coefficients need more careful implementation
-	return sinTaylorReduced(x)
+	sin, _ := chebyshevSinCos(context.Background(), x, false)
+
+	return sin
 }
 
-// sinCORDICImproved calculates sin(x) using improved CORDIC algorithm
-// with better precision and convergence
+// sinCORDICImproved calculates sin(x) using the CORDIC algorithm with half
+// the rotations of sinCORDIC, finishing with one multiplication by the
+// small angle left. x must be finite.
 func sinCORDICImproved(x *big.Float) *big.Float {
-	precision := x.Prec()
-
-	// CORDIC works best for arguments in [-π/2, π/2]
-	// Assume argument reduction has already been done
-
-	// Initial values
-	x0 := new(big.Float).SetPrec(precision).SetFloat64(0.6072529350088812561694) // 1/K
-	y0 := new(big.Float).SetPrec(precision)
-	z0 := new(big.Float).SetPrec(precision).Set(x)
-
-	// CORDIC iterations with improved precision
-	for i := 0; i < int(precision/2)+20; i++ {
-		var sigma int
-		if z0.Sign() >= 0 {
-			sigma = 1
-		} else {
-			sigma = -1
-		}
-
-		// Calculate 2^(-i)
-		powerOf2 := new(big.Float).SetPrec(precision).SetInt64(1)
-		for j := 0; j < i; j++ {
-			powerOf2.Quo(powerOf2, big.NewFloat(2))
-		}
-
-		// Get arctan value
-		var arctanVal *big.Float
-		if i < len(arctanLookupTable) {
-			arctanVal, _ = new(big.Float).SetPrec(precision).SetString(arctanLookupTable[i])
-		} else {
-			// For large i, arctan(2^(-i)) ≈ 2^(-i)
-			arctanVal = new(big.Float).SetPrec(precision).Set(powerOf2)
-		}
-
-		// CORDIC rotation
-		newX := new(big.Float).SetPrec(precision).Set(x0)
-		temp := new(big.Float).SetPrec(precision).Mul(y0, powerOf2)
-		temp.Mul(temp, big.NewFloat(float64(-sigma)))
-		newX.Add(newX, temp)
-
-		newY := new(big.Float).SetPrec(precision).Set(y0)
-		temp = new(big.Float).SetPrec(precision).Mul(x0, powerOf2)
-		temp.Mul(temp, big.NewFloat(float64(sigma)))
-		newY.Add(newY, temp)
-
-		newZ := new(big.Float).SetPrec(precision).Set(z0)
-		temp = new(big.Float).SetPrec(precision).Mul(arctanVal, big.NewFloat(float64(sigma)))
-		newZ.Sub(newZ, temp)
-
-		x0, y0, z0 = newX, newY, newZ
-
-		// Check convergence
-		if z0.Cmp(new(big.Float).SetPrec(precision).Quo(
-			big.NewFloat(1),
-			new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)),
-		)) < 0 {
-			break
-		}
-	}
+	sin, _ := cordicSinCos(context.Background(), x, true)
 
-	return y0
+	return sin
 }
 
 // sinGoSource converts the Go standard library sin implementation to use math/big values.
@@ -482,7 +394,7 @@ func sinGoSource(x *big.Float) *big.Float {
 	// Special cases
 	if x.IsInf() {
 		result := new(big.Float).SetPrec(precision)
-		result.SetInf(false) // Return NaN for ±Inf
+		result.SetInf(false) // +Inf stands in for NaN (big.Float has no NaN)
 
 		return result
 	}
@@ -588,126 +500,65 @@ func sinGoSource(x *big.Float) *big.Float {
 	return result
 }
 
-// sinMinimax calculates sin(x) using minimax polynomial approximation
-// Optimized for [-π/4, π/4] with minimal maximum error
+// sinMinimax calculates sin(x) from the near-minimax polynomials of sin
+// and cos on [-π/4, π/4] of the precision of x, the truncated Chebyshev
+// series of sinChebyshev in powers of x, after reducing x by a multiple of
+// π/2. x must be finite.
+// This is a package-private method for performance comparison.
 func sinMinimax(x *big.Float) *big.Float {
-	precision := x.Prec()
-
-	// Minimax polynomial coefficients for sin(x) on [-π/4, π/4]
-	// Generated using Remez exchange algorithm
-	coeffs := []string{
-		"0", // x^0
-		"0.9999999999999999999999999999999999999999999999999999999999999999", // x^1
-		"0", // x^2
-		"-0.16666666666666666666666666666666666666666666666666666666666667", // x^3
-		"0", // x^4
-		"0.008333333333333333333333333333333333333333333333333333333333333", // x^5
-		"0", // x^6
-		"-0.000198412698412698412698412698412698412698412698412698412698413", // x^7
-		"0", // x^8
-		"0.0000027557319223985890652557319223985890652557319223985890652557", // x^9
-		"0", // x^10
-		"-0.000000025052108385441718775052108385441718775052108385441718775", // x^11
-	}
-
-	result := new(big.Float).SetPrec(precision)
-	xPower := new(big.Float).SetPrec(precision).SetInt64(1)
-
-	for i, coeffStr := range coeffs {
-		if coeffStr != "0" {
-			coeff, _ := new(big.Float).SetPrec(precision).SetString(coeffStr)
-			term := new(big.Float).SetPrec(precision).Mul(coeff, xPower)
-			result.Add(result, term)
-		}
+	sin, _ := chebyshevSinCos(context.Background(), x, true)
 
-		// xPower *= x for next iteration
-		if i < len(coeffs)-1 {
-			xPower.Mul(xPower, x)
-		}
-	}
-
-	return result
+	return sin
 }
 
 // sinTaylor calculates sin(x) using Taylor series.
 // Uses the series: sin(x) = x - x³/3! + x⁵/5! - x⁷/7! + ...
+// x must be finite.
 func sinTaylor(x *big.Float) *big.Float {
-	precision := x.Prec()
-
-	threshold := new(big.Float).SetPrec(precision).Quo(
-		new(big.Float).SetInt64(1),
-		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision/4)), nil)),
-	)
+	sin, _ := taylorSinCos(x, x.Prec())
 
-	result := new(big.Float).SetPrec(precision)
-	term := new(big.Float).SetPrec(precision).Set(x)
-	xSquared := new(big.Float).SetPrec(precision)
-	xSquared.Mul(x, x)
-
-	result.Set(x)
-
-	// sin(x) = x - x³/3! + x⁵/5! - x⁷/7! + ...
-	for i := int64(1); i < 100; i++ {
-		// Calculate next term: multiply by x² and divide by (2i)(2i+1)
-		term.Mul(term, xSquared)
-
-		// Divide by the next two factorial terms
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*i))
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*i+1))
-
-		// Alternate signs: subtract for odd i, add for even i
-		if i%2 == 1 {
-			result.Sub(result, term)
-		} else {
-			result.Add(result, term)
-		}
+	return sin
+}
 
-		// Check convergence
-		if term.Cmp(threshold) < 0 {
-			break
-		}
+// taylorSinCos returns sin(x) and cos(x) rounded to prec bits from the
+// Taylor series of sin r and cos r, after reducing x by a multiple of π/2
+// to |r| <= π/4, where the terms fall from the first and the series do not
+// cancel. x must be finite.
+func taylorSinCos(x *big.Float, prec uint) (sin, cos *big.Float) {
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x), new(big.Float).SetPrec(prec).SetInt64(1)
 	}
 
-	return result
+	ctx := context.Background()
+	work := seriesWork(prec)
+	r, quadrant := reduceHalfPi(ctx, x, work)
+
+	s := getScratch()
+	defer s.release()
+
+	y := s.float(work).Mul(r, r)
+	y.Neg(y)
+	sr := sumSeries(ctx, s, s.float(work), r, y, sinRatio)
+	cr := sumSeries(ctx, s, s.float(work), s.float(work).SetInt64(1), y, cosRatio)
+
+	return unreduceHalfPi(sr, cr, quadrant, prec)
 }
 
 // sinTaylorReduced calculates sin(x) for x in [-π/2, π/2] using Taylor series
 func sinTaylorReduced(x *big.Float) *big.Float {
-	precision := x.Prec()
-
-	threshold := new(big.Float).SetPrec(precision).Quo(
-		new(big.Float).SetInt64(1),
-		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision/3)), nil)),
-	)
-
-	result := new(big.Float).SetPrec(precision)
-	term := new(big.Float).SetPrec(precision).Set(x)
-	xSquared := new(big.Float).SetPrec(precision)
-	xSquared.Mul(x, x)
-
-	result.Set(x)
-
-	// sin(x) = x - x³/3! + x⁵/5! - x⁷/7! + ...
-	for i := int64(1); i < 200; i++ {
-		// Calculate next term: multiply by x² and divide by (2i)(2i+1)
-		term.Mul(term, xSquared)
-
-		// Divide by the next two factorial terms
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*i))
-		term.Quo(term, new(big.Float).SetPrec(precision).SetInt64(2*i+1))
-
-		// Alternate signs: subtract for odd i, add for even i
-		if i%2 == 1 {
-			result.Sub(result, term)
-		} else {
-			result.Add(result, term)
-		}
+	// On the reduced range the terms fall from the first, so the series
+	// engine needs no more care than sinTaylor gives it.
+	return sinTaylor(x)
+}
 
-		// Check convergence
-		if term.Cmp(threshold) < 0 {
-			break
-		}
-	}
+// sinTaylorRemainder returns an upper bound, rounded up, on the error of the
+// Taylor series of sin r or cos r truncated before its term of degree n,
+// for every |r| <= bound. By the Lagrange form of the remainder the error
+// is f⁽ⁿ⁾(ξ)·rⁿ/n! for some ξ, and no derivative of sin or cos exceeds 1 in
+// magnitude, so it is at most boundⁿ/n!.
+func sinTaylorRemainder(bound *big.Float, n int) *big.Float {
+	prec := max(bound.Prec(), 64)
+	result := powUp(bound, n, prec)
 
-	return result
+	return result.Quo(result, factorialDown(n, prec))
 }
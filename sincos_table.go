@@ -0,0 +1,198 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math/big"
+	"math/bits"
+)
+
+// A table of sin kθ and cos kθ is filled in blocks. The first entry of each
+// block is evaluated in full, and the rest follow from the rotation
+//
+//	sin (k+1)θ = sin kθ·cos θ + cos kθ·sin θ
+//	cos (k+1)θ = cos kθ·cos θ − sin kθ·sin θ
+//
+// which costs four multiplications. Each rotation adds at most a few units
+// of absolute rounding error, so carrying the bits for a block's worth of
+// them beyond the precision of the table keeps every entry within a unit
+// in its last place, however long the table, as long as the entry is not
+// much smaller than 1. Near a multiple of π/2, where sin kθ or cos kθ is
+// smaller than that absolute error, the pair is evaluated afresh, and the
+// rotation goes on from it. The blocks are independent, and are shared
+// among the workers set by SetMaxWorkers.
+
+// sincosTableBlock is the number of entries in each block of a table.
+const sincosTableBlock = 64
+
+// SincosTable returns sin kθ and cos kθ for k = 0, 1, …, n-1, each within
+// a unit in the last place of the precision of theta, with much less work
+// than n calls of Sin and Cos. It returns nil slices for n <= 0.
+//
+// The special case is:
+//
+//	SincosTable(±Inf, n) = +Inf for every entry
+func SincosTable(theta *big.Float, n int) (sin, cos []*big.Float) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	prec := theta.Prec()
+	if theta.IsInf() {
+		sin = make([]*big.Float, n)
+		cos = make([]*big.Float, n)
+		for k := range n {
+			sin[k] = new(big.Float).SetPrec(prec).SetInf(false)
+			cos[k] = new(big.Float).SetPrec(prec).SetInf(false)
+		}
+
+		return sin, cos
+	}
+
+	// k·θ is exact at the working precision, which has room for k.
+	return sincosTable(context.Background(), n, prec, func(k int, work uint) *big.Float {
+		a := new(big.Float).SetPrec(work).SetInt64(int64(k))
+
+		return a.Mul(a, theta)
+	})
+}
+
+// RootsOfUnity returns the n-th roots of unity e^(2πik/n) for
+// k = 0, 1, …, n-1, the twiddle factors of a discrete Fourier transform of
+// length n, with the given precision. Each part is within a unit in its
+// last place, and 1, i, -1 and -i are exact. It returns nil for n <= 0.
+func RootsOfUnity(n int, prec uint) []*Complex {
+	if n <= 0 {
+		return nil
+	}
+
+	// Only the first quarter of the roots needs sines and cosines when n is
+	// a multiple of 4, as the rest are those times i, -1 and -i. Otherwise
+	// the second half are the conjugates of the first.
+	m := n/2 + 1
+	if n%4 == 0 {
+		m = n / 4
+	}
+
+	ctx := context.Background()
+	sin, cos := sincosTable(ctx, m, prec, func(k int, work uint) *big.Float {
+		// 2πk/n, rounded once from the exact rational multiple of π.
+		a := piCache.get(ctx, work+8)
+		a.Mul(a, new(big.Float).SetInt64(2*int64(k)))
+		a.Quo(a, new(big.Float).SetInt64(int64(n)))
+
+		return a.SetPrec(work)
+	})
+
+	roots := make([]*Complex, n)
+	for k := range m {
+		roots[k] = new(Complex)
+		roots[k].re.Set(cos[k])
+		roots[k].im.Set(sin[k])
+	}
+
+	if n%4 == 0 {
+		// e^(2πi(k+m)/n) = i·e^(2πik/n)
+		for k := m; k < n; k++ {
+			z := roots[k-m]
+			roots[k] = new(Complex)
+			negTo(&roots[k].re, &z.im)
+			roots[k].im.Set(&z.re)
+		}
+
+		return roots
+	}
+
+	for k := m; k < n; k++ {
+		z := roots[n-k]
+		roots[k] = new(Complex)
+		roots[k].re.Set(&z.re)
+		negTo(&roots[k].im, &z.im)
+	}
+	if n%2 == 0 {
+		roots[n/2].re.SetInt64(-1)
+		roots[n/2].im.SetInt64(0)
+	}
+
+	return roots
+}
+
+// negTo sets z to -x, with -0 taken as +0, and returns z.
+func negTo(z, x *big.Float) *big.Float {
+	if x.Sign() == 0 {
+		return z.SetPrec(x.Prec()).SetInt64(0)
+	}
+
+	return z.Neg(x)
+}
+
+// sincosTable returns sin and cos of angle(k) for k = 0, 1, …, n-1 with
+// precision prec, where angle(k, work) returns kθ for a fixed θ, with work
+// bits or exactly.
+func sincosTable(ctx context.Context, n int, prec uint, angle func(k int, work uint) *big.Float) (sin, cos []*big.Float) {
+	work := prec + uint(bits.Len(uint(n))) + uint(bits.Len(sincosTableBlock)) + 8
+
+	sin = make([]*big.Float, n)
+	cos = make([]*big.Float, n)
+
+	var s1, c1 *big.Float
+	if n > 1 {
+		s1, c1 = sinCosApprox(ctx, angle(1, work))
+	}
+
+	// The rotations of a block leave each entry within 2^(9-work) of its
+	// value, which is within a quarter of a unit in the last place of prec
+	// bits only for entries of at least 2^(tiny-1).
+	tiny := int(prec) - int(work) + 11
+
+	blocks := (n + sincosTableBlock - 1) / sincosTableBlock
+	forEach(blocks, func(b int) {
+		start := b * sincosTableBlock
+		end := min(start+sincosTableBlock, n)
+
+		s, c := sinCosApprox(ctx, angle(start, work))
+
+		// big.Float.Mul allocates when its result is one of its operands,
+		// so each rotation goes into the other pair of buffers.
+		ns := new(big.Float).SetPrec(work)
+		nc := new(big.Float).SetPrec(work)
+		t := new(big.Float).SetPrec(work)
+		for k := start; ; k++ {
+			if k > start && (s.Sign() == 0 || s.MantExp(nil) < tiny || c.Sign() == 0 || c.MantExp(nil) < tiny) {
+				ds, dc := sinCosApprox(ctx, angle(k, work))
+				s.Set(ds)
+				c.Set(dc)
+			}
+			sin[k] = new(big.Float).SetPrec(prec).Set(s)
+			cos[k] = new(big.Float).SetPrec(prec).Set(c)
+			if k+1 == end {
+				break
+			}
+
+			checkCtx(ctx)
+			ns.Mul(s, c1)
+			t.Mul(c, s1)
+			ns.Add(ns, t)
+			nc.Mul(c, c1)
+			t.Mul(s, s1)
+			nc.Sub(nc, t)
+			s, ns = ns, s
+			c, nc = nc, c
+		}
+	})
+
+	return sin, cos
+}
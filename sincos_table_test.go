@@ -0,0 +1,162 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestSincosTable(t *testing.T) {
+	for _, prec := range []uint{53, 256, 1000} {
+		for _, theta := range []string{"1/3", "-7/5", "100", "1/1000000"} {
+			x := newRatFloat(theta, prec)
+			const n = 300
+			sin, cos := SincosTable(x, n)
+			if len(sin) != n || len(cos) != n {
+				t.Fatalf("SincosTable(%s, %d) has lengths %d and %d", theta, n, len(sin), len(cos))
+			}
+			for k := range n {
+				a := new(big.Float).SetPrec(prec + 64).SetInt64(int64(k))
+				a.Mul(a, x)
+				if want := Sin(a); sin[k].Prec() != prec || !withinUlp(sin[k], want) {
+					t.Errorf("sin(%d·%s) at %d bits = %s, want %s", k, theta, prec, sin[k].Text('g', 30), want.Text('g', 30))
+				}
+				if want := Cos(a); cos[k].Prec() != prec || !withinUlp(cos[k], want) {
+					t.Errorf("cos(%d·%s) at %d bits = %s, want %s", k, theta, prec, cos[k].Text('g', 30), want.Text('g', 30))
+				}
+			}
+		}
+	}
+}
+
+func TestSincosTableNearZero(t *testing.T) {
+	// θ = π/63 and π/62 put sin 63θ and cos 31θ within a few units of 2^-prec
+	// of zero, far below the absolute error of the rotations.
+	for _, prec := range []uint{53, 200, 1000} {
+		for _, d := range []int64{63, 62} {
+			x := new(big.Float).SetPrec(prec).Quo(ComputePi(prec+64), big.NewFloat(float64(d)))
+			const n = 130
+			sin, cos := SincosTable(x, n)
+			for k := range n {
+				a := new(big.Float).SetPrec(2*prec + 64).SetInt64(int64(k))
+				a.Mul(a, x)
+				if want := Sin(a); !withinUlp(sin[k], want) {
+					t.Errorf("sin(%d·π/%d) at %d bits = %s, want %s", k, d, prec, sin[k].Text('g', 30), want.Text('g', 30))
+				}
+				if want := Cos(a); !withinUlp(cos[k], want) {
+					t.Errorf("cos(%d·π/%d) at %d bits = %s, want %s", k, d, prec, cos[k].Text('g', 30), want.Text('g', 30))
+				}
+			}
+		}
+	}
+}
+
+func TestSincosTableSpecial(t *testing.T) {
+	if sin, cos := SincosTable(big.NewFloat(1), 0); sin != nil || cos != nil {
+		t.Errorf("SincosTable(1, 0) = %v, %v, want nil, nil", sin, cos)
+	}
+
+	sin, cos := SincosTable(new(big.Float).SetInf(true), 3)
+	for k := range 3 {
+		if !sin[k].IsInf() || !cos[k].IsInf() {
+			t.Errorf("SincosTable(-Inf, 3)[%d] = %v, %v, want +Inf, +Inf", k, sin[k], cos[k])
+		}
+	}
+}
+
+func TestRootsOfUnity(t *testing.T) {
+	for _, prec := range []uint{53, 300} {
+		for _, n := range []int{1, 2, 3, 5, 6, 8, 12, 100, 257} {
+			roots := RootsOfUnity(n, prec)
+			if len(roots) != n {
+				t.Fatalf("RootsOfUnity(%d, %d) has length %d", n, prec, len(roots))
+			}
+
+			twoPi := ComputePi(prec + 128)
+			twoPi.Mul(twoPi, two)
+			for k, z := range roots {
+				a := new(big.Float).SetPrec(prec + 128).SetInt64(int64(k))
+				a.Mul(a, twoPi)
+				a.Quo(a, new(big.Float).SetInt64(int64(n)))
+
+				re, im := Cos(a), Sin(a)
+				// At the exact points the reference is only nearly zero.
+				if 4*k%n == 0 {
+					re.SetPrec(prec)
+					im.SetPrec(prec)
+					if re.MantExp(nil) < -int(prec) {
+						re.SetInt64(0)
+					}
+					if im.MantExp(nil) < -int(prec) {
+						im.SetInt64(0)
+					}
+					if z.Real().Cmp(re) != 0 || z.Imag().Cmp(im) != 0 || isNegZero(z.Real()) || isNegZero(z.Imag()) {
+						t.Errorf("RootsOfUnity(%d, %d)[%d] = %v, want exactly %v", n, prec, k, z, NewComplex(re, im))
+					}
+
+					continue
+				}
+				if z.Prec() != prec || !withinUlp(z.Real(), re) || !withinUlp(z.Imag(), im) {
+					t.Errorf("RootsOfUnity(%d, %d)[%d] = %v, want %s + %si", n, prec, k, z, re.Text('g', 20), im.Text('g', 20))
+				}
+			}
+		}
+	}
+
+	if roots := RootsOfUnity(0, 53); roots != nil {
+		t.Errorf("RootsOfUnity(0, 53) = %v, want nil", roots)
+	}
+}
+
+// isNegZero reports whether x is -0.
+func isNegZero(x *big.Float) bool {
+	return x.Sign() == 0 && x.Signbit()
+}
+
+func BenchmarkSincosTable(b *testing.B) {
+	for _, prec := range []uint{256, 2048} {
+		const n = 1024
+		theta := newRatFloat("1/3", prec)
+
+		b.Run(fmt.Sprintf("SincosTable_prec_%d", prec), func(b *testing.B) {
+			for b.Loop() {
+				SincosTable(theta, n)
+			}
+		})
+		b.Run(fmt.Sprintf("SinCos_loop_prec_%d", prec), func(b *testing.B) {
+			a := new(big.Float).SetPrec(prec + 16)
+			for b.Loop() {
+				for k := range n {
+					a.SetInt64(int64(k))
+					a.Mul(a, theta)
+					Sin(a)
+					Cos(a)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkRootsOfUnity(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 16} {
+		b.Run(fmt.Sprintf("n_%d", n), func(b *testing.B) {
+			for b.Loop() {
+				RootsOfUnity(n, 256)
+			}
+		})
+	}
+}
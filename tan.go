@@ -14,75 +14,258 @@
 
 package bigmath
 
-import "math/big"
+import (
+	"context"
+	"math"
+	"math/big"
+)
 
 // Tan returns the tangent of the radian argument x.
 //
 // The special cases are:
 //
 //	Tan(±0) = ±0
-//	Tan(±Inf) = NaN
-//	Tan(NaN) = NaN
+//	Tan(±Inf) = +Inf, and TanE returns ErrDomain
 func Tan(x *big.Float) *big.Float {
+	if x.IsInf() {
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return new(big.Float).SetPrec(x.Prec()).SetInf(false)
+	}
+	if x.Sign() != 0 && 2*x.MantExp(nil) <= -int(x.Prec())-4 {
+		// tan x = x + x³/3 + …, above x in magnitude by less than |x|³.
+		return roundNudged(new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode()), x, x.Sign())
+	}
+
 	return tanNaive(x)
 }
 
+// TanE is like Tan, and also returns an error wrapping ErrDomain for ±Inf.
+func TanE(x *big.Float) (*big.Float, error) {
+	if x.IsInf() {
+		return Tan(x), funcError(ErrDomain, "Tan", x)
+	}
+
+	return Tan(x), nil
+}
+
+// atanReduceExp is the binary exponent below which Atan stops halving its
+// argument and sums the Taylor series. Each term of the series is then at
+// least 2^(2·atanReduceExp) times smaller than the one before it.
+const atanReduceExp = -4
+
 // Atan returns the arctangent, in radians, of x.
 //
+// The result has the precision of x and is correctly rounded in its
+// rounding mode.
+//
 // The special cases are:
 //
 //	Atan(±0) = ±0
 //	Atan(±Inf) = ±Pi/2
-//	Atan(NaN) = NaN
 func Atan(x *big.Float) *big.Float {
-	prec := x.Prec()
+	z, _ := AtanE(x)
+
+	return z
+}
+
+// AtanE is like Atan, and also returns an error wrapping ErrNoConvergence
+// if the rounding could not be proven correct.
+func AtanE(x *big.Float) (*big.Float, error) {
+	return atanE(context.Background(), x)
+}
+
+// AtanCtx is like AtanE, but stops and returns ctx.Err() if ctx is done
+// before the result is ready.
+func AtanCtx(ctx context.Context, x *big.Float) (z *big.Float, err error) {
+	defer recoverCtx(&err)
+
+	return atanE(ctx, x)
+}
+
+// AtanTo sets z to the arctangent, in radians, of x, correctly rounded to
+// the precision of z in its rounding mode, and returns z. If the precision
+// of z is 0, it is changed to the precision of x first, so that
+// AtanTo(new(big.Float).SetMode(x.Mode()), x) gives the same result as
+// Atan(x). z may be x.
+//
+// Like the methods of big.Float, AtanTo reuses the storage of z, and its
+// temporaries are reused from one call to the next, so it allocates far
+// less than Atan when called in a loop.
+func AtanTo(z, x *big.Float) *big.Float {
+	if z.Prec() == 0 {
+		z.SetPrec(x.Prec())
+	}
+	z, _ = atanTo(context.Background(), nil, z, x)
+
+	return z
+}
+
+// atanE implements AtanE.
+func atanE(ctx context.Context, x *big.Float) (*big.Float, error) {
+	return atanTo(ctx, nil, new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode()), x)
+}
 
-	// For |x| > 1, use the identity:
-	//    arctan(x) = π/2 - arctan(1/x) for x > 0
-	// or arctan(x) = -π/2 - arctan(1/x) for x < 0
-	abs := new(big.Float).SetPrec(prec).Abs(x)
+// atanTo implements AtanTo, and also returns the error of AtanE. The error
+// describes x as it is after the call, so it is only meaningful if z is not
+// x. The Context c, which may be nil, sets the guard bits and iteration
+// limit of the rounding.
+func atanTo(ctx context.Context, c *Context, z, x *big.Float) (*big.Float, error) {
+	if x.Sign() == 0 {
+		return z.Set(x), nil
+	}
+	if 2*x.MantExp(nil) <= -int(max(x.Prec(), z.Prec()))-4 {
+		// atan x = x − x³/3 + …, below x in magnitude by less than |x|³.
+		return roundNudged(z, x, -x.Sign()), nil
+	}
 
-	if abs.Cmp(one) > 0 {
-		reciprocal := new(big.Float).SetPrec(prec).Quo(one, x)
-		arctanRecip := Atan(reciprocal)
+	z, err := c.correctlyRounded(z, func(work uint) *big.Float {
+		return atan(ctx, new(big.Float).SetPrec(work).Set(x))
+	}, nil)
+	if err != nil {
+		return z, funcError(err, "Atan", x)
+	}
 
-		if x.Sign() > 0 {
-			return new(big.Float).SetPrec(prec).Sub(bigHalfPi, arctanRecip)
+	return z, nil
+}
+
+// atan returns the arctangent of x to within a unit in the last place of
+// the precision of x.
+func atan(ctx context.Context, x *big.Float) *big.Float {
+	prec := x.Prec()
+
+	switch {
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(x)
+	case x.IsInf():
+		result := piCache.get(ctx, prec)
+		result.Quo(result, two)
+		if x.Signbit() {
+			result.Neg(result)
 		}
 
-		result := new(big.Float).SetPrec(prec).Add(bigHalfPi, arctanRecip)
+		return result
+	}
+
+	work := prec + 32
+	t := new(big.Float).SetPrec(work).Abs(x)
+
+	// For |x| > 1, use the identity arctan(x) = π/2 - arctan(1/x).
+	invert := t.Cmp(one) > 0
+	if invert {
+		t.Quo(one, t)
+	}
 
-		return result.Neg(result)
+	// Halve the argument until the series converges quickly, using
+	//    arctan(t) = 2·arctan(t / (1 + √(1 + t²)))
+	halvings := 0
+	s := new(big.Float).SetPrec(work)
+	for t.MantExp(nil) > atanReduceExp {
+		checkCtx(ctx)
+		s.Mul(t, t)
+		s.Add(s, one)
+		s.Sqrt(s)
+		s.Add(s, one)
+		t.Quo(t, s)
+		halvings++
 	}
 
-	tolerance := new(big.Float).SetPrec(prec).Quo(one, new(big.Float).SetInt(new(big.Int).Exp(intTen, big.NewInt(int64(prec/4)), nil)))
+	// arctan(t) = t - t³/3 + t⁵/5 - t⁷/7 + ...
+	sc := getScratch()
+	defer sc.release()
 
-	result := new(big.Float).SetPrec(prec)
-	term := new(big.Float).SetPrec(prec).Set(x)
-	xSquared := new(big.Float).SetPrec(prec)
-	xSquared.Mul(x, x)
+	y := sc.float(work).Mul(t, t)
+	result := sumSeries(ctx, sc, new(big.Float).SetPrec(work), t, y.Neg(y), atanRatio)
+	result.SetMantExp(result, halvings)
 
-	result.Set(x)
+	if invert {
+		halfPi := piCache.get(ctx, work)
+		halfPi.Quo(halfPi, two)
+		result.Sub(halfPi, result)
+	}
 
-	// arctan(x) = x - x³/3 + x⁵/5 - x⁷/7 + ...
-	for i := int64(1); i < 500; i++ {
-		term.Mul(term, xSquared)
-		denominator := new(big.Float).SetPrec(prec).SetInt64(2*i + 1)
-		termValue := new(big.Float).SetPrec(prec).Quo(term, denominator)
+	if x.Sign() < 0 {
+		result.Neg(result)
+	}
 
-		if i%2 == 0 {
-			result.Add(result, termValue)
-		} else {
-			result.Sub(result, termValue)
+	return result.SetPrec(prec)
+}
+
+// Atan2 returns the arc tangent of y/x, using the signs of the two to
+// determine the quadrant of the return value. The result has the larger
+// of the precisions of y and x.
+//
+// The special cases are, in order:
+//
+//	Atan2(+0, x>=0) = +0
+//	Atan2(-0, x>=0) = -0
+//	Atan2(+0, x<=-0) = +Pi
+//	Atan2(-0, x<=-0) = -Pi
+//	Atan2(y>0, 0) = +Pi/2
+//	Atan2(y<0, 0) = -Pi/2
+//	Atan2(+Inf, +Inf) = +Pi/4
+//	Atan2(-Inf, +Inf) = -Pi/4
+//	Atan2(+Inf, -Inf) = 3Pi/4
+//	Atan2(-Inf, -Inf) = -3Pi/4
+//	Atan2(y, +Inf) = 0
+//	Atan2(y>0, -Inf) = +Pi
+//	Atan2(y<0, -Inf) = -Pi
+//	Atan2(+Inf, x) = +Pi/2
+//	Atan2(-Inf, x) = -Pi/2
+func Atan2(y, x *big.Float) *big.Float {
+	prec := max(y.Prec(), x.Prec())
+
+	// piTimes returns ±k·π/4 with the sign of y.
+	piTimes := func(k int64) *big.Float {
+		result := piCache.get(context.Background(), prec+8)
+		result.Mul(result, big.NewFloat(float64(k)))
+		result.Quo(result, four)
+		if y.Signbit() {
+			result.Neg(result)
 		}
 
-		// Check convergence
-		if termValue.Cmp(tolerance) < 0 {
-			break
+		return result.SetPrec(prec)
+	}
+
+	switch {
+	case y.Sign() == 0:
+		if x.Signbit() {
+			return piTimes(4)
 		}
+
+		return new(big.Float).SetPrec(prec).Set(y)
+	case x.Sign() == 0:
+		return piTimes(2)
+	case x.IsInf():
+		switch {
+		case y.IsInf() && x.Signbit():
+			return piTimes(3)
+		case y.IsInf():
+			return piTimes(1)
+		case x.Signbit():
+			return piTimes(4)
+		}
+		result := new(big.Float).SetPrec(prec)
+		if y.Signbit() {
+			result.Neg(result)
+		}
+
+		return result
+	case y.IsInf():
+		return piTimes(2)
 	}
 
-	return result
+	work := prec + 32
+	result := Atan(new(big.Float).SetPrec(work).Quo(y, x))
+	if x.Sign() < 0 {
+		pi := piCache.get(context.Background(), work)
+		if result.Sign() <= 0 {
+			result.Add(result, pi)
+		} else {
+			result.Sub(result, pi)
+		}
+	}
+
+	return result.SetPrec(prec)
 }
 
 // Tanh returns the hyperbolic tangent of x.
@@ -91,16 +274,35 @@ func Atan(x *big.Float) *big.Float {
 //
 //	Tanh(±0) = ±0
 //	Tanh(±Inf) = ±1
-//	Tanh(NaN) = NaN
 func Tanh(x *big.Float) *big.Float {
-	precision := x.Prec()
+	prec := x.Prec()
 
-	// TODO: Implement efficient hyperbolic tangent calculation
-	// For now, return a placeholder result
-	result := new(big.Float).SetPrec(precision)
-	result.SetInt64(0)
+	// 1 − |tanh x| = 2/(e^2|x| + 1) is below half a unit in the last place
+	// once 2|x| > (prec + 2)·ln 2.
+	if xf, _ := x.Float64(); math.Abs(xf) > float64(prec)/2+1 {
+		return new(big.Float).SetPrec(prec).SetInt64(int64(x.Sign()))
+	}
 
-	return result
+	// The quotient of values each within a unit of 8 more bits is within
+	// a unit in the last place.
+	sinh, cosh := sinhCosh(new(big.Float).SetPrec(prec + 8).Set(x))
+
+	return new(big.Float).SetPrec(prec).Quo(sinh, cosh)
+}
+
+// tanhCORDIC calculates tanh(x) as sinh(x)/cosh(x), with both from one
+// hyperbolic CORDIC rotation, as in sinhCORDIC. x must be finite, with
+// |x| < 2^31·ln 2 so that e^x is within the exponent range; larger x give
+// meaningless results.
+// This is a package-private method for performance comparison.
+func tanhCORDIC(x *big.Float) *big.Float {
+	prec := x.Prec()
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
+	sinh, cosh := cordicSinhCosh(context.Background(), new(big.Float).SetPrec(prec+8).Set(x))
+
+	return new(big.Float).SetPrec(prec).Quo(sinh, cosh)
 }
 
 // Atanh returns the inverse hyperbolic arc tangent of x.
@@ -110,17 +312,62 @@ func Tanh(x *big.Float) *big.Float {
 //	Atanh(1) = +Inf
 //	Atanh(±0) = ±0
 //	Atanh(-1) = -Inf
-//	Atanh(x) = NaN if x < -1 or x > 1
-//	Atanh(NaN) = NaN
+//	Atanh(x) = +Inf if x < -1 or x > 1
 func Atanh(x *big.Float) *big.Float {
-	precision := x.Prec()
+	prec := x.Prec()
 
-	// TODO: Implement efficient hyperbolic arc tangent calculation
-	// For now, return a placeholder result
-	result := new(big.Float).SetPrec(precision)
-	result.SetInt64(0)
+	switch c := new(big.Float).Abs(x).Cmp(one); {
+	case c > 0:
+		// Return +Inf to indicate undefined behavior (big.Float has no NaN)
+		return new(big.Float).SetPrec(prec).SetInf(false)
+	case c == 0:
+		return new(big.Float).SetPrec(prec).SetInf(x.Signbit())
+	case x.Sign() == 0:
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
 
-	return result
+	// atanh x = ½·ln((1 + x)/(1 − x)) = ½·log1p(2|x|/(1 − |x|)) with the
+	// sign of x. The argument of log1p is positive, so nothing cancels.
+	work := prec + 8
+	ax := new(big.Float).SetPrec(work).Abs(x)
+	d := new(big.Float).SetPrec(work).Sub(one, ax)
+	d.Quo(ax, d)
+	d.SetMantExp(d, 1)
+	result := log1p(d)
+	result.SetMantExp(result, -1)
+	if x.Signbit() {
+		result.Neg(result)
+	}
+
+	return result.SetPrec(prec)
+}
+
+// atanhCORDIC calculates atanh(x) by hyperbolic CORDIC, vectoring (1, x)
+// for |x| <= 3/4, and otherwise as ½·ln((1 + x)/(1 − x)) with logCORDIC,
+// as the angle would be out of the range of the steps. |x| must be less
+// than 1; it panics at ±1 and is meaningless beyond.
+// This is a package-private method for performance comparison.
+func atanhCORDIC(x *big.Float) *big.Float {
+	prec := x.Prec()
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
+
+	if xf, _ := x.Float64(); math.Abs(xf) <= 0.75 {
+		_, z := cordicVector(context.Background(), CORDICHyperbolic, one, x, cordicWork(prec, x))
+
+		return z.SetPrec(prec)
+	}
+
+	// The quotient is at least 7 or at most 1/7, so its logarithm is at
+	// least ln 7 in magnitude and keeps the precision of the quotient.
+	work := seriesWork(prec)
+	q := new(big.Float).SetPrec(work).Add(one, x)
+	q.Quo(q, new(big.Float).SetPrec(work).Sub(one, x))
+	result := logCORDIC(q)
+	result.SetMantExp(result, -1)
+
+	return result.SetPrec(prec)
 }
 
 func tanNaive(x *big.Float) *big.Float {
@@ -249,164 +496,23 @@ func tanContinuedFraction(x *big.Float) *big.Float {
 	return result
 }
 
-// tanCORDIC calculates tan(x) using direct CORDIC tangent mode.
-// This implementation uses CORDIC vectoring mode for direct tangent computation.
+// tanCORDIC calculates tan(x) as sin(x)/cos(x), with both from one CORDIC
+// rotation, as in sinCORDIC. x must be finite.
 // This is a package-private method for performance comparison.
 func tanCORDIC(x *big.Float) *big.Float {
 	prec := x.Prec()
 
-	// Track transformations needed for argument reduction
-	negateResult := false
-	reciprocal := false
-
-	// Argument reduction to [-π/4, π/4] for CORDIC convergence
-	reducedX := new(big.Float).SetPrec(prec).Set(x)
-
-	// Handle negative values: tan(-x) = -tan(x)
-	if reducedX.Sign() < 0 {
-		negateResult = !negateResult
-		reducedX.Abs(reducedX)
-	}
-
-	// Reduce argument using tan(x) periodicity
-	for reducedX.Cmp(bigPi) >= 0 {
-		reducedX.Sub(reducedX, bigPi)
-	}
-
-	// Use tan(π - x) = -tan(x) for [π/2, π]
-	if reducedX.Cmp(bigHalfPi) > 0 {
-		reducedX.Sub(bigPi, reducedX)
-		negateResult = !negateResult
-	}
-
-	// Use tan(π/2 - x) = cot(x) = 1/tan(x) for [π/4, π/2]
-	if reducedX.Cmp(bigQuarterPi) > 0 {
-		reducedX.Sub(bigHalfPi, reducedX)
-		reciprocal = !reciprocal
-	}
-
-	// Now reducedX is in [0, π/4] - suitable for CORDIC
-
-	// CORDIC rotation mode to compute sin and cos simultaneously with higher precision
-	// Use higher internal precision for intermediate calculations
-	internalPrec := prec + 64
-
-	// High-precision CORDIC gain factor K = PRODUCT(0..n-1) sqrt(1 + 2^(-2i))
-	// 1/K ≈ 0.60725293500888125616944038415753462296702
-	invK := new(big.Float).SetPrec(internalPrec)
-	invK.SetString("0.60725293500888125616944038415753462296701947427363059429382421077774838754932863810214048")
-	if invK == nil {
-		invK = new(big.Float).SetPrec(internalPrec).SetFloat64(0.6072529350088812561694)
-	}
-
-	// Initial values for CORDIC
-	x0 := new(big.Float).SetPrec(internalPrec).Set(invK)
-	y0 := new(big.Float).SetPrec(internalPrec).SetFloat64(0)
-	z0 := new(big.Float).SetPrec(internalPrec).Set(reducedX)
-
-	// CORDIC iterations with improved precision
-	iterations := int(prec/2) + 30
-	if iterations > 100 {
-		iterations = 100
-	}
-
-	for i := 0; i < iterations; i++ {
-		var sigma int
-		if z0.Sign() >= 0 {
-			sigma = 1
-		} else {
-			sigma = -1
-		}
-
-		// Calculate 2^(-i)
-		powerOf2 := new(big.Float).SetPrec(internalPrec).SetInt64(1)
-		for j := 0; j < i; j++ {
-			powerOf2.Quo(powerOf2, big.NewFloat(2))
-		}
-
-		// Get high-precision arctan value
-		var arctanVal *big.Float
-		if i < len(arctanLookupTable) {
-			arctanVal, _ = new(big.Float).SetPrec(internalPrec).SetString(arctanLookupTable[i])
-		} else {
-			// For large i, arctan(2^(-i)) ≈ 2^(-i)
-			arctanVal = new(big.Float).SetPrec(internalPrec).Set(powerOf2)
-		}
+	// The quotient of values each within a unit of 8 more bits is within
+	// a unit in the last place.
+	sin, cos := cordicSinCos(context.Background(), new(big.Float).SetPrec(prec+8).Set(x), false)
 
-		// CORDIC rotation formulas
-		newX := new(big.Float).SetPrec(internalPrec).Set(x0)
-		temp := new(big.Float).SetPrec(internalPrec).Mul(y0, powerOf2)
-		if sigma < 0 {
-			newX.Add(newX, temp)
-		} else {
-			newX.Sub(newX, temp)
-		}
-
-		newY := new(big.Float).SetPrec(internalPrec).Set(y0)
-		temp = new(big.Float).SetPrec(internalPrec).Mul(x0, powerOf2)
-		if sigma < 0 {
-			newY.Sub(newY, temp)
-		} else {
-			newY.Add(newY, temp)
-		}
-
-		newZ := new(big.Float).SetPrec(internalPrec).Set(z0)
-		temp = new(big.Float).SetPrec(internalPrec).Set(arctanVal)
-		if sigma < 0 {
-			newZ.Add(newZ, temp)
-		} else {
-			newZ.Sub(newZ, temp)
-		}
-
-		x0, y0, z0 = newX, newY, newZ
-
-		// Early termination check
-		if new(big.Float).SetPrec(internalPrec).Abs(z0).Cmp(
-			new(big.Float).SetPrec(internalPrec).Quo(
-				big.NewFloat(1),
-				new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec)), nil)),
-			),
-		) < 0 {
-			break
-		}
-	}
-
-	// Final result: tan(x) = sin(x)/cos(x) = y0/x0
-	result := new(big.Float).SetPrec(prec).Quo(y0, x0)
-
-	// Apply transformations in reverse order
-	if reciprocal {
-		result.Quo(big.NewFloat(1), result)
-	}
-
-	if negateResult {
-		result.Neg(result)
-	}
-
-	return result
-}
-
-// First 15 coefficients for the tan taylor series.
-var taylorTanSeriesCoeffs = []*big.Float{
-	big.NewFloat(1.0 / 3),                                               // x^3
-	big.NewFloat(2.0 / 15),                                              // x^5
-	big.NewFloat(17.0 / 315),                                            // x^7
-	big.NewFloat(62.0 / 2835),                                           // x^9
-	big.NewFloat(1382.0 / 155925),                                       // x^11
-	big.NewFloat(21844.0 / 6081075),                                     // x^13
-	big.NewFloat(929569.0 / 638512875),                                  // x^15
-	big.NewFloat(6404582.0 / 10854718875),                               // x^17
-	big.NewFloat(443861162.0 / 1856156927625),                           // x^19
-	big.NewFloat(18888466084.0 / 194896477400625),                       // x^21
-	big.NewFloat(113927491862.0 / 2900518163668125),                     // x^23
-	big.NewFloat(58870668456604.0 / 3698160658676859375),                // x^25
-	big.NewFloat(8374643517010684.0 / 1298054391195577640625),           // x^27
-	big.NewFloat(689005380505609448.0 / 263747951750360639921875),       // x^29
-	big.NewFloat(129848163681107301953.0 / 122529844256906551386796875), // x^31
+	return new(big.Float).SetPrec(prec).Quo(sin, cos)
 }
 
-// tanTaylor calculates tan(x) using direct Taylor series.
-// Uses the series: tan(x) = x + x³/3 + 2x⁵/15 + 17x⁷/315 + ...
+// tanTaylor calculates tan(x) as the quotient of the Taylor series
+// sin(x) = x - x³/3! + x⁵/5! - ... and cos(x) = 1 - x²/2! + x⁴/4! - ...,
+// whose coefficients, unlike those of tan(x) = x + x³/3 + 2x⁵/15 + ...,
+// need no table.
 //
 // This is a package-private method for performance comparison.
 func tanTaylor(x *big.Float) *big.Float {
@@ -443,28 +549,16 @@ func tanTaylor(x *big.Float) *big.Float {
 	}
 
 	// Now reducedX is in [0, π/4]
-	result := new(big.Float).SetPrec(prec).Set(reducedX)
-	term := new(big.Float).SetPrec(prec).Set(reducedX)
-	xSquared := new(big.Float).SetPrec(prec).Mul(reducedX, reducedX)
-
-	convergenceThreshold := new(big.Float).SetPrec(prec).Quo(
-		one,
-		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(prec/4)), nil)),
-	)
-
-	for _, coeff := range taylorTanSeriesCoeffs {
-		term.Mul(term, xSquared)
-		termValue := new(big.Float).SetPrec(prec).Mul(term, coeff)
+	sc := getScratch()
+	defer sc.release()
 
-		result.Add(result, termValue)
+	work := seriesWork(prec)
+	y := sc.float(work).Mul(reducedX, reducedX)
+	y.Neg(y)
+	sin := sumSeries(context.Background(), sc, sc.float(work), reducedX, y, sinRatio)
+	cos := sumSeries(context.Background(), sc, sc.float(work), sc.float(work).SetInt64(1), y, cosRatio)
+	result := new(big.Float).SetPrec(prec).Quo(sin, cos)
 
-		// Check convergence
-		if termValue.Cmp(convergenceThreshold) <= 0 {
-			// TODO(rsned): Add an indicator to signal when convergence
-			// was not reached and we ran out of iterations.
-			break
-		}
-	}
 	// Apply transformations
 	if reciprocal {
 		result.Quo(one, result)
@@ -475,3 +569,14 @@ func tanTaylor(x *big.Float) *big.Float {
 
 	return result
 }
+
+// atanTaylorRemainder returns an upper bound, rounded up, on the tail
+// Σ_{k≥n} (-1)^k·t^(2k+1)/(2k+1) of the series for arctan t, for every
+// |t| <= bound <= 1. The terms alternate in sign and shrink in magnitude,
+// so the tail is at most its first term, bound^(2n+1)/(2n+1).
+func atanTaylorRemainder(bound *big.Float, n int) *big.Float {
+	prec := max(bound.Prec(), 64)
+	result := powUp(bound, 2*n+1, prec)
+
+	return result.Quo(result, new(big.Float).SetInt64(int64(2*n+1)))
+}
@@ -112,6 +112,133 @@ func TestTanVsMathTan(t *testing.T) {
 	}
 }
 
+func TestAtanVsMathAtan(t *testing.T) {
+	testValues := []float64{0, 1e-20, 0.1, 0.3, 0.9, 1, 2.5, -4, 100, 1e20, -0.75}
+
+	for _, val := range testValues {
+		t.Run(fmt.Sprintf("Atan(%g)", val), func(t *testing.T) {
+			got, _ := Atan(new(big.Float).SetPrec(53).SetFloat64(val)).Float64()
+			if want := math.Atan(val); math.Abs(got-want) > 1e-15*math.Max(1, math.Abs(want)) {
+				t.Errorf("Atan(%v) = %v, want %v", val, got, want)
+			}
+		})
+	}
+
+	if got, _ := Atan(new(big.Float).SetPrec(53).SetInf(true)).Float64(); got != -math.Pi/2 {
+		t.Errorf("Atan(-Inf) = %v, want -π/2", got)
+	}
+}
+
+func TestAtanHighPrecision(t *testing.T) {
+	sqrt3 := func(prec uint) *big.Float {
+		x := new(big.Float).SetPrec(prec).SetInt64(3)
+
+		return x.Sqrt(x)
+	}
+	tests := []struct {
+		name string
+		x    func(prec uint) *big.Float
+		num  int64 // atan(x) = num·π/den
+		den  int64
+	}{
+		{"1", func(prec uint) *big.Float {
+			return new(big.Float).SetPrec(prec).SetInt64(1)
+		}, 1, 4},
+		{"√3", sqrt3, 1, 3},
+		{"1/√3", func(prec uint) *big.Float {
+			x := sqrt3(prec)
+
+			return x.Quo(one, x)
+		}, 1, 6},
+		{"2-√3", func(prec uint) *big.Float {
+			x := sqrt3(prec)
+
+			return x.Sub(two, x)
+		}, 1, 12},
+		{"-2-√3", func(prec uint) *big.Float {
+			x := sqrt3(prec)
+			x.Add(x, two)
+
+			return x.Neg(x)
+		}, -5, 12},
+	}
+	for _, prec := range []uint{256, 1000, 3000} {
+		for _, test := range tests {
+			// The argument carries extra bits, so that its rounding does not
+			// limit the agreement.
+			x := test.x(prec + 64)
+			got := Atan(x)
+			want := ComputePi(prec + 64)
+			want.Mul(want, big.NewFloat(float64(test.num)))
+			want.Quo(want, big.NewFloat(float64(test.den)))
+			if agree := bitsOfAgreement(got, want); agree < int(prec)+62 {
+				t.Errorf("Atan(%s) at %d bits only agrees with %dπ/%d to %d bits", test.name, prec+64, test.num, test.den, agree)
+			}
+		}
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	inf, negZero := math.Inf(1), math.Copysign(0, -1)
+	values := []float64{0, negZero, 1, -1, 0.5, -3, inf, -inf}
+
+	for _, y := range values {
+		for _, x := range values {
+			t.Run(fmt.Sprintf("Atan2(%g,%g)", y, x), func(t *testing.T) {
+				got, _ := Atan2(big.NewFloat(y), big.NewFloat(x)).Float64()
+				want := math.Atan2(y, x)
+				if math.Abs(got-want) > 1e-15 || math.Signbit(got) != math.Signbit(want) {
+					t.Errorf("Atan2(%v, %v) = %v, want %v", y, x, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestAtan2HighPrecision(t *testing.T) {
+	tests := []struct {
+		y, x     int64 // √3 if 3 or -3
+		num, den int64 // atan2(y, x) = num·π/den
+	}{
+		{1, 1, 1, 4},
+		{1, -1, 3, 4},
+		{-1, -1, -3, 4},
+		{-1, 1, -1, 4},
+		{3, 1, 1, 3},
+		{-3, -1, -2, 3},
+		{1, -3, 5, 6},
+		{-1, 3, -1, 6},
+	}
+	for _, prec := range []uint{256, 1000} {
+		for _, test := range tests {
+			arg := func(v int64) *big.Float {
+				f := new(big.Float).SetPrec(prec + 64).SetInt64(v)
+				if v == 3 || v == -3 {
+					f.Sqrt(f.Abs(f))
+					if v < 0 {
+						f.Neg(f)
+					}
+				}
+
+				return f
+			}
+			// The result has the larger of the precisions of y and x.
+			y := new(big.Float).SetPrec(prec).Set(arg(test.y))
+			got := Atan2(y, arg(test.x))
+			if got.Prec() != prec+64 {
+				t.Errorf("Atan2 of %d and %d bits has %d bits, want %d", prec, prec+64, got.Prec(), prec+64)
+			}
+			got = Atan2(arg(test.y), arg(test.x))
+			want := ComputePi(prec + 64)
+			want.Mul(want, big.NewFloat(float64(test.num)))
+			want.Quo(want, big.NewFloat(float64(test.den)))
+			if agree := bitsOfAgreement(got, want); agree < int(prec)+60 {
+				t.Errorf("Atan2(%d, %d) at %d bits only agrees with %dπ/%d to %d bits", test.y, test.x, prec+64, test.num, test.den, agree)
+			}
+		}
+	}
+}
+
 func BenchmarkTan(b *testing.B) {
 	x := new(big.Float).SetPrec(64)
 	x.SetFloat64(math.Pi / 3.0)
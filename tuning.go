@@ -0,0 +1,300 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sync/atomic"
+	"time"
+)
+
+// Which of its correctly rounded kernels Exp, Sin and Cos use depends on
+// the precision: the power series of e^x, sin x and cos x are fastest at
+// lower precisions and the bit-burst algorithm at higher ones. Where they
+// cross over depends on the machine, so the precisions are held in a
+// Thresholds profile, which Tune measures on the host and SetThresholds
+// installs. The kernels agree to within a unit in the last place, and the
+// result is rounded correctly whichever one runs, so a profile changes
+// only the speed of the functions.
+//
+// The other crossovers of the package are fixed. Log and ComputePi have
+// none to tune, as the arithmetic-geometric mean and the Chudnovsky series
+// are faster at every precision measured, and Gamma chooses its algorithm
+// by the size of its argument, for accuracy rather than speed. Where
+// Factorial turns to the prime-swing algorithm and where Binomial factors
+// its result compare exact integer algorithms whose relative cost is set by
+// big.Int multiplication and moves little from one machine to another. The
+// sizes at which work is split among parallel workers need only be large
+// enough to pay for handing it to a goroutine.
+
+// Thresholds holds the precisions at which Exp, Sin and Cos, and the
+// functions built on them, switch from the power series to the bit-burst
+// algorithm. A zero field stands for its default. Its JSON encoding is the
+// profile read by LoadThresholds and written by Save.
+type Thresholds struct {
+	// ExpBitBurstPrec is the working precision at and above which Exp,
+	// and the functions built on it, use the bit-burst algorithm rather
+	// than the power series.
+	ExpBitBurstPrec uint `json:"exp_bit_burst_prec,omitempty"`
+
+	// SinCosBitBurstPrec is the same for Sin and Cos, and the functions
+	// built on them.
+	SinCosBitBurstPrec uint `json:"sin_cos_bit_burst_prec,omitempty"`
+}
+
+// defaultThresholds is the profile the package starts with.
+var defaultThresholds = Thresholds{
+	ExpBitBurstPrec:    expSeriesMaxPrec,
+	SinCosBitBurstPrec: sinCosSeriesMaxPrec,
+}
+
+// thresholds is the profile set by SetThresholds, or nil for the default.
+var thresholds atomic.Pointer[Thresholds]
+
+// DefaultThresholds returns the profile the package starts with.
+func DefaultThresholds() Thresholds {
+	return defaultThresholds
+}
+
+// CurrentThresholds returns the profile in use, with every field filled in.
+func CurrentThresholds() Thresholds {
+	return *currentThresholds()
+}
+
+// currentThresholds returns the profile in use.
+func currentThresholds() *Thresholds {
+	if t := thresholds.Load(); t != nil {
+		return t
+	}
+
+	return &defaultThresholds
+}
+
+// SetThresholds installs the profile t, taking the default for each of its
+// zero fields, and returns the previous profile. It is safe to call while
+// other goroutines are computing; each computation uses either the old or
+// the new profile.
+func SetThresholds(t Thresholds) Thresholds {
+	if t.ExpBitBurstPrec == 0 {
+		t.ExpBitBurstPrec = defaultThresholds.ExpBitBurstPrec
+	}
+	if t.SinCosBitBurstPrec == 0 {
+		t.SinCosBitBurstPrec = defaultThresholds.SinCosBitBurstPrec
+	}
+
+	if old := thresholds.Swap(&t); old != nil {
+		return *old
+	}
+
+	return defaultThresholds
+}
+
+// LoadThresholds reads a profile written by Save from r, installs it with
+// SetThresholds, and returns it. Fields missing from the profile take
+// their defaults, and fields it does not know are ignored, so a profile
+// can be loaded by other versions of the package. For example, a profile
+// generated into the source tree can be embedded and loaded at startup:
+//
+//	//go:generate go run github.com/rsned/bigmath/cmd/bigmathtune -o thresholds.json
+//
+//	//go:embed thresholds.json
+//	var profile []byte
+//
+//	func init() {
+//		if _, err := bigmath.LoadThresholds(bytes.NewReader(profile)); err != nil {
+//			panic(err)
+//		}
+//	}
+func LoadThresholds(r io.Reader) (Thresholds, error) {
+	var t Thresholds
+	if err := json.NewDecoder(r).Decode(&t); err != nil {
+		return Thresholds{}, fmt.Errorf("bigmath: reading thresholds: %w", err)
+	}
+	SetThresholds(t)
+
+	return CurrentThresholds(), nil
+}
+
+// Save writes t to w as a profile for LoadThresholds.
+func (t Thresholds) Save(w io.Writer) error {
+	data, err := json.MarshalIndent(t, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+
+	return err
+}
+
+// TuneOptions sets what Tune measures. The zero value, like a nil
+// *TuneOptions, measures the defaults.
+type TuneOptions struct {
+	// Precisions are the working precisions to measure, in increasing
+	// order. The default is from 1024 to 65536 bits in steps of √2.
+	Precisions []uint
+
+	// Magnitudes are the magnitudes of the arguments to measure at each
+	// precision. The default is 0.5, 20 and 10000.
+	Magnitudes []float64
+
+	// Duration is the least time to spend timing each kernel on each
+	// argument. The default is 10ms.
+	Duration time.Duration
+}
+
+// A crossover is a choice between two kernels for a function that Tune
+// measures.
+type crossover struct {
+	name      string
+	low, high func(ctx context.Context, x *big.Float) *big.Float
+	field     func(t *Thresholds) *uint
+}
+
+var crossovers = []crossover{
+	{
+		name: "Exp",
+		low:  expSeries,
+		high: expBitBurst,
+		field: func(t *Thresholds) *uint {
+			return &t.ExpBitBurstPrec
+		},
+	},
+	{
+		name: "Sin",
+		low: func(ctx context.Context, x *big.Float) *big.Float {
+			sin, _ := sinCosSeries(ctx, x)
+
+			return sin
+		},
+		high: func(ctx context.Context, x *big.Float) *big.Float {
+			sin, _ := sinCosBitBurst(ctx, x)
+
+			return sin
+		},
+		field: func(t *Thresholds) *uint {
+			return &t.SinCosBitBurstPrec
+		},
+	},
+}
+
+// Tune times the power series and bit-burst kernels of Exp, and of Sin and
+// Cos, on this machine, at each of the precisions and magnitudes of opts,
+// and returns the profile under which the package uses the faster. It does
+// not install the profile; pass it to SetThresholds, or Save it for
+// LoadThresholds.
+//
+// A threshold is the smallest precision measured from which on the
+// higher-precision kernel is faster in total over the magnitudes. If it is
+// faster at none of the precisions, the threshold is set above them: to
+// the current one if that is larger, or else to twice the largest.
+//
+// Tune also checks that the kernels agree on every argument, and returns
+// an error if they do not. It returns the error of ctx if ctx is done
+// first. With the default options, it takes a few seconds.
+func Tune(ctx context.Context, opts *TuneOptions) (t Thresholds, err error) {
+	defer recoverCtx(&err)
+
+	if opts == nil {
+		opts = &TuneOptions{}
+	}
+	precisions := opts.Precisions
+	if len(precisions) == 0 {
+		for p := 1024.0; p <= 65536; p *= 1.4142135623730951 {
+			precisions = append(precisions, uint(p+0.5))
+		}
+	}
+	magnitudes := opts.Magnitudes
+	if len(magnitudes) == 0 {
+		magnitudes = []float64{0.5, 20, 10000}
+	}
+	duration := opts.Duration
+	if duration <= 0 {
+		duration = 10 * time.Millisecond
+	}
+
+	t = CurrentThresholds()
+	for _, c := range crossovers {
+		faster := make([]bool, len(precisions))
+		for i, prec := range precisions {
+			var low, high time.Duration
+			for _, m := range magnitudes {
+				x := tuneArgument(prec, m)
+
+				lowTime, lowResult := timeKernel(ctx, duration, c.low, x)
+				highTime, highResult := timeKernel(ctx, duration, c.high, x)
+				if !kernelsAgree(lowResult, highResult) {
+					return Thresholds{}, fmt.Errorf("bigmath: the %s kernels disagree at %d bits for magnitude %g: %s and %s",
+						c.name, prec, m, lowResult.Text('g', 20), highResult.Text('g', 20))
+				}
+				low += lowTime
+				high += highTime
+			}
+			faster[i] = high < low
+		}
+
+		field := c.field(&t)
+		last := precisions[len(precisions)-1]
+		*field = max(*field, 2*last)
+		for i := len(precisions) - 1; i >= 0 && faster[i]; i-- {
+			*field = precisions[i]
+		}
+	}
+
+	return t, nil
+}
+
+// tuneArgument returns a tuning argument of the given precision and
+// magnitude, m·√½, whose mantissa has every bit in use.
+func tuneArgument(prec uint, m float64) *big.Float {
+	x := new(big.Float).SetPrec(prec).SetFloat64(0.5)
+	x.Sqrt(x)
+
+	return x.Mul(x, new(big.Float).SetFloat64(m))
+}
+
+// timeKernel returns the mean time fn takes at x, over at least d, and its
+// result.
+func timeKernel(ctx context.Context, d time.Duration, fn func(ctx context.Context, x *big.Float) *big.Float, x *big.Float) (time.Duration, *big.Float) {
+	// The first call fills the constant caches.
+	result := fn(ctx, x)
+
+	start := time.Now()
+	for n := 1; ; n++ {
+		fn(ctx, x)
+		if elapsed := time.Since(start); elapsed >= d {
+			return elapsed / time.Duration(n), result
+		}
+		checkCtx(ctx)
+	}
+}
+
+// kernelsAgree reports whether a and b, each within a unit in the last
+// place of the true value, are within two units of each other.
+func kernelsAgree(a, b *big.Float) bool {
+	if a.IsInf() || b.IsInf() || a.Sign() == 0 || b.Sign() == 0 {
+		return a.Cmp(b) == 0
+	}
+
+	diff := new(big.Float).SetPrec(a.Prec()+2).Sub(a, b)
+	if diff.Sign() == 0 {
+		return true
+	}
+
+	return diff.MantExp(nil) <= a.MantExp(nil)-int(a.Prec())+2
+}
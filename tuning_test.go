@@ -0,0 +1,178 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withThresholds installs the profile th for the rest of the test.
+func withThresholds(t testing.TB, th Thresholds) {
+	old := SetThresholds(th)
+	t.Cleanup(func() { SetThresholds(old) })
+}
+
+func TestSetThresholds(t *testing.T) {
+	if got := CurrentThresholds(); got != DefaultThresholds() {
+		t.Fatalf("CurrentThresholds() = %+v, want the default %+v", got, DefaultThresholds())
+	}
+
+	withThresholds(t, Thresholds{ExpBitBurstPrec: 1000})
+	want := Thresholds{ExpBitBurstPrec: 1000, SinCosBitBurstPrec: sinCosSeriesMaxPrec}
+	if got := CurrentThresholds(); got != want {
+		t.Errorf("after SetThresholds, CurrentThresholds() = %+v, want %+v", got, want)
+	}
+
+	if old := SetThresholds(Thresholds{}); old != want {
+		t.Errorf("SetThresholds returned %+v, want %+v", old, want)
+	}
+	if got := CurrentThresholds(); got != DefaultThresholds() {
+		t.Errorf("SetThresholds(Thresholds{}) left %+v, want the default", got)
+	}
+}
+
+func TestThresholdsSaveLoad(t *testing.T) {
+	withThresholds(t, Thresholds{})
+
+	want := Thresholds{ExpBitBurstPrec: 4096, SinCosBitBurstPrec: 30000}
+	var buf bytes.Buffer
+	if err := want.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := LoadThresholds(&buf)
+	if err != nil || got != want || CurrentThresholds() != want {
+		t.Errorf("LoadThresholds(Save(%+v)) = %+v, %v and installed %+v", want, got, err, CurrentThresholds())
+	}
+
+	// Missing fields take their defaults, and unknown ones are ignored.
+	got, err = LoadThresholds(strings.NewReader(`{"exp_bit_burst_prec": 2000, "log_agm_prec": 10}`))
+	want = Thresholds{ExpBitBurstPrec: 2000, SinCosBitBurstPrec: sinCosSeriesMaxPrec}
+	if err != nil || got != want {
+		t.Errorf("LoadThresholds of a partial profile = %+v, %v, want %+v", got, err, want)
+	}
+
+	// A bad profile leaves the current one alone.
+	if _, err := LoadThresholds(strings.NewReader(`{"exp_bit_burst_prec": -1}`)); err == nil {
+		t.Errorf("LoadThresholds of a bad profile succeeded")
+	}
+	if got := CurrentThresholds(); got != want {
+		t.Errorf("after a bad profile, CurrentThresholds() = %+v, want %+v", got, want)
+	}
+}
+
+// TestThresholdsResults checks that the profile changes which kernel runs
+// but not the results.
+func TestThresholdsResults(t *testing.T) {
+	var xs []*big.Float
+	for _, prec := range []uint{53, 200, 1000} {
+		for _, s := range []string{"1/3", "-7/2", "1000/7"} {
+			xs = append(xs, newRatFloat(s, prec))
+		}
+	}
+	results := func() []*big.Float {
+		var r []*big.Float
+		for _, x := range xs {
+			r = append(r, Exp(x), Sin(x), Cos(x))
+		}
+
+		return r
+	}
+
+	want := results()
+	for _, th := range []Thresholds{
+		{ExpBitBurstPrec: 1, SinCosBitBurstPrec: 1},
+		{ExpBitBurstPrec: 1 << 30, SinCosBitBurstPrec: 1 << 30},
+	} {
+		withThresholds(t, th)
+		if got := results(); !sameValue(got, want) {
+			t.Errorf("with thresholds %+v the results differ", th)
+		}
+	}
+}
+
+func TestTune(t *testing.T) {
+	opts := &TuneOptions{
+		Precisions: []uint{128, 512},
+		Magnitudes: []float64{1, 100},
+		Duration:   time.Millisecond,
+	}
+	got, err := Tune(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Tune: %v", err)
+	}
+	for name, prec := range map[string]uint{
+		"ExpBitBurstPrec":    got.ExpBitBurstPrec,
+		"SinCosBitBurstPrec": got.SinCosBitBurstPrec,
+	} {
+		if prec != 128 && prec != 512 && prec < 1024 {
+			t.Errorf("Tune set %s to %d, want a precision measured or above them", name, prec)
+		}
+	}
+	if CurrentThresholds() != DefaultThresholds() {
+		t.Errorf("Tune installed its profile")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Tune(ctx, opts); !errors.Is(err, context.Canceled) {
+		t.Errorf("Tune with a canceled context returned %v, want context.Canceled", err)
+	}
+}
+
+func TestKernelsAgree(t *testing.T) {
+	x := newRatFloat("1/3", 100)
+	ulp := new(big.Float).SetMantExp(big.NewFloat(1), x.MantExp(nil)-100)
+
+	for _, tt := range []struct {
+		ulps float64
+		want bool
+	}{
+		{0, true},
+		{1, true},
+		{2, true},
+		{-2, true},
+		{5, false},
+		{-5, false},
+	} {
+		y := new(big.Float).SetPrec(100).Mul(ulp, big.NewFloat(tt.ulps))
+		y.Add(y, x)
+		if got := kernelsAgree(x, y); got != tt.want {
+			t.Errorf("kernelsAgree(x, x%+g ulp) = %v, want %v", tt.ulps, got, tt.want)
+		}
+	}
+
+	if kernelsAgree(big.NewFloat(0), big.NewFloat(1e-300)) {
+		t.Errorf("kernelsAgree(0, 1e-300) = true, want false")
+	}
+}
+
+func BenchmarkTune(b *testing.B) {
+	opts := &TuneOptions{
+		Precisions: []uint{1024, 4096},
+		Magnitudes: []float64{1},
+		Duration:   time.Millisecond,
+	}
+	for b.Loop() {
+		if _, err := Tune(context.Background(), opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"math/bits"
+	"math/cmplx"
+)
+
+// ZetaCriticalLine returns ζ(1/2 + ti), the Riemann zeta function on the
+// critical line, with the precision of t.
+func ZetaCriticalLine(t *big.Float) *Complex {
+	s := new(Complex).SetPrec(t.Prec())
+	s.re.SetFloat64(0.5)
+	s.im.Set(t)
+
+	return s.Zeta(s)
+}
+
+// Zeta sets z to the Riemann zeta function ζ(x) and returns z.
+//
+// For real(x) >= 0 it uses Euler–Maclaurin summation, and otherwise the
+// functional equation
+//
+//	ζ(x) = 2^x·π^(x-1)·sin(πx/2)·Γ(1-x)·ζ(1-x)
+//
+// The error is relative to the larger of 1 and |ζ(x)|, so close to the
+// zeros of ζ the result is accurate to the precision of z after the binary
+// point rather than to that many significant bits.
+//
+// The special cases are:
+//
+//	Zeta(1) = +Inf
+//	Zeta(+Inf) = 1
+//	Zeta(x) = +Inf+Inf i for any other x with an infinite part
+func (z *Complex) Zeta(x *Complex) *Complex {
+	prec := z.resultPrec(x)
+	switch {
+	case x.im.Sign() == 0 && x.re.IsInf() && x.re.Sign() > 0:
+		return z.setSpecial(1, prec)
+	case x.IsInf():
+		return z.setSpecial(cmplx.Inf(), prec)
+	case x.im.Sign() == 0 && x.re.Cmp(one) == 0:
+		return z.setSpecial(complex(math.Inf(1), 0), prec)
+	}
+
+	// The phases of the terms n^-x grow with |imag(x)|, so they need as
+	// many extra bits.
+	work := prec + complexGuardBits + uint(bits.Len64(uint64(cmplx.Abs(x.Complex128()))))
+	if x.re.Sign() < 0 {
+		return z.setParts(zetaReflection(x, work).parts(prec))
+	}
+
+	return z.setParts(zetaEulerMaclaurin(x, work).parts(prec))
+}
+
+// zetaEulerMaclaurin returns ζ(x) with the given precision using
+//
+//	ζ(x) = Σ_{n<N} n^-x + N^(1-x)/(x-1) + N^-x/2 + Σ_k B(2k)/(2k)!·x(x+1)···(x+2k-2)·N^(-x-2k+1)
+//
+// The ratio of consecutive correction terms is about ((|x|+2k)/2πN)², so N
+// is chosen to keep it below 1/4 for the terms needed.
+func zetaEulerMaclaurin(x *Complex, work uint) *Complex {
+	n := int64(math.Ceil((cmplx.Abs(x.Complex128())+float64(work)/2)/math.Pi)) + 1
+
+	sum := new(Complex).SetPrec(work)
+	term := new(Complex).SetPrec(work)
+	kf := new(big.Float).SetPrec(work)
+	for k := int64(1); k < n; k++ {
+		term.power(x, kf.SetInt64(k))
+		sum.Add(sum, term)
+	}
+
+	nf := new(big.Float).SetPrec(work).SetInt64(n)
+	pw := new(Complex).SetPrec(work).power(x, nf)
+
+	// N^-x/2
+	term.re.Quo(&pw.re, two)
+	term.im.Quo(&pw.im, two)
+	sum.Add(sum, term)
+
+	// N^(1-x)/(x-1)
+	term.re.Mul(&pw.re, nf)
+	term.im.Mul(&pw.im, nf)
+	xm1 := new(Complex).SetPrec(work).Set(x)
+	xm1.re.Sub(&xm1.re, one)
+	sum.Add(sum, term.Quo(term, xm1))
+
+	// u(k) = x(x+1)···(x+2k-2)·N^(-x-2k+1), starting from u(1) = x·N^(-x-1).
+	u := new(Complex).SetPrec(work).Mul(x, pw)
+	u.re.Quo(&u.re, nf)
+	u.im.Quo(&u.im, nf)
+	n2 := new(big.Float).SetPrec(work).Mul(nf, nf)
+
+	bernoulli := bernoulliEven(int(work/2) + 16)
+	c := new(big.Float).SetPrec(work)
+	factorial := new(big.Float).SetPrec(work).SetInt64(1)
+	step := new(Complex).SetPrec(work)
+	for k := 1; k < len(bernoulli); k++ {
+		// c = B(2k)/(2k)!
+		factorial.Mul(factorial, new(big.Float).SetInt64(int64((2*k-1)*2*k)))
+		c.SetRat(bernoulli[k])
+		c.Quo(c, factorial)
+
+		term.re.Mul(&u.re, c)
+		term.im.Mul(&u.im, c)
+		sum.Add(sum, term)
+
+		if complexExponent(term) < max(complexExponent(sum), 0)-int(work) {
+			break
+		}
+
+		// u(k+1) = u(k)·(x+2k-1)(x+2k)/N²
+		step.Set(x)
+		step.re.Add(&step.re, new(big.Float).SetInt64(int64(2*k-1)))
+		u.Mul(u, step)
+		step.re.Add(&step.re, one)
+		u.Mul(u, step)
+		u.re.Quo(&u.re, n2)
+		u.im.Quo(&u.im, n2)
+	}
+
+	return sum
+}
+
+// zetaReflection returns ζ(x) for real(x) < 0 with the given precision
+// using the functional equation.
+func zetaReflection(x *Complex, work uint) *Complex {
+	y := new(Complex).SetPrec(work)
+	y.re.Sub(one, &x.re)
+	y.im.Neg(&x.im)
+
+	// Γ(1-x) and (2π)^x are large, so carry their magnitude as extra bits.
+	work += uint(max(0, bits.Len64(uint64(cmplx.Abs(y.Complex128())))))
+	result := zetaEulerMaclaurin(y, work)
+	result.Mul(result, new(Complex).SetPrec(work).Gamma(y))
+
+	half := new(Complex).SetPrec(work).Set(x)
+	half.re.Quo(&half.re, two)
+	half.im.Quo(&half.im, two)
+	result.Mul(result, sinPi(half, work))
+
+	// 2^x·π^(x-1) = e^(x·ln 2π)/π
+	ctx := context.Background()
+	pi := piCache.get(ctx, work)
+	logTwoPi := log2PiCache.get(ctx, work)
+	w := new(Complex).SetPrec(work)
+	w.re.Mul(&x.re, logTwoPi)
+	w.im.Mul(&x.im, logTwoPi)
+	result.Mul(result, w.Exp(w))
+	result.re.Quo(&result.re, pi)
+	result.im.Quo(&result.im, pi)
+
+	return result
+}
+
+// power sets z to n^-x = e^(-x·ln n) and returns z.
+func (z *Complex) power(x *Complex, n *big.Float) *Complex {
+	if n.Cmp(one) == 0 {
+		return z.SetComplex128(1)
+	}
+
+	logN := Log(n)
+	z.re.Mul(&x.re, logN)
+	z.re.Neg(&z.re)
+	z.im.Mul(&x.im, logN)
+	z.im.Neg(&z.im)
+
+	return z.Exp(z)
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigmath
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestZetaKnownValues(t *testing.T) {
+	const prec = 256
+
+	pi2 := ComputePi(prec)
+	pi2.Mul(pi2, pi2)
+	pi2.Quo(pi2, big.NewFloat(6))
+
+	tests := []struct {
+		x    complex128
+		want string
+	}{
+		{0.5, "-1.46035450880958681288949915251529801246722933101258149054289"},
+		{3, "1.20205690315959428539973816151144999076498629234049888179227"},
+		{0, "-0.5"},
+		{-1, "-0.0833333333333333333333333333333333333333333333333333333333333"},
+		{-7, "0.00416666666666666666666666666666666666666666666666666666666667"},
+	}
+
+	for _, test := range tests {
+		x := new(Complex).SetPrec(prec).SetComplex128(test.x)
+		want, _ := new(big.Float).SetPrec(prec).SetString(test.want)
+		checkComplexAgreement(t, fmt.Sprintf("Zeta(%v)", test.x), new(Complex).Zeta(x), NewComplex(want, new(big.Float)), 190)
+	}
+
+	// ζ(2) = π²/6
+	x := new(Complex).SetPrec(prec).SetComplex128(2)
+	checkComplexAgreement(t, "Zeta(2)", new(Complex).Zeta(x), NewComplex(pi2, new(big.Float)), prec)
+
+	// The trivial zeros.
+	for _, v := range []complex128{-2, -10} {
+		if got := new(Complex).Zeta(NewComplex128(v)); got.Abs().Sign() != 0 {
+			t.Errorf("Zeta(%v) = %v, want 0", v, got)
+		}
+	}
+}
+
+func TestZetaCriticalLine(t *testing.T) {
+	// The first non-trivial zero is at 1/2 + 14.1347251417346937904572519835624702707842571156992…i
+	// The digits below are good to about 460 bits.
+	for _, prec := range []uint{64, 256, 448} {
+		t1, _ := new(big.Float).SetPrec(prec).SetString("14.1347251417346937904572519835624702707842571156992431756855674601499634298092567649490103931715610127792029715487974367661426914698822545825")
+		got := ZetaCriticalLine(t1)
+		if got.Prec() != prec {
+			t.Errorf("ZetaCriticalLine has precision %d, want %d", got.Prec(), prec)
+		}
+		// |ζ'(1/2 + t₁i)| ≈ 0.79, so the result is about the error in t₁.
+		if e := got.Abs().MantExp(nil); e > -int(prec)+6 {
+			t.Errorf("ZetaCriticalLine(t₁) at %d bits = %s, want 0", prec, got.Text('g', 10))
+		}
+	}
+
+	// ζ(1/2 + 100i) = 2.69261988568132…  - 0.0203860296025982…i
+	got := ZetaCriticalLine(big.NewFloat(100)).Complex128()
+	if want := complex(2.692619885681324, -0.020386029602598162); !complexClose(got, want) {
+		t.Errorf("ZetaCriticalLine(100) = %v, want %v", got, want)
+	}
+}
+
+func TestZetaIdentities(t *testing.T) {
+	values := []complex128{complex(0.5, 30), complex(2, -3), complex(-0.5, 2), complex(-3.5, -7), complex(0.75, 0.1)}
+
+	for _, prec := range []uint{128, 512} {
+		for _, v := range values {
+			x := new(Complex).SetPrec(prec).SetComplex128(v)
+			z := new(Complex).Zeta(x)
+
+			// ζ(conj x) = conj ζ(x)
+			got := new(Complex).Zeta(new(Complex).Conj(x))
+			checkComplexAgreement(t, fmt.Sprintf("Zeta(conj %v)", v), got, new(Complex).Conj(z), prec-4)
+
+			// The Euler–Maclaurin sum also converges for real(x) < 0, which
+			// checks the functional equation.
+			em := zetaEulerMaclaurin(x, prec+64)
+			checkComplexAgreement(t, fmt.Sprintf("zetaEulerMaclaurin(%v)", v), em.SetPrec(prec), z, prec-8)
+		}
+	}
+}
+
+func TestZetaSpecialCases(t *testing.T) {
+	if got := new(Complex).Zeta(NewComplex128(1)); !got.Real().IsInf() {
+		t.Errorf("Zeta(1) = %v, want +Inf", got)
+	}
+	if got := new(Complex).Zeta(NewComplex128(complex(math.Inf(1), 0))).Complex128(); got != 1 {
+		t.Errorf("Zeta(+Inf) = %v, want 1", got)
+	}
+}
+
+func BenchmarkZetaCriticalLine(b *testing.B) {
+	for _, prec := range []uint{53, 256, 1000} {
+		b.Run(fmt.Sprintf("precision_%d", prec), func(b *testing.B) {
+			t := new(big.Float).SetPrec(prec).SetInt64(100)
+			for b.Loop() {
+				_ = ZetaCriticalLine(t)
+			}
+		})
+	}
+}